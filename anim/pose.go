@@ -0,0 +1,161 @@
+/*
+ * 姿势  每根骨骼相对父骨骼的TRS
+ *   混合: 平移/缩放线性, 旋转取最短路径的归一化线性插值
+ *   输出pose可以和输入是同一个
+ */
+package anim
+
+import (
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/transform"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 局部变换, 下标为骨骼下标
+type Pose []transform.Transform
+
+func NewPose(n int) Pose {
+	l_pose := make(Pose, n)
+	l_pose.Reset()
+	return l_pose
+}
+
+// 全部置为单位变换
+func (t Pose) Reset() {
+	for i := range t {
+		t[i] = transform.Ident
+	}
+}
+
+// 每根骨骼的混合权重[0,1], nil表示全为1; 非nil时长度需与pose一致
+type Mask []float32
+
+func (t Mask) Weight(bone int) float32 {
+	if t == nil {
+		return 1
+	}
+	return t[bone]
+}
+
+// a到b插值
+func LerpTransform(a, b *transform.Transform, f float32) transform.Transform {
+	return transform.Transform{
+		Translation: lerpVec3(&a.Translation, &b.Translation, f),
+		Rotation:    nlerpShortest(&a.Rotation, &b.Rotation, f),
+		Scale:       lerpVec3(&a.Scale, &b.Scale, f),
+	}
+}
+
+// out = a到b按weight插值, 每根骨骼的权重再乘mask
+func Blend(out, a, b Pose, weight float32, mask Mask) {
+	checkLen(len(out), len(a), len(b))
+	if mask != nil {
+		checkLen(len(out), len(mask))
+	}
+	for i := range out {
+		out[i] = LerpTransform(&a[i], &b[i], weight*mask.Weight(i))
+	}
+}
+
+// 多个pose加权混合, 权重会被归一化; 权重和为0时out不变
+func BlendWeighted(out Pose, poses []Pose, weights []float32) {
+	if len(poses) != len(weights) {
+		panic("anim: poses and weights length mismatch")
+	}
+	var sum float32
+	for i := range poses {
+		checkLen(len(out), len(poses[i]))
+		sum += weights[i]
+	}
+	if sum == 0 {
+		return
+	}
+	for bone := range out {
+		var l_res transform.Transform
+		for i := range poses {
+			w := weights[i] / sum
+			src := &poses[i][bone]
+			l_trans := src.Translation.Scaled(w)
+			l_res.Translation.Add(&l_trans)
+			l_scale := src.Scale.Scaled(w)
+			l_res.Scale.Add(&l_scale)
+			// 与第一个pose放到同一半球再累加
+			l_rot := src.Rotation
+			if i > 0 && quat.Dot(&l_rot, &poses[0][bone].Rotation) < 0 {
+				w = -w
+			}
+			l_res.Rotation.Add(l_rot.Scaled(w))
+		}
+		l_res.Rotation.Normalize()
+		out[bone] = l_res
+	}
+}
+
+// 叠加层 = pose相对ref的差
+// 平移为差, 旋转为 ref^-1 * pose, 缩放为比值(ref缩放为0的分量取1)
+func MakeAdditive(out, pose, ref Pose) {
+	checkLen(len(out), len(pose), len(ref))
+	for i := range out {
+		p, r := &pose[i], &ref[i]
+		var l_add transform.Transform
+		l_add.Translation = vector3.Sub(&p.Translation, &r.Translation)
+		l_inv := r.Rotation.Inversed()
+		l_add.Rotation = quat.Mul(&l_inv, &p.Rotation)
+		l_add.Rotation.Normalize()
+		for c := 0; c < 3; c++ {
+			if r.Scale[c] == 0 {
+				l_add.Scale[c] = 1
+			} else {
+				l_add.Scale[c] = p.Scale[c] / r.Scale[c]
+			}
+		}
+		out[i] = l_add
+	}
+}
+
+// out = base叠加additive (由MakeAdditive得到), 强度为weight*mask
+func ApplyAdditive(out, base, additive Pose, weight float32, mask Mask) {
+	checkLen(len(out), len(base), len(additive))
+	if mask != nil {
+		checkLen(len(out), len(mask))
+	}
+	for i := range out {
+		w := weight * mask.Weight(i)
+		b, a := &base[i], &additive[i]
+		var l_res transform.Transform
+		l_trans := a.Translation.Scaled(w)
+		l_res.Translation = vector3.Add(&b.Translation, &l_trans)
+		l_rot := nlerpShortest(&quat.Ident, &a.Rotation, w)
+		l_res.Rotation = quat.Mul(&b.Rotation, &l_rot)
+		l_res.Rotation.Normalize()
+		l_scale := lerpVec3(&vector3.UnitXYZ, &a.Scale, w)
+		l_res.Scale = vector3.Mul(&b.Scale, &l_scale)
+		out[i] = l_res
+	}
+}
+
+// 不截断f, 叠加层允许外插
+func lerpVec3(a, b *vector3.Vector, f float32) vector3.Vector {
+	return vector3.Vector{
+		a[0] + (b[0]-a[0])*f,
+		a[1] + (b[1]-a[1])*f,
+		a[2] + (b[2]-a[2])*f,
+	}
+}
+
+// 最短路径的归一化线性插值
+func nlerpShortest(a, b *quat.Quaternion, f float32) quat.Quaternion {
+	l_b := *b
+	if quat.Dot(a, b) < 0 {
+		l_b.Scale(-1)
+	}
+	return *quat.NLerp(a, &l_b, f)
+}
+
+func checkLen(n int, others ...int) {
+	for _, o := range others {
+		if o != n {
+			panic("anim: pose length mismatch")
+		}
+	}
+}
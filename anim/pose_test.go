@@ -0,0 +1,141 @@
+package anim
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+func samePose(t *testing.T, name string, got, want Pose) {
+	t.Helper()
+	for i := range want {
+		g, w := &got[i], &want[i]
+		if !g.Translation.ApproxEqual(&w.Translation, 1e-5) || !g.Scale.ApproxEqual(&w.Scale, 1e-5) ||
+			!g.Rotation.ApproxEqual(&w.Rotation, 1e-5) {
+			t.Errorf("%s: bone %d = %v, want %v", name, i, *g, *w)
+		}
+	}
+}
+
+func testPoses() (a, b Pose) {
+	a = NewPose(2)
+	a[0].Rotation = quat.FromZAxisAngle(0.3)
+	a[0].Scale = vector3.Vector{0.5, 1, 1}
+	b = Pose{
+		{Translation: vector3.Vector{1, 2, 3}, Rotation: quat.FromYAxisAngle(1.2), Scale: vector3.Vector{2, 1, 1}},
+		{Translation: vector3.Vector{0, 1, 0}, Rotation: quat.FromXAxisAngle(0.4), Scale: vector3.UnitXYZ},
+	}
+	return a, b
+}
+
+func TestBlend(t *testing.T) {
+	a, b := testPoses()
+	cases := []struct {
+		name   string
+		weight float32
+		mask   Mask
+		want   Pose
+	}{
+		{"a", 0, nil, a},
+		{"b", 1, nil, b},
+		{"mask", 1, Mask{1, 0}, Pose{b[0], a[1]}},
+		{"half", 0.5, nil, Pose{LerpTransform(&a[0], &b[0], 0.5), LerpTransform(&a[1], &b[1], 0.5)}},
+		{"half mask", 1, Mask{0.5, 0.5}, Pose{LerpTransform(&a[0], &b[0], 0.5), LerpTransform(&a[1], &b[1], 0.5)}},
+	}
+	for _, c := range cases {
+		out := NewPose(2)
+		Blend(out, a, b, c.weight, c.mask)
+		samePose(t, c.name, out, c.want)
+	}
+
+	// 输出可以是输入
+	out := append(Pose(nil), a...)
+	Blend(out, out, b, 1, nil)
+	samePose(t, "in place", out, b)
+
+	mid := LerpTransform(&a[1], &b[1], 0.5)
+	if want := quat.FromXAxisAngle(0.2); !mid.Rotation.ApproxEqual(&want, 1e-5) {
+		t.Errorf("LerpTransform rotation = %v, want %v", mid.Rotation, want)
+	}
+}
+
+func TestBlendWeighted(t *testing.T) {
+	a, b := testPoses()
+	out := NewPose(2)
+	BlendWeighted(out, []Pose{a, b}, []float32{1, 1})
+	samePose(t, "equal", out, Pose{LerpTransform(&a[0], &b[0], 0.5), LerpTransform(&a[1], &b[1], 0.5)})
+
+	// 权重归一化
+	BlendWeighted(out, []Pose{a, b}, []float32{0, 3})
+	samePose(t, "only b", out, b)
+
+	// 反半球的旋转先取反再累加
+	c := append(Pose(nil), b...)
+	for i := range c {
+		c[i].Rotation.Scale(-1)
+	}
+	BlendWeighted(out, []Pose{b, c}, []float32{1, 1})
+	samePose(t, "opposite hemisphere", out, b)
+
+	prev := append(Pose(nil), out...)
+	BlendWeighted(out, []Pose{a, b}, []float32{0, 0})
+	samePose(t, "zero weights", out, prev)
+}
+
+func TestAdditive(t *testing.T) {
+	ref, pose := testPoses()
+	add := NewPose(2)
+	MakeAdditive(add, pose, ref)
+
+	cases := []struct {
+		name   string
+		weight float32
+		mask   Mask
+		want   Pose
+	}{
+		{"full", 1, nil, pose},
+		{"none", 0, nil, ref},
+		{"mask", 1, Mask{0, 1}, Pose{ref[0], pose[1]}},
+	}
+	for _, c := range cases {
+		out := NewPose(2)
+		ApplyAdditive(out, ref, add, c.weight, c.mask)
+		samePose(t, c.name, out, c.want)
+	}
+
+	// ref缩放为0的分量取1
+	zero := NewPose(1)
+	zero[0].Scale = vector3.Vector{0, 1, 1}
+	one := NewPose(1)
+	one[0].Scale = vector3.Vector{3, 2, 1}
+	MakeAdditive(add[:1], one, zero)
+	if want := (vector3.Vector{1, 2, 1}); add[0].Scale != want {
+		t.Errorf("additive scale = %v, want %v", add[0].Scale, want)
+	}
+}
+
+func TestLengthMismatch(t *testing.T) {
+	a, b := testPoses()
+	add := NewPose(2)
+	cases := []struct {
+		name string
+		fn   func()
+	}{
+		{"Blend pose", func() { Blend(NewPose(3), a, b, 1, nil) }},
+		{"Blend mask", func() { Blend(NewPose(2), a, b, 1, Mask{1}) }},
+		{"ApplyAdditive mask", func() { ApplyAdditive(NewPose(2), a, add, 1, Mask{1, 1, 1}) }},
+		{"BlendWeighted", func() { BlendWeighted(NewPose(2), []Pose{a, b}, []float32{1}) }},
+		{"MakeAdditive", func() { MakeAdditive(NewPose(1), a, b) }},
+	}
+	for _, c := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", c.name)
+				}
+			}()
+			c.fn()
+		}()
+	}
+}
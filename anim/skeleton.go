@@ -0,0 +1,117 @@
+/*
+ * 骨架与动画片段
+ *   骨骼按父在前子在后排列, 一次顺序遍历即可求出模型空间矩阵
+ */
+package anim
+
+import (
+	"fmt"
+
+	"github.com/tinysss/smath/mat4"
+)
+
+type Skeleton struct {
+	Parents     []int       // 父骨骼下标, 根为-1
+	InverseBind []mat4.Mat4 // 绑定姿势下 骨骼->模型 的逆, 蒙皮用
+}
+
+func NewSkeleton(parents []int, inverseBind []mat4.Mat4) *Skeleton {
+	for i, p := range parents {
+		if p >= i {
+			panic(fmt.Sprintf("anim: bone %d has parent %d, parents must come first", i, p))
+		}
+	}
+	if inverseBind != nil && len(inverseBind) != len(parents) {
+		panic("anim: inverse bind length mismatch")
+	}
+	return &Skeleton{Parents: parents, InverseBind: inverseBind}
+}
+
+func (t *Skeleton) NumBones() int {
+	return len(t.Parents)
+}
+
+// root及其所有后代为weight, 其余为0
+func (t *Skeleton) BranchMask(root int, weight float32) Mask {
+	l_mask := make(Mask, len(t.Parents))
+	l_mask[root] = weight
+	for i := root + 1; i < len(t.Parents); i++ {
+		if p := t.Parents[i]; p >= 0 && l_mask[p] != 0 {
+			l_mask[i] = weight
+		}
+	}
+	return l_mask
+}
+
+// 局部->模型空间矩阵, model[i] = model[parent] * local[i]
+// out长度不够时重新分配
+func (t *Skeleton) ModelMatrices(pose Pose, out []mat4.Mat4) []mat4.Mat4 {
+	checkLen(len(t.Parents), len(pose))
+	if len(out) < len(pose) {
+		out = make([]mat4.Mat4, len(pose))
+	}
+	for i := range pose {
+		l_local := pose[i].Mat4()
+		if p := t.Parents[i]; p >= 0 {
+			out[i].AssignMul(&out[p], &l_local)
+		} else {
+			out[i] = l_local
+		}
+	}
+	return out[:len(pose)]
+}
+
+// 蒙皮矩阵 model[i] * InverseBind[i]
+func (t *Skeleton) SkinningPalette(pose Pose, out []mat4.Mat4) []mat4.Mat4 {
+	out = t.ModelMatrices(pose, out)
+	if t.InverseBind == nil {
+		return out
+	}
+	for i := range out {
+		l_model := out[i]
+		out[i].AssignMul(&l_model, &t.InverseBind[i])
+	}
+	return out
+}
+
+// 一根骨骼的轨道, 没有关键帧的轨道不参与采样
+type BoneTracks struct {
+	Translation Vec3Track
+	Rotation    QuatTrack
+	Scale       Vec3Track
+}
+
+type Clip struct {
+	Bones []BoneTracks // 下标为骨骼下标
+}
+
+// 所有轨道中最长的时间
+func (t *Clip) Duration() float32 {
+	var d float32
+	for i := range t.Bones {
+		b := &t.Bones[i]
+		for _, bd := range [...]float32{b.Translation.Duration(), b.Rotation.Duration(), b.Scale.Duration()} {
+			if bd > d {
+				d = bd
+			}
+		}
+	}
+	return d
+}
+
+// 采样到out, 没有关键帧的分量保留out原值
+func (t *Clip) Sample(time float32, out Pose) {
+	checkLen(len(out), len(t.Bones))
+	for i := range t.Bones {
+		b := &t.Bones[i]
+		if len(b.Translation.Keys) > 0 {
+			out[i].Translation = b.Translation.Sample(time)
+		}
+		if len(b.Rotation.Keys) > 0 {
+			out[i].Rotation = b.Rotation.Sample(time)
+		}
+		if len(b.Scale.Keys) > 0 {
+			out[i].Scale = b.Scale.Sample(time)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package anim
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestBranchMask(t *testing.T) {
+	sk := NewSkeleton([]int{-1, 0, 1, 0, 3, 2}, nil)
+	cases := []struct {
+		root int
+		want Mask
+	}{
+		{0, Mask{0.5, 0.5, 0.5, 0.5, 0.5, 0.5}},
+		{1, Mask{0, 0.5, 0.5, 0, 0, 0.5}},
+		{3, Mask{0, 0, 0, 0.5, 0.5, 0}},
+		{5, Mask{0, 0, 0, 0, 0, 0.5}},
+	}
+	for _, c := range cases {
+		got := sk.BranchMask(c.root, 0.5)
+		for i := range c.want {
+			if got[i] != c.want[i] {
+				t.Errorf("BranchMask(%d) = %v, want %v", c.root, got, c.want)
+				break
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewSkeleton with a child before its parent did not panic")
+		}
+	}()
+	NewSkeleton([]int{-1, 2, 0}, nil)
+}
+
+func TestModelMatrices(t *testing.T) {
+	pose := Pose{
+		{Translation: vector3.Vector{1, 0, 0}, Rotation: quat.FromZAxisAngle(1.5707964), Scale: vector3.UnitXYZ},
+		{Translation: vector3.Vector{2, 0, 0}, Rotation: quat.Ident, Scale: vector3.Vector{3, 3, 3}},
+		{Translation: vector3.Vector{0, 1, 0}, Rotation: quat.Ident, Scale: vector3.UnitXYZ},
+	}
+	sk := NewSkeleton([]int{-1, 0, 1}, nil)
+	mm := sk.ModelMatrices(pose, nil)
+	// 骨骼原点的模型空间位置
+	want := []vector3.Vector{{1, 0, 0}, {1, 2, 0}, {-2, 2, 0}}
+	for i, w := range want {
+		if got := mm[i].MulVec3(&vector3.Zero); !got.ApproxEqual(&w, 1e-5) {
+			t.Errorf("bone %d at %v, want %v", i, got, w)
+		}
+	}
+
+	// out够长时复用
+	buf := make([]mat4.Mat4, 4)
+	if out := sk.ModelMatrices(pose, buf); len(out) != 3 || &out[0] != &buf[0] {
+		t.Errorf("ModelMatrices did not reuse out")
+	}
+
+	// 绑定姿势下蒙皮矩阵为单位阵
+	inv := make([]mat4.Mat4, len(mm))
+	for i := range mm {
+		inv[i] = mm[i].Inverted()
+	}
+	sk.InverseBind = inv
+	for i, m := range sk.SkinningPalette(pose, nil) {
+		if !m.ApproxEqual(&mat4.Ident, 1e-4) {
+			t.Errorf("bind palette %d = %v", i, m)
+		}
+	}
+	// 根骨骼平移时所有蒙皮矩阵都是同一平移
+	moved := append(Pose(nil), pose...)
+	moved[0].Translation = vector3.Vector{1, 0, 5}
+	for i, m := range sk.SkinningPalette(moved, nil) {
+		if got := m.MulVec3(&vector3.Zero); !got.ApproxEqual(&vector3.Vector{0, 0, 5}, 1e-4) {
+			t.Errorf("moved palette %d translates by %v", i, got)
+		}
+	}
+}
+
+func TestClip(t *testing.T) {
+	c := Clip{Bones: []BoneTracks{
+		{Translation: Vec3Track{Keys: []Vec3Key{{Time: 0}, {Time: 2, Value: vector3.Vector{2, 0, 0}}}, Interp: Linear}},
+		{Rotation: QuatTrack{Keys: []QuatKey{{Time: 0, Value: quat.Ident}, {Time: 4, Value: quat.FromYAxisAngle(1)}}, Interp: Linear}},
+	}}
+	if d := c.Duration(); d != 4 {
+		t.Errorf("Duration = %v", d)
+	}
+	out := NewPose(2)
+	out[0].Scale = vector3.Vector{2, 2, 2}
+	c.Sample(1, out)
+	want := Pose{
+		{Translation: vector3.Vector{1, 0, 0}, Rotation: quat.Ident, Scale: vector3.Vector{2, 2, 2}},
+		{Rotation: quat.FromYAxisAngle(0.25), Scale: vector3.UnitXYZ},
+	}
+	samePose(t, "Sample", out, want)
+}
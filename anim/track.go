@@ -0,0 +1,168 @@
+/*
+ * 关键帧轨道  vector3(平移/缩放) quat(旋转)
+ *   关键帧按时间升序, 二分查找所在区间; 超出首尾时取首尾值
+ *   Cubic为三次Hermite, 切线单位为 值/秒 (与glTF CUBICSPLINE一致)
+ */
+package anim
+
+import (
+	"sort"
+
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 插值方式
+type Interp int
+
+const (
+	Step   Interp = iota // 保持前一帧的值
+	Linear               // 线性, 旋转为球面线性
+	Cubic                // 三次Hermite, 使用关键帧的In/Out切线
+)
+
+type Vec3Key struct {
+	Time  float32
+	Value vector3.Vector
+	In    vector3.Vector // 入切线, 只有Cubic使用
+	Out   vector3.Vector // 出切线
+}
+
+type Vec3Track struct {
+	Keys   []Vec3Key
+	Interp Interp
+}
+
+type QuatKey struct {
+	Time  float32
+	Value quat.Quaternion
+	In    quat.Quaternion // 入切线, 只有Cubic使用
+	Out   quat.Quaternion // 出切线
+}
+
+type QuatTrack struct {
+	Keys   []QuatKey
+	Interp Interp
+}
+
+// 最后一帧的时间
+func (t *Vec3Track) Duration() float32 {
+	if len(t.Keys) == 0 {
+		return 0
+	}
+	return t.Keys[len(t.Keys)-1].Time
+}
+
+// 空轨道返回零向量
+func (t *Vec3Track) Sample(time float32) vector3.Vector {
+	n := len(t.Keys)
+	if n == 0 {
+		return vector3.Zero
+	}
+	i, f := locate(n, func(i int) float32 { return t.Keys[i].Time }, time)
+	if i < 0 {
+		return t.Keys[0].Value
+	} else if i >= n-1 {
+		return t.Keys[n-1].Value
+	}
+	k0, k1 := &t.Keys[i], &t.Keys[i+1]
+	switch t.Interp {
+	case Step:
+		return k0.Value
+	case Cubic:
+		dt := k1.Time - k0.Time
+		h00, h10, h01, h11 := hermite(f)
+		var l_res vector3.Vector
+		for c := 0; c < 3; c++ {
+			l_res[c] = h00*k0.Value[c] + h10*dt*k0.Out[c] + h01*k1.Value[c] + h11*dt*k1.In[c]
+		}
+		return l_res
+	}
+	return vector3.Interpolate(&k0.Value, &k1.Value, f)
+}
+
+// 按Catmull-Rom计算所有关键帧的切线, 首尾用单侧差分
+func (t *Vec3Track) ComputeTangents() {
+	n := len(t.Keys)
+	for i := range t.Keys {
+		prev, next := i-1, i+1
+		if prev < 0 {
+			prev = 0
+		}
+		if next > n-1 {
+			next = n - 1
+		}
+		dt := t.Keys[next].Time - t.Keys[prev].Time
+		if dt <= 0 {
+			t.Keys[i].In, t.Keys[i].Out = vector3.Zero, vector3.Zero
+			continue
+		}
+		l_tan := vector3.Sub(&t.Keys[next].Value, &t.Keys[prev].Value)
+		l_tan.Scale(1 / dt)
+		t.Keys[i].In, t.Keys[i].Out = l_tan, l_tan
+	}
+}
+
+// 最后一帧的时间
+func (t *QuatTrack) Duration() float32 {
+	if len(t.Keys) == 0 {
+		return 0
+	}
+	return t.Keys[len(t.Keys)-1].Time
+}
+
+// 空轨道返回单位四元数
+func (t *QuatTrack) Sample(time float32) quat.Quaternion {
+	n := len(t.Keys)
+	if n == 0 {
+		return quat.Ident
+	}
+	i, f := locate(n, func(i int) float32 { return t.Keys[i].Time }, time)
+	if i < 0 {
+		return t.Keys[0].Value
+	} else if i >= n-1 {
+		return t.Keys[n-1].Value
+	}
+	k0, k1 := &t.Keys[i], &t.Keys[i+1]
+	switch t.Interp {
+	case Step:
+		return k0.Value
+	case Cubic:
+		// 逐分量Hermite后归一化
+		dt := k1.Time - k0.Time
+		h00, h10, h01, h11 := hermite(f)
+		var l_res quat.Quaternion
+		for c := 0; c < 4; c++ {
+			l_res[c] = h00*k0.Value[c] + h10*dt*k0.Out[c] + h01*k1.Value[c] + h11*dt*k1.In[c]
+		}
+		return l_res.Normalized()
+	}
+	return quat.SmartSlerp(&k0.Value, &k1.Value, f)
+}
+
+// 找到 time 所在区间 [i, i+1] 及区间内比例 f
+// time在第一帧之前返回-1, 在最后一帧及之后返回n-1
+func locate(n int, timeAt func(i int) float32, time float32) (i int, f float32) {
+	if time < timeAt(0) {
+		return -1, 0
+	}
+	// 第一个时间大于time的帧
+	j := sort.Search(n, func(k int) bool { return timeAt(k) > time })
+	i = j - 1
+	if i >= n-1 {
+		return n - 1, 0
+	}
+	t0, t1 := timeAt(i), timeAt(i+1)
+	return i, (time - t0) / (t1 - t0)
+}
+
+// Hermite基函数
+func hermite(f float32) (h00, h10, h01, h11 float32) {
+	f2 := f * f
+	f3 := f2 * f
+	h00 = 2*f3 - 3*f2 + 1
+	h10 = f3 - 2*f2 + f
+	h01 = -2*f3 + 3*f2
+	h11 = f3 - f2
+	return
+}
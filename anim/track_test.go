@@ -0,0 +1,88 @@
+package anim
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestVec3Track(t *testing.T) {
+	// 值为(time, 2*time, 0), Catmull-Rom切线能精确还原
+	keys := []Vec3Key{
+		{Time: 0, Value: vector3.Vector{0, 0, 0}},
+		{Time: 1, Value: vector3.Vector{1, 2, 0}},
+		{Time: 3, Value: vector3.Vector{3, 6, 0}},
+	}
+	cases := []struct {
+		interp Interp
+		time   float32
+		want   vector3.Vector
+	}{
+		{Step, -1, vector3.Vector{0, 0, 0}},
+		{Step, 0.5, vector3.Vector{0, 0, 0}},
+		{Step, 2, vector3.Vector{1, 2, 0}},
+		{Step, 5, vector3.Vector{3, 6, 0}},
+		{Linear, 0.5, vector3.Vector{0.5, 1, 0}},
+		{Linear, 1, vector3.Vector{1, 2, 0}},
+		{Linear, 2.5, vector3.Vector{2.5, 5, 0}},
+		{Linear, 3, vector3.Vector{3, 6, 0}},
+		{Cubic, 0.5, vector3.Vector{0.5, 1, 0}},
+		{Cubic, 2, vector3.Vector{2, 4, 0}},
+		{Cubic, -2, vector3.Vector{0, 0, 0}},
+	}
+	for _, c := range cases {
+		tr := Vec3Track{Keys: append([]Vec3Key(nil), keys...), Interp: c.interp}
+		tr.ComputeTangents()
+		if got := tr.Sample(c.time); !got.ApproxEqual(&c.want, 1e-5) {
+			t.Errorf("interp %d: Sample(%v) = %v, want %v", c.interp, c.time, got, c.want)
+		}
+		if d := tr.Duration(); d != 3 {
+			t.Errorf("Duration = %v", d)
+		}
+	}
+
+	// 切线为0时为smoothstep
+	tr := Vec3Track{Keys: []Vec3Key{{Time: 0}, {Time: 2, Value: vector3.Vector{4, 0, 0}}}, Interp: Cubic}
+	if got := tr.Sample(0.5); !got.ApproxEqual(&vector3.Vector{0.625, 0, 0}, 1e-5) {
+		t.Errorf("smoothstep Sample = %v", got)
+	}
+	var empty Vec3Track
+	if got := empty.Sample(1); got != vector3.Zero || empty.Duration() != 0 {
+		t.Errorf("empty Sample = %v", got)
+	}
+}
+
+func TestQuatTrack(t *testing.T) {
+	keys := []QuatKey{{Time: 0, Value: quat.Ident}, {Time: 2, Value: quat.FromYAxisAngle(1.2)}}
+	cases := []struct {
+		interp Interp
+		time   float32
+		want   quat.Quaternion
+	}{
+		{Step, 1, quat.Ident},
+		{Linear, 1, quat.FromYAxisAngle(0.6)},
+		{Linear, 0.5, quat.FromYAxisAngle(0.3)},
+		// 切线为0, 中点与nlerp一致
+		{Cubic, 1, quat.FromYAxisAngle(0.6)},
+		{Linear, 3, quat.FromYAxisAngle(1.2)},
+	}
+	for _, c := range cases {
+		tr := QuatTrack{Keys: keys, Interp: c.interp}
+		if got := tr.Sample(c.time); !got.ApproxEqual(&c.want, 1e-5) {
+			t.Errorf("interp %d: Sample(%v) = %v, want %v", c.interp, c.time, got, c.want)
+		}
+	}
+
+	// 走最短路径
+	neg := quat.FromYAxisAngle(0.4).Scaled(-1)
+	tr := QuatTrack{Keys: []QuatKey{{Time: 0, Value: quat.Ident}, {Time: 1, Value: neg}}, Interp: Linear}
+	got := tr.Sample(0.5)
+	if want := quat.FromYAxisAngle(0.2); !got.ApproxEqual(&want, 1e-5) {
+		t.Errorf("shortest Sample = %v, want %v", got, want)
+	}
+	var empty QuatTrack
+	if got := empty.Sample(1); got != quat.Ident {
+		t.Errorf("empty Sample = %v", got)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * 预乘alpha及混合模式  混合公式按W3C Compositing and Blending (source-over)
+ */
+package color
+
+type BlendMode int
+
+const (
+	BlendNormal BlendMode = iota
+	BlendMultiply
+	BlendScreen
+	BlendOverlay
+	BlendDarken
+	BlendLighten
+	BlendAdd // 结果截断到1
+	BlendDifference
+)
+
+// rgb乘以alpha
+func (t *RGBA) Premultiply() *RGBA {
+	t[0] *= t[3]
+	t[1] *= t[3]
+	t[2] *= t[3]
+	return t
+}
+
+func (t *RGBA) Premultiplied() RGBA {
+	r := *t
+	return *r.Premultiply()
+}
+
+// Premultiply的逆, alpha为0时rgb置0
+func (t *RGBA) Unpremultiply() *RGBA {
+	if t[3] == 0 {
+		*t = Transparent
+		return t
+	}
+	oo := 1 / t[3]
+	t[0] *= oo
+	t[1] *= oo
+	t[2] *= oo
+	return t
+}
+
+func (t *RGBA) Unpremultiplied() RGBA {
+	r := *t
+	return *r.Unpremultiply()
+}
+
+// 预乘颜色的source-over: src + dst*(1-src.a)
+func Over(dst, src *RGBA) RGBA {
+	k := 1 - src[3]
+	return RGBA{
+		src[0] + dst[0]*k,
+		src[1] + dst[1]*k,
+		src[2] + dst[2]*k,
+		src[3] + dst[3]*k,
+	}
+}
+
+// 非预乘颜色按mode混合后source-over合成, 返回非预乘颜色
+func Blend(dst, src *RGBA, mode BlendMode) RGBA {
+	as, ab := src[3], dst[3]
+	var res RGBA
+	for i := 0; i < 3; i++ {
+		cs, cb := src[i], dst[i]
+		// 与背景混合后的源颜色
+		mixed := (1-ab)*cs + ab*blendChannel(cb, cs, mode)
+		// 预乘合成
+		res[i] = as*mixed + ab*cb*(1-as)
+	}
+	res[3] = as + ab*(1-as)
+	return *res.Unpremultiply()
+}
+
+// 单通道混合函数B(cb, cs)
+func blendChannel(cb, cs float32, mode BlendMode) float32 {
+	switch mode {
+	case BlendMultiply:
+		return cb * cs
+	case BlendScreen:
+		return cb + cs - cb*cs
+	case BlendOverlay:
+		// 即交换参数的hard-light
+		if cb <= 0.5 {
+			return 2 * cb * cs
+		}
+		return 1 - 2*(1-cb)*(1-cs)
+	case BlendDarken:
+		if cb < cs {
+			return cb
+		}
+		return cs
+	case BlendLighten:
+		if cb > cs {
+			return cb
+		}
+		return cs
+	case BlendAdd:
+		if s := cb + cs; s < 1 {
+			return s
+		}
+		return 1
+	case BlendDifference:
+		if cb > cs {
+			return cb - cs
+		}
+		return cs - cb
+	}
+	return cs
+}
@@ -0,0 +1,75 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestPremultiply(t *testing.T) {
+	c := RGBA{0.8, 0.4, 0.2, 0.5}
+	p := c.Premultiplied()
+	if !rgbaNear(p, RGBA{0.4, 0.2, 0.1, 0.5}, 1e-6) {
+		t.Errorf("Premultiplied = %v", p)
+	}
+	if back := p.Unpremultiplied(); !rgbaNear(back, c, 1e-6) {
+		t.Errorf("Unpremultiplied = %v, want %v", back, c)
+	}
+	z := RGBA{0.3, 0.3, 0.3, 0}
+	if got := z.Unpremultiplied(); got != Transparent {
+		t.Errorf("Unpremultiply alpha 0 = %v", got)
+	}
+}
+
+func TestOver(t *testing.T) {
+	dst := RGBA{1, 0, 0, 1}
+	src := RGBA{0, 0, 1, 0.5}
+	pd, ps := dst.Premultiplied(), src.Premultiplied()
+	got := Over(&pd, &ps)
+	if !rgbaNear(got, RGBA{0.5, 0, 0.5, 1}, 1e-6) {
+		t.Errorf("Over = %v", got)
+	}
+	// 与BlendNormal一致
+	if n := Blend(&dst, &src, BlendNormal); !rgbaNear(n, got.Unpremultiplied(), 1e-6) {
+		t.Errorf("BlendNormal = %v, Over = %v", n, got)
+	}
+	// 半透明叠半透明
+	d2 := RGBA{1, 0, 0, 0.5}
+	pd2 := d2.Premultiplied()
+	o2 := Over(&pd2, &ps)
+	if !rgbaNear(o2, RGBA{0.25, 0, 0.5, 0.75}, 1e-6) {
+		t.Errorf("Over translucent = %v", o2)
+	}
+}
+
+func TestBlend(t *testing.T) {
+	dst := RGBA{0.25, 0.5, 0.75, 1}
+	src := RGBA{0.5, 0.5, 0.5, 1}
+	cases := []struct {
+		mode BlendMode
+		want RGBA
+	}{
+		{BlendNormal, RGBA{0.5, 0.5, 0.5, 1}},
+		{BlendMultiply, RGBA{0.125, 0.25, 0.375, 1}},
+		{BlendScreen, RGBA{0.625, 0.75, 0.875, 1}},
+		{BlendOverlay, RGBA{0.25, 0.5, 0.75, 1}},
+		{BlendDarken, RGBA{0.25, 0.5, 0.5, 1}},
+		{BlendLighten, RGBA{0.5, 0.5, 0.75, 1}},
+		{BlendAdd, RGBA{0.75, 1, 1, 1}},
+		{BlendDifference, RGBA{0.25, 0, 0.25, 1}},
+	}
+	for _, c := range cases {
+		if got := Blend(&dst, &src, c.mode); !rgbaNear(got, c.want, 1e-6) {
+			t.Errorf("mode %d: %v, want %v", c.mode, got, c.want)
+		}
+	}
+
+	// 源半透明时为混合结果与背景的插值
+	half := RGBA{0.5, 0.5, 0.5, 0.5}
+	if got := Blend(&dst, &half, BlendMultiply); !rgbaNear(got, RGBA{0.1875, 0.375, 0.5625, 1}, 1e-6) {
+		t.Errorf("translucent multiply = %v", got)
+	}
+	// 背景透明时混合模式不起作用
+	clear := RGBA{0.25, 0.5, 0.75, 0}
+	if got := Blend(&clear, &src, BlendMultiply); !rgbaNear(got, src, 1e-6) {
+		t.Errorf("multiply over transparent = %v", got)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * 颜色  RGB基于vector3, RGBA基于vector4, 分量范围[0,1]
+ *   类型本身不记录颜色空间, 转换函数的注释说明输入输出是sRGB还是线性
+ */
+package color
+
+import (
+	"math"
+
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+	"github.com/tinysss/smath/vector4"
+)
+
+type RGB vector3.Vector
+
+// 非预乘alpha, 预乘见Premultiply
+type RGBA vector4.Vector
+
+var (
+	Black = RGB{0, 0, 0}
+	White = RGB{1, 1, 1}
+
+	Transparent = RGBA{0, 0, 0, 0}
+)
+
+func FromVec3(v *vector3.Vector) RGB {
+	return RGB(*v)
+}
+
+func FromVec4(v *vector4.Vector) RGBA {
+	return RGBA(*v)
+}
+
+func (t *RGB) Vec3() vector3.Vector {
+	return vector3.Vector(*t)
+}
+
+func (t *RGBA) Vec4() vector4.Vector {
+	return vector4.Vector(*t)
+}
+
+func (t *RGB) RGBA(alpha float32) RGBA {
+	return RGBA{t[0], t[1], t[2], alpha}
+}
+
+func (t *RGBA) RGB() RGB {
+	return RGB{t[0], t[1], t[2]}
+}
+
+func (t *RGB) Clamp01() *RGB {
+	for i := range t {
+		t[i] = sutil.Clamp(t[i], 0, 1)
+	}
+	return t
+}
+
+func (t *RGBA) Clamp01() *RGBA {
+	for i := range t {
+		t[i] = sutil.Clamp(t[i], 0, 1)
+	}
+	return t
+}
+
+// 线性空间下的相对亮度 (Rec.709)
+func (t *RGB) Luminance() float32 {
+	return 0.2126*t[0] + 0.7152*t[1] + 0.0722*t[2]
+}
+
+func Lerp(a, b *RGBA, t float32) RGBA {
+	return RGBA(vector4.Interpolate((*vector4.Vector)(a), (*vector4.Vector)(b), t))
+}
+
+//-------------------------------------------- sRGB ------------------------------------------------
+
+// sRGB分量 -> 线性
+func SRGBToLinear(c float32) float32 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return float32(math.Pow((float64(c)+0.055)/1.055, 2.4))
+}
+
+// 线性分量 -> sRGB
+func LinearToSRGB(c float32) float32 {
+	if c <= 0.0031308 {
+		return c * 12.92
+	}
+	return float32(1.055*math.Pow(float64(c), 1/2.4) - 0.055)
+}
+
+// t为sRGB, 返回线性
+func (t *RGB) Linear() RGB {
+	return RGB{SRGBToLinear(t[0]), SRGBToLinear(t[1]), SRGBToLinear(t[2])}
+}
+
+// t为线性, 返回sRGB
+func (t *RGB) SRGB() RGB {
+	return RGB{LinearToSRGB(t[0]), LinearToSRGB(t[1]), LinearToSRGB(t[2])}
+}
+
+// alpha不变
+func (t *RGBA) Linear() RGBA {
+	return RGBA{SRGBToLinear(t[0]), SRGBToLinear(t[1]), SRGBToLinear(t[2]), t[3]}
+}
+
+// alpha不变
+func (t *RGBA) SRGB() RGBA {
+	return RGBA{LinearToSRGB(t[0]), LinearToSRGB(t[1]), LinearToSRGB(t[2]), t[3]}
+}
+
+//-------------------------------------------- 打包 ------------------------------------------------
+
+// 低位到高位依次为R,G,B,A各8位 (小端内存顺序即RGBA), 超出[0,1]的分量截断
+func (t *RGBA) PackRGBA8() uint32 {
+	return unorm(t[0], 255) | unorm(t[1], 255)<<8 | unorm(t[2], 255)<<16 | unorm(t[3], 255)<<24
+}
+
+func UnpackRGBA8(u uint32) RGBA {
+	return RGBA{
+		float32(u&0xff) / 255,
+		float32(u>>8&0xff) / 255,
+		float32(u>>16&0xff) / 255,
+		float32(u>>24) / 255,
+	}
+}
+
+// 低位到高位依次为R,G,B各10位, A 2位 (GL_UNSIGNED_INT_2_10_10_10_REV / R10G10B10A2_UNORM)
+func (t *RGBA) PackRGB10A2() uint32 {
+	return unorm(t[0], 1023) | unorm(t[1], 1023)<<10 | unorm(t[2], 1023)<<20 | unorm(t[3], 3)<<30
+}
+
+func UnpackRGB10A2(u uint32) RGBA {
+	return RGBA{
+		float32(u&0x3ff) / 1023,
+		float32(u>>10&0x3ff) / 1023,
+		float32(u>>20&0x3ff) / 1023,
+		float32(u>>30) / 3,
+	}
+}
+
+// [0,1] -> [0,max] 四舍五入
+func unorm(c float32, max float32) uint32 {
+	return uint32(sutil.Clamp(c, 0, 1)*max + 0.5)
+}
@@ -0,0 +1,177 @@
+package color
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+	"github.com/tinysss/smath/vector4"
+)
+
+func rgbNear(a, b RGB, tol float32) bool {
+	va, vb := a.Vec3(), b.Vec3()
+	return va.ApproxEqual(&vb, tol)
+}
+
+func rgbaNear(a, b RGBA, tol float32) bool {
+	va, vb := a.Vec4(), b.Vec4()
+	return va.ApproxEqual(&vb, tol)
+}
+
+func TestSRGB(t *testing.T) {
+	cases := []struct {
+		srgb, linear float32
+	}{
+		{0, 0},
+		{1, 1},
+		{0.04045, 0.0031308},
+		{0.5, 0.21404114},
+		{0.2, 0.033104767},
+	}
+	for _, c := range cases {
+		if got := SRGBToLinear(c.srgb); !sutil.AlmostEqual(got, c.linear, 1e-6, 1e-5) {
+			t.Errorf("SRGBToLinear(%v) = %v, want %v", c.srgb, got, c.linear)
+		}
+		if got := LinearToSRGB(c.linear); !sutil.AlmostEqual(got, c.srgb, 1e-6, 1e-5) {
+			t.Errorf("LinearToSRGB(%v) = %v, want %v", c.linear, got, c.srgb)
+		}
+	}
+	c := RGBA{0.2, 0.5, 0.9, 0.3}
+	lin := c.Linear()
+	if lin[3] != 0.3 {
+		t.Errorf("Linear changed alpha: %v", lin)
+	}
+	if back := lin.SRGB(); !rgbaNear(back, c, 1e-5) {
+		t.Errorf("SRGB(Linear(c)) = %v, want %v", back, c)
+	}
+}
+
+func TestHSVHSL(t *testing.T) {
+	cases := []struct {
+		rgb RGB
+		hsv HSV
+		hsl HSL
+	}{
+		{RGB{1, 0, 0}, HSV{0, 1, 1}, HSL{0, 1, 0.5}},
+		{RGB{0, 1, 0}, HSV{120, 1, 1}, HSL{120, 1, 0.5}},
+		{RGB{0, 0.5, 1}, HSV{210, 1, 1}, HSL{210, 1, 0.5}},
+		{RGB{1, 0, 0.5}, HSV{330, 1, 1}, HSL{330, 1, 0.5}},
+		{RGB{0.5, 0.25, 0.25}, HSV{0, 0.5, 0.5}, HSL{0, 1.0 / 3, 0.375}},
+		{RGB{0.5, 0.5, 0.5}, HSV{0, 0, 0.5}, HSL{0, 0, 0.5}},
+		{Black, HSV{0, 0, 0}, HSL{0, 0, 0}},
+		{White, HSV{0, 0, 1}, HSL{0, 0, 1}},
+	}
+	near := func(a, b [3]float32) bool {
+		for i := range a {
+			if !sutil.AlmostEqual(a[i], b[i], 1e-5, 1e-5) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, c := range cases {
+		hsv := c.rgb.ToHSV()
+		if !near([3]float32{hsv.H, hsv.S, hsv.V}, [3]float32{c.hsv.H, c.hsv.S, c.hsv.V}) {
+			t.Errorf("ToHSV(%v) = %v, want %v", c.rgb, hsv, c.hsv)
+		}
+		hsl := c.rgb.ToHSL()
+		if !near([3]float32{hsl.H, hsl.S, hsl.L}, [3]float32{c.hsl.H, c.hsl.S, c.hsl.L}) {
+			t.Errorf("ToHSL(%v) = %v, want %v", c.rgb, hsl, c.hsl)
+		}
+		if back := c.hsv.ToRGB(); !rgbNear(back, c.rgb, 1e-5) {
+			t.Errorf("HSV %v ToRGB = %v, want %v", c.hsv, back, c.rgb)
+		}
+		if back := c.hsl.ToRGB(); !rgbNear(back, c.rgb, 1e-5) {
+			t.Errorf("HSL %v ToRGB = %v, want %v", c.hsl, back, c.rgb)
+		}
+	}
+	// 色相超出[0,360)时取模
+	h := HSV{-150, 1, 1}
+	if got := h.ToRGB(); !rgbNear(got, RGB{0, 0.5, 1}, 1e-5) {
+		t.Errorf("HSV hue -150 = %v", got)
+	}
+}
+
+func TestOklab(t *testing.T) {
+	// 参考值来自Ottosson的文章
+	cases := []struct {
+		rgb RGB
+		lab Oklab
+	}{
+		{White, Oklab{1, 0, 0}},
+		{RGB{1, 0, 0}, Oklab{0.6279554, 0.22486306, 0.1258463}},
+		{RGB{0, 1, 0}, Oklab{0.8664396, -0.2338874, 0.1794985}},
+		{RGB{0, 0, 1}, Oklab{0.4520137, -0.0324570, -0.3115281}},
+	}
+	for _, c := range cases {
+		lab := c.rgb.ToOklab()
+		got := vector3.Vector{lab.L, lab.A, lab.B}
+		want := vector3.Vector{c.lab.L, c.lab.A, c.lab.B}
+		if !got.ApproxEqual(&want, 1e-4) {
+			t.Errorf("ToOklab(%v) = %v, want %v", c.rgb, lab, c.lab)
+		}
+		if back := lab.ToRGB(); !rgbNear(back, c.rgb, 1e-4) {
+			t.Errorf("Oklab round trip %v = %v", c.rgb, back)
+		}
+	}
+	a, b := RGB{1, 0, 0}, RGB{0, 0, 1}
+	if got := LerpOklab(&a, &b, 0); !rgbNear(got, a, 1e-4) {
+		t.Errorf("LerpOklab(0) = %v", got)
+	}
+	if got := LerpOklab(&a, &b, 1); !rgbNear(got, b, 1e-4) {
+		t.Errorf("LerpOklab(1) = %v", got)
+	}
+}
+
+func TestPack(t *testing.T) {
+	cases := []struct {
+		c              RGBA
+		rgba8, rgb10a2 uint32
+	}{
+		{RGBA{0, 0, 0, 0}, 0, 0},
+		{RGBA{1, 1, 1, 1}, 0xffffffff, 0xffffffff},
+		{RGBA{1, 0, 0, 1}, 0xff0000ff, 0xc00003ff},
+		{RGBA{0, 0, 1, 0}, 0x00ff0000, 0x3ff00000},
+		{RGBA{0.5, 0.25, 0, 1}, 0xff004080, 0xc0040200},
+		{RGBA{2, -1, 0, 1}, 0xff0000ff, 0xc00003ff}, // 截断
+	}
+	for _, c := range cases {
+		if got := c.c.PackRGBA8(); got != c.rgba8 {
+			t.Errorf("PackRGBA8(%v) = %#x, want %#x", c.c, got, c.rgba8)
+		}
+		if got := c.c.PackRGB10A2(); got != c.rgb10a2 {
+			t.Errorf("PackRGB10A2(%v) = %#x, want %#x", c.c, got, c.rgb10a2)
+		}
+	}
+	c := RGBA{0.2, 0.4, 0.6, 0.8}
+	if got := UnpackRGBA8(c.PackRGBA8()); !rgbaNear(got, c, 0.5/255) {
+		t.Errorf("RGBA8 round trip = %v", got)
+	}
+	c[3] = 2.0 / 3
+	if got := UnpackRGB10A2(c.PackRGB10A2()); !rgbaNear(got, c, 0.5/1023) {
+		t.Errorf("RGB10A2 round trip = %v", got)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	v := vector4.Vector{0.1, 0.2, 0.3, 0.4}
+	c := FromVec4(&v)
+	if c.Vec4() != v {
+		t.Errorf("Vec4 round trip")
+	}
+	rgb := c.RGB()
+	if rgb.RGBA(0.4) != c {
+		t.Errorf("RGB/RGBA round trip")
+	}
+	over := RGBA{2, -1, 0.5, 1.5}
+	if over.Clamp01(); over != (RGBA{1, 0, 0.5, 1}) {
+		t.Errorf("Clamp01 = %v", over)
+	}
+	if l := White.Luminance(); !sutil.AlmostEqual(l, 1, 1e-6, 0) {
+		t.Errorf("white luminance %v", l)
+	}
+	a, b := RGBA{0, 0, 0, 0}, RGBA{1, 1, 1, 1}
+	if got := Lerp(&a, &b, 0.25); !rgbaNear(got, RGBA{0.25, 0.25, 0.25, 0.25}, 1e-6) {
+		t.Errorf("Lerp = %v", got)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+ * 颜色矩阵  mat4作用于(r,g,b,a), 第4列可作偏移
+ *   饱和度/色相矩阵不改变alpha, 用于线性RGB
+ */
+package color
+
+import (
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector4"
+)
+
+// 灰度权重 (Rec.709)
+const (
+	kLumR = 0.2126
+	kLumG = 0.7152
+	kLumB = 0.0722
+)
+
+// m * (r,g,b,a)
+func (t *RGBA) Transform(m *mat4.Mat4) RGBA {
+	return RGBA(m.MulVec4((*vector4.Vector)(t)))
+}
+
+// m * (r,g,b,1), 取rgb
+func (t *RGB) Transform(m *mat4.Mat4) RGB {
+	v := vector4.Vector{t[0], t[1], t[2], 1}
+	v = m.MulVec4(&v)
+	return RGB{v[0], v[1], v[2]}
+}
+
+// s=0为灰度, 1不变, >1增强
+func SaturationMatrix(s float32) mat4.Mat4 {
+	k := 1 - s
+	r, g, b := k*kLumR, k*kLumG, k*kLumB
+	return mat4.Mat4{
+		{r + s, r, r, 0},
+		{g, g + s, g, 0},
+		{b, b, b + s, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// 绕灰轴(1,1,1)旋转色相, 灰色不变
+func HueRotationMatrix(angle sutil.Angle) mat4.Mat4 {
+	sa, ca := angle.Rad().Sincos()
+	// Rodrigues: cI + (1-c)aa^T + s[a]x, a = (1,1,1)/sqrt(3)
+	k := (1 - ca) / 3
+	s := sa * 0.57735026919 // 1/sqrt(3)
+	d := ca + k
+	return mat4.Mat4{
+		{d, k + s, k - s, 0},
+		{k - s, d, k + s, 0},
+		{k + s, k - s, d, 0},
+		{0, 0, 0, 1},
+	}
+}
@@ -0,0 +1,58 @@
+package color
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/sutil"
+)
+
+func TestSaturation(t *testing.T) {
+	c := RGB{0.8, 0.3, 0.1}
+	gray := SaturationMatrix(0)
+	g := c.Transform(&gray)
+	l := c.Luminance()
+	if !rgbNear(g, RGB{l, l, l}, 1e-6) {
+		t.Errorf("saturation 0 = %v, want gray %v", g, l)
+	}
+	id := SaturationMatrix(1)
+	if !id.ApproxEqual(&mat4.Ident, 1e-7) {
+		t.Errorf("saturation 1 = %v", id)
+	}
+	// 任意饱和度不改变亮度
+	more := SaturationMatrix(1.5)
+	m := c.Transform(&more)
+	if !sutil.AlmostEqual(m.Luminance(), l, 1e-6, 1e-6) {
+		t.Errorf("saturation changed luminance %v -> %v", l, m.Luminance())
+	}
+	a := RGBA{0.8, 0.3, 0.1, 0.4}
+	if got := a.Transform(&gray); got[3] != 0.4 {
+		t.Errorf("saturation changed alpha: %v", got)
+	}
+}
+
+func TestHueRotation(t *testing.T) {
+	cases := []struct {
+		angle    sutil.Angle
+		in, want RGB
+	}{
+		{sutil.Degrees(0), RGB{0.8, 0.3, 0.1}, RGB{0.8, 0.3, 0.1}},
+		{sutil.Degrees(120), RGB{1, 0, 0}, RGB{0, 1, 0}},
+		{sutil.Degrees(240), RGB{1, 0, 0}, RGB{0, 0, 1}},
+		{sutil.Radians(2 * sutil.KPi / 3), RGB{0, 0, 1}, RGB{1, 0, 0}},
+		{sutil.Degrees(77), RGB{0.5, 0.5, 0.5}, RGB{0.5, 0.5, 0.5}},
+	}
+	for _, c := range cases {
+		m := HueRotationMatrix(c.angle)
+		if got := c.in.Transform(&m); !rgbNear(got, c.want, 1e-5) {
+			t.Errorf("hue %v of %v = %v, want %v", c.angle, c.in, got, c.want)
+		}
+	}
+	// 与HSV色相偏移方向一致
+	m := HueRotationMatrix(sutil.Degrees(60))
+	red := RGB{1, 0, 0}
+	got := red.Transform(&m)
+	if h := got.ToHSV().H; h < 1 || h > 119 {
+		t.Errorf("rotating red by +60 gives hue %v", h)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * 颜色空间转换  HSV / HSL / Oklab
+ *   HSV,HSL的H为角度[0,360), 其余分量[0,1], 与输入RGB处于同一空间(通常为sRGB)
+ *   Oklab输入输出为线性RGB
+ */
+package color
+
+import (
+	"math"
+
+	"github.com/tinysss/smath/sutil"
+)
+
+type HSV struct {
+	H, S, V float32
+}
+
+type HSL struct {
+	H, S, L float32
+}
+
+type Oklab struct {
+	L, A, B float32
+}
+
+// 最大最小分量及色相
+func (t *RGB) hue() (h, max, min float32) {
+	r, g, b := t[0], t[1], t[2]
+	max = float32(math.Max(float64(r), math.Max(float64(g), float64(b))))
+	min = float32(math.Min(float64(r), math.Min(float64(g), float64(b))))
+	d := max - min
+	if d == 0 {
+		return 0, max, min
+	}
+	switch max {
+	case r:
+		h = (g - b) / d
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return sutil.Mod(h*60, 360), max, min
+}
+
+func (t *RGB) ToHSV() HSV {
+	h, max, min := t.hue()
+	var s float32
+	if max > 0 {
+		s = (max - min) / max
+	}
+	return HSV{h, s, max}
+}
+
+func (t *HSV) ToRGB() RGB {
+	return hueToRGB(t.H, t.V*t.S, t.V-t.V*t.S)
+}
+
+func (t *RGB) ToHSL() HSL {
+	h, max, min := t.hue()
+	l := (max + min) * 0.5
+	var s float32
+	if d := max - min; d > 0 {
+		s = d / (1 - sutil.Abs(2*l-1))
+	}
+	return HSL{h, s, l}
+}
+
+func (t *HSL) ToRGB() RGB {
+	c := (1 - sutil.Abs(2*t.L-1)) * t.S
+	return hueToRGB(t.H, c, t.L-c*0.5)
+}
+
+// 色相h, 色度c, 最小分量m
+func hueToRGB(h, c, m float32) RGB {
+	hp := sutil.Mod(h, 360) / 60
+	x := c * (1 - sutil.Abs(sutil.Mod(hp, 2)-1))
+	var r, g, b float32
+	switch {
+	case hp < 1:
+		r, g, b = c, x, 0
+	case hp < 2:
+		r, g, b = x, c, 0
+	case hp < 3:
+		r, g, b = 0, c, x
+	case hp < 4:
+		r, g, b = 0, x, c
+	case hp < 5:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return RGB{r + m, g + m, b + m}
+}
+
+// 线性RGB -> Oklab (Björn Ottosson 2020)
+func (t *RGB) ToOklab() Oklab {
+	l := 0.4122214708*t[0] + 0.5363325363*t[1] + 0.0514459929*t[2]
+	m := 0.2119034982*t[0] + 0.6806995451*t[1] + 0.1073969566*t[2]
+	s := 0.0883024619*t[0] + 0.2817188376*t[1] + 0.6299787005*t[2]
+
+	l = float32(math.Cbrt(float64(l)))
+	m = float32(math.Cbrt(float64(m)))
+	s = float32(math.Cbrt(float64(s)))
+
+	return Oklab{
+		0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// Oklab -> 线性RGB, 结果可能超出[0,1]
+func (t *Oklab) ToRGB() RGB {
+	l := t.L + 0.3963377774*t.A + 0.2158037573*t.B
+	m := t.L - 0.1055613458*t.A - 0.0638541728*t.B
+	s := t.L - 0.0894841775*t.A - 1.2914855480*t.B
+
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	return RGB{
+		4.0767416621*l - 3.3077115913*m + 0.2309699292*s,
+		-1.2684380046*l + 2.6097574011*m - 0.3413193965*s,
+		-0.0041960863*l - 0.7034186147*m + 1.7076147010*s,
+	}
+}
+
+// Oklab空间插值, 感知上比RGB插值均匀
+func LerpOklab(a, b *RGB, t float32) RGB {
+	la, lb := a.ToOklab(), b.ToOklab()
+	l := Oklab{
+		sutil.Lerp(la.L, lb.L, t),
+		sutil.Lerp(la.A, lb.A, t),
+		sutil.Lerp(la.B, lb.B, t),
+	}
+	return l.ToRGB()
+}
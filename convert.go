@@ -10,6 +10,7 @@ package smath
 import (
 	math "github.com/barnex/fmath"
 	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/mat4"
 	"github.com/tinysss/smath/quat"
 )
 
@@ -76,3 +77,24 @@ func Mat3ToQuat(mat3 *mat3.Mat3) quat.Quaternion {
 
 	return l_quat.Normalized()
 }
+
+// quat ->mat4, 平移为0
+func QuatToMat4(quat *quat.Quaternion) mat4.Mat4 {
+	l_m3 := QuatToMat3(quat)
+	return mat4.Mat4{
+		{l_m3[0][0], l_m3[0][1], l_m3[0][2], 0},
+		{l_m3[1][0], l_m3[1][1], l_m3[1][2], 0},
+		{l_m3[2][0], l_m3[2][1], l_m3[2][2], 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// mat4 ->　quat, 只取左上3x3 (需为无缩放的旋转)
+func Mat4ToQuat(mat4 *mat4.Mat4) quat.Quaternion {
+	l_m3 := mat3.Mat3{
+		{mat4[0][0], mat4[0][1], mat4[0][2]},
+		{mat4[1][0], mat4[1][1], mat4[1][2]},
+		{mat4[2][0], mat4[2][1], mat4[2][2]},
+	}
+	return Mat3ToQuat(&l_m3)
+}
@@ -0,0 +1,50 @@
+package smath
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestQuatToMat4(t *testing.T) {
+	tests := []struct {
+		name string
+		q    quat.Quaternion
+	}{
+		{"ident", quat.Ident},
+		{"x", quat.FromXAxisAngle(0.7)},
+		{"z 90", quat.FromZAxisAngle(1.5707964)},
+		{"oblique", quat.FromAxisAngle(&vector3.Vector{1, 2, -3}, 2.5)},
+	}
+	v := vector3.Vector{0.3, -1, 2}
+	for _, tt := range tests {
+		m := QuatToMat4(&tt.q)
+		// 与四元数旋转一致, 平移为0
+		got := m.MulVec3(&v)
+		want := tt.q.RotatedVec3(&v)
+		if !got.ApproxEqual(&want, 1e-5) {
+			t.Errorf("%s: QuatToMat4 * v = %v, want %v", tt.name, got, want)
+		}
+		if m[3] != mat4.Ident[3] || m[0][3] != 0 || m[1][3] != 0 || m[2][3] != 0 {
+			t.Errorf("%s: QuatToMat4 not affine rotation: %v", tt.name, m)
+		}
+		// 往返, q与-q等价
+		back := Mat4ToQuat(&m)
+		neg := tt.q.Scaled(-1)
+		if !back.ApproxEqual(&tt.q, 1e-5) && !back.ApproxEqual(&neg, 1e-5) {
+			t.Errorf("%s: Mat4ToQuat = %v, want %v", tt.name, back, tt.q)
+		}
+	}
+}
+
+// Mat4ToQuat只看左上3x3, 平移不影响结果
+func TestMat4ToQuatIgnoresTranslation(t *testing.T) {
+	q := quat.FromYAxisAngle(1.1)
+	m := QuatToMat4(&q)
+	m[3][0], m[3][1], m[3][2] = 5, -6, 7
+	if got := Mat4ToQuat(&m); !got.ApproxEqual(&q, 1e-5) {
+		t.Errorf("Mat4ToQuat = %v, want %v", got, q)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ * 对偶四元数  刚体变换(旋转+平移), 用于蒙皮避免"糖纸"扭曲
+ *   q = Real + ε Dual, Real为旋转, Dual = 0.5 * t * Real (t为纯四元数平移)
+ */
+package dquat
+
+import (
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath"
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+type DualQuaternion struct {
+	Real quat.Quaternion
+	Dual quat.Quaternion
+}
+
+var (
+	Ident = DualQuaternion{Real: quat.Ident}
+)
+
+// 先旋转rot再平移trans
+func FromRotationTranslation(rot *quat.Quaternion, trans *vector3.Vector) DualQuaternion {
+	l_real := rot.Normalized()
+	l_t := quat.Quaternion{trans[0], trans[1], trans[2], 0}
+	l_dual := quat.Mul(&l_t, &l_real)
+	return DualQuaternion{l_real, l_dual.Scaled(0.5)}
+}
+
+func FromRotation(rot *quat.Quaternion) DualQuaternion {
+	return DualQuaternion{Real: rot.Normalized()}
+}
+
+func FromTranslation(trans *vector3.Vector) DualQuaternion {
+	return DualQuaternion{
+		Real: quat.Ident,
+		Dual: quat.Quaternion{trans[0] * 0.5, trans[1] * 0.5, trans[2] * 0.5, 0},
+	}
+}
+
+// 左上3x3需为无缩放的旋转
+func FromMat4(m *mat4.Mat4) DualQuaternion {
+	l_rot := smath.Mat4ToQuat(m)
+	l_trans := vector3.Vector{m[3][0], m[3][1], m[3][2]}
+	return FromRotationTranslation(&l_rot, &l_trans)
+}
+
+func (t *DualQuaternion) Rotation() quat.Quaternion {
+	return t.Real
+}
+
+// t = 2 * Dual * conj(Real)
+func (t *DualQuaternion) Translation() vector3.Vector {
+	l_conj := t.Real.Conjugated()
+	l_t := quat.Mul(&t.Dual, &l_conj)
+	return vector3.Vector{2 * l_t[0], 2 * l_t[1], 2 * l_t[2]}
+}
+
+func (t *DualQuaternion) ToMat4() mat4.Mat4 {
+	l_m := smath.QuatToMat4(&t.Real)
+	l_trans := t.Translation()
+	l_m.SetTranslation(&l_trans)
+	return l_m
+}
+
+// 实部与对偶部各分量在误差tol内相等
+// 注意q与-q表示同一变换, 这里不认为相等
+func (t *DualQuaternion) ApproxEqual(o *DualQuaternion, tol float32) bool {
+	return t.Real.ApproxEqual(&o.Real, tol) && t.Dual.ApproxEqual(&o.Dual, tol)
+}
+
+// 四元数共轭, 对单位对偶四元数即为逆变换
+func (t *DualQuaternion) Conjugate() *DualQuaternion {
+	t.Real.Conjugate()
+	t.Dual.Conjugate()
+	return t
+}
+
+func (t *DualQuaternion) Conjugated() DualQuaternion {
+	l_dq := *t
+	return *l_dq.Conjugate()
+}
+
+// 归一化Real, 并去掉Dual中与Real不正交的部分
+func (t *DualQuaternion) Normalize() *DualQuaternion {
+	l_len := t.Real.Len()
+	if l_len == 0 {
+		*t = Ident
+		return t
+	}
+	l_inv := 1 / l_len
+	t.Real.Scale(l_inv)
+	t.Dual.Scale(l_inv)
+	t.Dual.Sub(t.Real.Scaled(quat.Dot(&t.Real, &t.Dual)))
+	return t
+}
+
+func (t *DualQuaternion) Normalized() DualQuaternion {
+	l_dq := *t
+	return *l_dq.Normalize()
+}
+
+// 变换点(旋转+平移), t需为单位对偶四元数
+func (t *DualQuaternion) TransformPoint(p *vector3.Vector) vector3.Vector {
+	l_res := t.Real.RotatedVec3(p)
+	l_trans := t.Translation()
+	return *l_res.Add(&l_trans)
+}
+
+// 变换方向(只旋转)
+func (t *DualQuaternion) TransformDir(v *vector3.Vector) vector3.Vector {
+	return t.Real.RotatedVec3(v)
+}
+
+// a * b: 先应用b再应用a
+func Mul(a, b *DualQuaternion) DualQuaternion {
+	l_d1 := quat.Mul(&a.Real, &b.Dual)
+	l_d2 := quat.Mul(&a.Dual, &b.Real)
+	return DualQuaternion{
+		Real: quat.Mul(&a.Real, &b.Real),
+		Dual: l_d1.Added(l_d2),
+	}
+}
+
+func Dot(a, b *DualQuaternion) float32 {
+	return quat.Dot(&a.Real, &b.Real)
+}
+
+// 螺旋线性插值, 沿最短路径在a,b之间做匀速螺旋运动, a,b需为单位对偶四元数
+func ScLERP(a, b *DualQuaternion, t float32) DualQuaternion {
+	l_b := *b
+	if Dot(a, b) < 0 {
+		l_b.Real.Scale(-1)
+		l_b.Dual.Scale(-1)
+	}
+	l_aconj := a.Conjugated()
+	l_diff := Mul(&l_aconj, &l_b)
+	l_step, ok := l_diff.pow(t)
+	if !ok {
+		// 旋转几乎为0, 退化为线性混合
+		return DLB([]DualQuaternion{*a, l_b}, []float32{1 - t, t})
+	}
+	return Mul(a, &l_step)
+}
+
+// 转成螺旋参数(角度theta, 沿轴位移d, 轴向l, 矩m)后按比例缩放
+func (t *DualQuaternion) pow(e float32) (DualQuaternion, bool) {
+	l_vr := vector3.Vector{t.Real[0], t.Real[1], t.Real[2]}
+	l_sin := l_vr.Length() // sin(theta/2)
+	if l_sin < 1e-6 {
+		return Ident, false
+	}
+	l_cos := quat.Clamp(t.Real[3], -1, 1)
+	l_theta := 2 * math.Atan2(l_sin, l_cos)
+	l_axis := l_vr.Scaled(1 / l_sin)
+
+	l_vd := vector3.Vector{t.Dual[0], t.Dual[1], t.Dual[2]}
+	l_d := -2 * t.Dual[3] / l_sin
+	l_ad := l_axis.Scaled(l_d * 0.5 * l_cos)
+	l_moment := vector3.Sub(&l_vd, &l_ad)
+	l_moment.Scale(1 / l_sin)
+
+	l_theta *= e
+	l_d *= e
+	s, c := math.Sincos(l_theta * 0.5)
+
+	l_ms := l_moment.Scaled(s)
+	l_as := l_axis.Scaled(l_d * 0.5 * c)
+	l_dv := vector3.Add(&l_ms, &l_as)
+	return DualQuaternion{
+		Real: quat.Quaternion{l_axis[0] * s, l_axis[1] * s, l_axis[2] * s, c},
+		Dual: quat.Quaternion{l_dv[0], l_dv[1], l_dv[2], -l_d * 0.5 * s},
+	}, true
+}
+
+// 对偶四元数线性混合(DLB): 加权求和后归一化, 与第一个输入不同半球的取反
+// dqs与weights长度需一致, 为空时返回Ident
+func DLB(dqs []DualQuaternion, weights []float32) DualQuaternion {
+	if len(dqs) != len(weights) {
+		panic("dquat: DLB len(dqs) != len(weights)")
+	}
+	if len(dqs) == 0 {
+		return Ident
+	}
+	var l_res DualQuaternion
+	for i := range dqs {
+		w := weights[i]
+		if Dot(&dqs[0], &dqs[i]) < 0 {
+			w = -w
+		}
+		l_res.Real.Add(dqs[i].Real.Scaled(w))
+		l_res.Dual.Add(dqs[i].Dual.Scaled(w))
+	}
+	return *l_res.Normalize()
+}
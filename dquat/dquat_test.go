@@ -0,0 +1,151 @@
+package dquat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+)
+
+func rt(axis vector3.Vector, angle float32, trans vector3.Vector) DualQuaternion {
+	r := quat.FromAxisAngle(&axis, angle)
+	return FromRotationTranslation(&r, &trans)
+}
+
+// 绕过点(1,0,0)平行于z的轴旋转angle: p' = R(p-c) + c
+func aboutOffsetAxis(angle float32) DualQuaternion {
+	s, c := math.Sincos(float64(angle))
+	return rt(vector3.Vector{0, 0, 1}, angle, vector3.Vector{float32(1 - c), float32(-s), 0})
+}
+
+// 同一变换(q与-q等价)
+func sameTransform(a, b *DualQuaternion, tol float32) bool {
+	if a.ApproxEqual(b, tol) {
+		return true
+	}
+	nb := DualQuaternion{b.Real.Scaled(-1), b.Dual.Scaled(-1)}
+	return a.ApproxEqual(&nb, tol)
+}
+
+func TestTransform(t *testing.T) {
+	dq := rt(vector3.Vector{0, 0, 1}, sutil.KPiOver2, vector3.Vector{1, 2, 3})
+	p := vector3.Vector{1, 0, 0}
+	cases := []struct {
+		name      string
+		got, want vector3.Vector
+	}{
+		{"Translation", dq.Translation(), vector3.Vector{1, 2, 3}},
+		{"TransformPoint", dq.TransformPoint(&p), vector3.Vector{1, 3, 3}},
+		{"TransformDir", dq.TransformDir(&p), vector3.Vector{0, 1, 0}},
+	}
+	for _, c := range cases {
+		if !c.got.ApproxEqual(&c.want, 1e-5) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	// 与矩阵互转
+	m := dq.ToMat4()
+	q := vector3.Vector{0.5, -0.7, 2}
+	if a, b := dq.TransformPoint(&q), m.MulVec3(&q); !a.ApproxEqual(&b, 1e-5) {
+		t.Errorf("ToMat4: %v, want %v", b, a)
+	}
+	if back := FromMat4(&m); !sameTransform(&back, &dq, 1e-5) {
+		t.Errorf("FromMat4(ToMat4) = %v, want %v", back, dq)
+	}
+
+	// 共轭为逆变换
+	inv := dq.Conjugated()
+	if id := Mul(&dq, &inv); !sameTransform(&id, &Ident, 1e-5) {
+		t.Errorf("dq * conj(dq) = %v", id)
+	}
+}
+
+func TestMul(t *testing.T) {
+	a := rt(vector3.Vector{0, 0, 1}, sutil.KPiOver2, vector3.Vector{1, 0, 0})
+	b := rt(vector3.Vector{1, 0, 0}, 0.7, vector3.Vector{0, -2, 5})
+	ab := Mul(&a, &b)
+	p := vector3.Vector{0.3, 1.1, -0.4}
+	bp := b.TransformPoint(&p)
+	want := a.TransformPoint(&bp)
+	if got := ab.TransformPoint(&p); !got.ApproxEqual(&want, 1e-5) {
+		t.Errorf("(a*b)(p) = %v, want a(b(p)) = %v", got, want)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	dq := rt(vector3.Vector{1, 1, 0}, 1.3, vector3.Vector{2, 0, -1})
+	s := DualQuaternion{dq.Real.Scaled(3), dq.Dual.Scaled(3)}
+	// 对偶部带上与实部不正交的分量
+	s.Dual.Add(s.Real.Scaled(0.1))
+	if n := s.Normalized(); !n.ApproxEqual(&dq, 1e-5) {
+		t.Errorf("Normalized = %v, want %v", n, dq)
+	}
+}
+
+func TestScLERP(t *testing.T) {
+	z := vector3.Vector{0, 0, 1}
+	a := rt(z, 0.3, vector3.Vector{1, 0, 0})
+	b := rt(vector3.Vector{1, 2, 3}, 2, vector3.Vector{0, 0, 4})
+	bNeg := DualQuaternion{b.Real.Scaled(-1), b.Dual.Scaled(-1)}
+	// 端点
+	for _, c := range []struct {
+		name string
+		a, b DualQuaternion
+	}{
+		{"same hemisphere", a, b},
+		{"opposite hemisphere", a, bNeg},
+	} {
+		if s := ScLERP(&c.a, &c.b, 0); !sameTransform(&s, &c.a, 1e-5) {
+			t.Errorf("%s: ScLERP(0) = %v, want %v", c.name, s, c.a)
+		}
+		if s := ScLERP(&c.a, &c.b, 1); !sameTransform(&s, &c.b, 1e-5) {
+			t.Errorf("%s: ScLERP(1) = %v, want %v", c.name, s, c.b)
+		}
+	}
+
+	// 中间值沿螺旋运动, 绕偏移轴旋转时平移不是线性插值
+	cases := []struct {
+		name string
+		a, b DualQuaternion
+		f    float32
+		want DualQuaternion
+	}{
+		{"screw about offset axis", Ident, aboutOffsetAxis(3), 0.5, aboutOffsetAxis(1.5)},
+		{"screw along axis", Ident, rt(z, sutil.KPiOver2, vector3.Vector{0, 0, 4}), 0.5,
+			rt(z, sutil.KPi/4, vector3.Vector{0, 0, 2})},
+		{"pure translation", FromTranslation(&vector3.Vector{1, 0, 0}), FromTranslation(&vector3.Vector{3, 2, 0}), 0.25,
+			FromTranslation(&vector3.Vector{1.5, 0.5, 0})},
+	}
+	for _, c := range cases {
+		s := ScLERP(&c.a, &c.b, c.f)
+		if !sameTransform(&s, &c.want, 1e-4) {
+			t.Errorf("%s: ScLERP(%v) = %v, want %v", c.name, c.f, s, c.want)
+		}
+	}
+}
+
+func TestDLB(t *testing.T) {
+	z := vector3.Vector{0, 0, 1}
+	a := rt(z, 0, vector3.Vector{1, 0, 0})
+	b := rt(z, sutil.KPiOver2, vector3.Vector{0, 1, 0})
+	bNeg := DualQuaternion{b.Real.Scaled(-1), b.Dual.Scaled(-1)}
+
+	if s := DLB([]DualQuaternion{a, b}, []float32{1, 0}); !sameTransform(&s, &a, 1e-6) {
+		t.Errorf("DLB weight (1,0) = %v, want %v", s, a)
+	}
+	want := rt(z, sutil.KPi/4, vector3.Vector{0, 0, 0})
+	mid := DLB([]DualQuaternion{a, b}, []float32{0.5, 0.5})
+	if r := mid.Rotation(); !r.ApproxEqual(&want.Real, 1e-5) {
+		t.Errorf("DLB rotation = %v, want %v", r, want.Real)
+	}
+	// 不同半球的输入取反后结果一致
+	if flip := DLB([]DualQuaternion{a, bNeg}, []float32{0.5, 0.5}); !sameTransform(&flip, &mid, 1e-6) {
+		t.Errorf("DLB with flipped input = %v, want %v", flip, mid)
+	}
+	if s := DLB(nil, nil); s != Ident {
+		t.Errorf("DLB of nothing = %v", s)
+	}
+}
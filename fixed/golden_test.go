@@ -0,0 +1,166 @@
+package fixed
+
+import (
+	"math"
+	"testing"
+)
+
+// 固定结果  输入均为整数字面值, 期望值按位固定
+// 实现改动导致任何结果变化都会使帧同步失步; ref为浮点参考值(原始整数单位), 用来确认期望值本身正确
+type goldenCase struct {
+	name string
+	fn   func() []int64
+	want []int64
+	ref  []float64
+	tol  float64
+}
+
+const k32 float64 = 1 << 32
+
+func rot(axis [3]float64, angle float64, p [3]float64) [3]float64 {
+	// Rodrigues
+	n := math.Sqrt(axis[0]*axis[0] + axis[1]*axis[1] + axis[2]*axis[2])
+	k := [3]float64{axis[0] / n, axis[1] / n, axis[2] / n}
+	s, c := math.Sincos(angle)
+	cr := [3]float64{k[1]*p[2] - k[2]*p[1], k[2]*p[0] - k[0]*p[2], k[0]*p[1] - k[1]*p[0]}
+	d := k[0]*p[0] + k[1]*p[1] + k[2]*p[2]
+	var r [3]float64
+	for i := range r {
+		r[i] = p[i]*c + cr[i]*s + k[i]*d*(1-c)
+	}
+	return r
+}
+
+func goldenCases() []goldenCase {
+	axis := [3]float64{1, 2, -1}
+	an := math.Sqrt(6)
+	qa := 5000000000.0 / k32
+	rp := rot(axis, qa, [3]float64{3, -1, 0.5})
+	vr := rot([3]float64{0, 0, 1}, 3000000000.0/k32, [3]float64{3, -5, 0})
+	return []goldenCase{
+		{"Q32.Mul", func() []int64 {
+			return raw32(Q32(-13958643712).Mul(6442450944), Q32(0x123456789).Mul(-0x9abcdef), Q32(1).Mul(Q32Half))
+		}, []int64{-20937965568, -184609358, 1}, []float64{-3.25 * 1.5 * k32, 0x123456789 * -0x9abcdef / k32, 0.5}, 1},
+		{"Q32.Div", func() []int64 {
+			return raw32(Q32(30064771072).Div(-8589934592), Q32(0x123456789).Div(0x9abcdef), Q32Max.Div(Q32Half))
+		}, []int64{-15032385536, 129354309986, math.MaxInt64}, []float64{-3.5 * k32, 0x123456789 * k32 / 0x9abcdef, math.MaxInt64}, 1},
+		{"Q32.Sqrt", func() []int64 {
+			return raw32(Q32(8589934592).Sqrt(), Q32(0x123456789abc).Sqrt(), Q32(1).Sqrt(), Q32(-5).Sqrt())
+		}, []int64{6074000999, 293203100740, 65536, 0}, []float64{math.Sqrt2 * k32, math.Sqrt(0x123456789abc * k32), k32 / 65536, 0}, 1},
+		{"Q32.Sin", func() []int64 {
+			return raw32(Q32(0).Sin(), Q32(4294967296).Sin(), Q32(-10000000000).Sin(), Q32Pi.Sin(), Q32(123456789012).Sin())
+		}, []int64{0, 3614090361, -3120504628, -1, -1945858416}, []float64{0, math.Sin(1) * k32, math.Sin(-10000000000/k32) * k32, math.Sin(13493037705/k32) * k32, math.Sin(123456789012/k32) * k32}, 8},
+		{"Q32.Cos", func() []int64 {
+			return raw32(Q32(0).Cos(), Q32(4294967296).Cos(), Q32(-10000000000).Cos(), Q32HalfPi.Cos(), Q32(123456789012).Cos())
+		}, []int64{4294967296, 2320580734, -2951134518, 0, -3828887449}, []float64{k32, math.Cos(1) * k32, math.Cos(-10000000000/k32) * k32, math.Cos(6746518852/k32) * k32, math.Cos(123456789012/k32) * k32}, 8},
+		{"Q32Atan2", func() []int64 {
+			return raw32(
+				Q32Atan2(Q32One, Q32One),
+				Q32Atan2(Q32One, -Q32One),
+				Q32Atan2(-3000000000, -7000000000),
+				Q32Atan2(0, -Q32One),
+				Q32Atan2(0, 123456789012),
+				Q32Atan2(5, 123456789012),
+				Q32Atan2(-5, 123456789012),
+				Q32Atan2(5, -123456789012),
+				Q32Atan2(-5, -123456789012),
+				Q32Atan2(123456789012, 5),
+				Q32Atan2(123456789012, -5),
+				Q32Atan2(-Q32One, 0),
+			)
+		}, []int64{3373259426, 10119778279, -11754040725, 13493037705, 0, 0, 0, 13493037705, -13493037705, 6746518852, 6746518853, -6746518852}, []float64{
+			math.Pi / 4 * k32, 3 * math.Pi / 4 * k32, math.Atan2(-3, -7) * k32, math.Pi * k32, 0,
+			math.Atan2(5, 123456789012) * k32, math.Atan2(-5, 123456789012) * k32,
+			math.Atan2(5, -123456789012) * k32, math.Atan2(-5, -123456789012) * k32,
+			math.Atan2(123456789012, 5) * k32, math.Atan2(123456789012, -5) * k32, -math.Pi / 2 * k32,
+		}, 8},
+		{"Vec2", func() []int64 {
+			v := Vec2{12884901888, -21474836480}
+			n := v.Normalized()
+			r := v.Rotated(3000000000)
+			return raw32(v.Length(), n[0], n[1], r[0], r[1], v.Angle())
+		}, []int64{25043747692, 2209742443, -3682904072, 23677107278, -8159895188, -4425434774}, []float64{
+			math.Sqrt(34) * k32, 3 / math.Sqrt(34) * k32, -5 / math.Sqrt(34) * k32,
+			vr[0] * k32, vr[1] * k32, math.Atan2(-5, 3) * k32,
+		}, 16},
+		{"Vec3", func() []int64 {
+			a := Vec3{12884901888, 17179869184, 51539607552}
+			b := Vec3{-4294967296, 2147483648, 1073741824}
+			n := a.Normalized()
+			c := Vec3Cross(&a, &b)
+			return raw32(a.Length(), n[0], n[1], n[2], Vec3Dot(&a, &b), c[0], c[1], c[2])
+		}, []int64{55834574848, 991146299, 1321528398, 3964585196, 8589934592, -21474836480, -54760833024, 23622320128}, []float64{13 * k32, 3.0 / 13 * k32, 4.0 / 13 * k32, 12.0 / 13 * k32, 2 * k32, -5 * k32, -12.75 * k32, 5.5 * k32}, 16},
+		{"Quat", func() []int64 {
+			axis := Vec3{4294967296, 8589934592, -4294967296}
+			q := QuatFromAxisAngle(&axis, 5000000000)
+			p := Vec3{12884901888, -4294967296, 2147483648}
+			r := q.RotatedVec3(&p)
+			q2 := QuatMul(&q, &q)
+			l := QuatNLerp(&QuatIdent, &q, Q32Half)
+			return raw32(q[0], q[1], q[2], q[3], r[0], r[1], r[2], q2[0], q2[3], l[0], l[3])
+		}, []int64{963955941, 1927911882, -963955941, 3587684175, 5312693598, -6902584717, -10639959482, 1610428782, 1698781598, 503136651, 4114348690}, []float64{
+			math.Sin(qa/2) / an * k32, 2 * math.Sin(qa/2) / an * k32, -math.Sin(qa/2) / an * k32, math.Cos(qa/2) * k32,
+			rp[0] * k32, rp[1] * k32, rp[2] * k32,
+			math.Sin(qa) / an * k32, math.Cos(qa) * k32,
+			math.Sin(qa/4) / an * k32, math.Cos(qa/4) * k32,
+		}, 16},
+		{"Q16", func() []int64 {
+			return raw16(Q16(-212992).Mul(98304), Q16(-212992).Div(98304), Q16(131072).Sqrt(),
+				Q16(65536).Sin(), Q16(65536).Cos(), Q16Atan2(65536, -65536))
+		}, []int64{-319488, -141994, 92681, 55147, 35409, 154416}, []float64{-4.875 * 65536, -3.25 / 1.5 * 65536, math.Sqrt2 * 65536, math.Sin(1) * 65536, math.Cos(1) * 65536, 3 * math.Pi / 4 * 65536}, 1},
+	}
+}
+
+func TestGolden(t *testing.T) {
+	for _, c := range goldenCases() {
+		got := c.fn()
+		if len(got) != len(c.want) || len(c.ref) != len(c.want) {
+			t.Fatalf("%s: len %d, want %d, ref %d", c.name, len(got), len(c.want), len(c.ref))
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("%s[%d] = %d, want %d", c.name, i, got[i], c.want[i])
+			}
+			if d := math.Abs(float64(c.want[i]) - c.ref[i]); d > c.tol {
+				t.Errorf("%s[%d]: want %d is %v off the reference %v", c.name, i, c.want[i], d, c.ref[i])
+			}
+		}
+	}
+}
+
+// y>0时结果在(0,pi], y<0时在[-pi,0), 且与math.Atan2一致
+func TestAtan2Quadrant(t *testing.T) {
+	vals := []int64{1, 5, 1 << 16, 3000000000, 1 << 32, 123456789012, 1 << 50}
+	for _, a := range vals {
+		for _, b := range vals {
+			for _, sx := range []int64{1, -1} {
+				for _, sy := range []int64{1, -1} {
+					x, y := Q32(sx*a), Q32(sy*b)
+					z := Q32Atan2(y, x)
+					if sy > 0 && z < 0 || sy < 0 && z > 0 {
+						t.Errorf("Q32Atan2(%d, %d) = %d, wrong sign", y, x, z)
+					}
+					if d := math.Abs(float64(z) - math.Atan2(float64(y), float64(x))*k32); d > 8 {
+						t.Errorf("Q32Atan2(%d, %d) = %d, %v off math.Atan2", y, x, z, d)
+					}
+				}
+			}
+		}
+	}
+}
+
+func raw32(vs ...Q32) []int64 {
+	res := make([]int64, len(vs))
+	for i, v := range vs {
+		res[i] = int64(v)
+	}
+	return res
+}
+
+func raw16(vs ...Q16) []int64 {
+	res := make([]int64, len(vs))
+	for i, v := range vs {
+		res[i] = int64(v)
+	}
+	return res
+}
@@ -0,0 +1,112 @@
+/*
+ * 16.16定点数  范围约±32768, 精度1/65536
+ *   三角函数转成Q32计算后舍入回Q16
+ */
+package fixed
+
+import (
+	"math"
+)
+
+// 16位整数 + 16位小数
+type Q16 int32
+
+const (
+	Q16One  Q16 = 1 << 16
+	Q16Half Q16 = 1 << 15
+	Q16Max  Q16 = math.MaxInt32
+	Q16Min  Q16 = math.MinInt32
+
+	Q16Pi     Q16 = 205887 // round(pi * 2^16)
+	Q16TwoPi  Q16 = 411775
+	Q16HalfPi Q16 = 102944
+)
+
+func Q16FromInt(i int16) Q16 {
+	return Q16(int32(i) << 16)
+}
+
+// 浮点转定点会受舍入影响, 只应在加载数据等非同步路径使用
+func Q16FromFloat(f float64) Q16 {
+	return Q16(math.Round(f * (1 << 16)))
+}
+
+func Q16FromFloat32(f float32) Q16 {
+	return Q16FromFloat(float64(f))
+}
+
+func (t Q16) Float64() float64 {
+	return float64(t) / (1 << 16)
+}
+
+func (t Q16) Float32() float32 {
+	return float32(t.Float64())
+}
+
+// 向下取整的整数部分
+func (t Q16) Int() int16 {
+	return int16(t >> 16)
+}
+
+func (t Q16) Floor() Q16 {
+	return t &^ (Q16One - 1)
+}
+
+func (t Q16) Fract() Q16 {
+	return t & (Q16One - 1)
+}
+
+func (t Q16) Abs() Q16 {
+	if t < 0 {
+		return -t
+	}
+	return t
+}
+
+// 无损转换
+func (t Q16) Q32() Q32 {
+	return Q32(int64(t) << 16)
+}
+
+// 四舍五入(远离0), 溢出按整数回绕
+func (t Q16) Mul(o Q16) Q16 {
+	p := int64(t) * int64(o)
+	if p < 0 {
+		return Q16(-((-p + 1<<15) >> 16))
+	}
+	return Q16((p + 1<<15) >> 16)
+}
+
+// 向0截断, 超出范围时饱和
+func (t Q16) Div(o Q16) Q16 {
+	if o == 0 {
+		panic("fixed: division by zero")
+	}
+	q := (int64(t) << 16) / int64(o)
+	if q > math.MaxInt32 {
+		return Q16Max
+	} else if q < math.MinInt32 {
+		return Q16Min
+	}
+	return Q16(q)
+}
+
+// 负数返回0; 结果向下截断
+func (t Q16) Sqrt() Q16 {
+	if t <= 0 {
+		return 0
+	}
+	return Q16(isqrt128(0, uint64(t)<<16))
+}
+
+func (t Q16) Sin() Q16 {
+	return t.Q32().Sin().Q16()
+}
+
+func (t Q16) Cos() Q16 {
+	return t.Q32().Cos().Q16()
+}
+
+func Q16Atan2(y, x Q16) Q16 {
+	return Q32Atan2(y.Q32(), x.Q32()).Q16()
+}
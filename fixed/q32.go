@@ -0,0 +1,191 @@
+/*
+ * 定点数  纯整数运算, 结果与CPU/编译器无关, 用于帧同步
+ *   Q32为32.32 (int64), Q16为16.16 (int32)
+ *   加减直接用 + -, 溢出按整数回绕; 乘法四舍五入(远离0), 除法向0截断, 除0同整数除法panic
+ */
+package fixed
+
+import (
+	"math"
+	"math/bits"
+)
+
+// 32位整数 + 32位小数
+type Q32 int64
+
+const (
+	Q32One  Q32 = 1 << 32
+	Q32Half Q32 = 1 << 31
+	Q32Max  Q32 = math.MaxInt64
+	Q32Min  Q32 = math.MinInt64
+
+	Q32Pi        Q32 = 13493037705 // round(pi * 2^32)
+	Q32TwoPi     Q32 = 26986075409
+	Q32HalfPi    Q32 = 6746518852
+	Q32QuarterPi Q32 = 3373259426
+)
+
+func Q32FromInt(i int32) Q32 {
+	return Q32(int64(i) << 32)
+}
+
+// 浮点转定点会受舍入影响, 只应在加载数据等非同步路径使用
+func Q32FromFloat(f float64) Q32 {
+	return Q32(math.Round(f * (1 << 32)))
+}
+
+func Q32FromFloat32(f float32) Q32 {
+	return Q32FromFloat(float64(f))
+}
+
+// n/d, 按定点除法截断
+func Q32FromRatio(n, d int32) Q32 {
+	return Q32FromInt(n).Div(Q32FromInt(d))
+}
+
+func (t Q32) Float64() float64 {
+	return float64(t) / (1 << 32)
+}
+
+func (t Q32) Float32() float32 {
+	return float32(t.Float64())
+}
+
+// 向下取整的整数部分
+func (t Q32) Int() int32 {
+	return int32(t >> 32)
+}
+
+func (t Q32) Floor() Q32 {
+	return t &^ (Q32One - 1)
+}
+
+func (t Q32) Ceil() Q32 {
+	return (t + Q32One - 1).Floor()
+}
+
+// 四舍五入 (0.5向正无穷)
+func (t Q32) Round() Q32 {
+	return (t + Q32Half).Floor()
+}
+
+// 小数部分 [0,1)
+func (t Q32) Fract() Q32 {
+	return t & (Q32One - 1)
+}
+
+func (t Q32) Abs() Q32 {
+	if t < 0 {
+		return -t
+	}
+	return t
+}
+
+func (t Q32) Neg() Q32 {
+	return -t
+}
+
+// -1, 0, 1
+func (t Q32) Sign() int {
+	if t > 0 {
+		return 1
+	} else if t < 0 {
+		return -1
+	}
+	return 0
+}
+
+func (t Q32) Mul(o Q32) Q32 {
+	neg := (t < 0) != (o < 0)
+	hi, lo := bits.Mul64(uint64(t.Abs()), uint64(o.Abs()))
+	// 加0.5后右移32位
+	lo, carry := bits.Add64(lo, 1<<31, 0)
+	hi += carry
+	res := Q32(hi<<32 | lo>>32)
+	if neg {
+		return -res
+	}
+	return res
+}
+
+// 结果超出范围时饱和到Q32Max/Q32Min
+func (t Q32) Div(o Q32) Q32 {
+	if o == 0 {
+		panic("fixed: division by zero")
+	}
+	neg := (t < 0) != (o < 0)
+	a, b := uint64(t.Abs()), uint64(o.Abs())
+	hi, lo := a>>32, a<<32
+	if hi >= b {
+		if neg {
+			return Q32Min
+		}
+		return Q32Max
+	}
+	q, _ := bits.Div64(hi, lo, b)
+	if q > math.MaxInt64 {
+		q = math.MaxInt64
+	}
+	if neg {
+		return -Q32(q)
+	}
+	return Q32(q)
+}
+
+// 整数倍
+func (t Q32) MulInt(i int32) Q32 {
+	return t * Q32(i)
+}
+
+func (t Q32) DivInt(i int32) Q32 {
+	return t / Q32(i)
+}
+
+// 负数返回0; 结果向下截断
+func (t Q32) Sqrt() Q32 {
+	if t <= 0 {
+		return 0
+	}
+	// sqrt(raw * 2^-32) * 2^32 = sqrt(raw * 2^32)
+	return Q32(isqrt128(uint64(t)>>32, uint64(t)<<32))
+}
+
+func (t Q32) Min(o Q32) Q32 {
+	if t < o {
+		return t
+	}
+	return o
+}
+
+func (t Q32) Max(o Q32) Q32 {
+	if t > o {
+		return t
+	}
+	return o
+}
+
+func (t Q32) Clamp(low, high Q32) Q32 {
+	return t.Max(low).Min(high)
+}
+
+// a + (b-a)*f
+func (t Q32) Lerp(o, f Q32) Q32 {
+	return t + (o - t).Mul(f)
+}
+
+func (t Q32) Q16() Q16 {
+	return Q16((t + 1<<15) >> 16)
+}
+
+// 128位无符号数(hi,lo)的整数平方根, 向下取整
+func isqrt128(hi, lo uint64) uint64 {
+	var res uint64
+	for bit := 63; bit >= 0; bit-- {
+		cand := res | 1<<uint(bit)
+		sh, sl := bits.Mul64(cand, cand)
+		if sh < hi || (sh == hi && sl <= lo) {
+			res = cand
+		}
+	}
+	return res
+}
@@ -0,0 +1,105 @@
+/*
+ * 定点四元数  (x,y,z,w), 接口与quat一致
+ */
+package fixed
+
+import (
+	"github.com/tinysss/smath/quat"
+)
+
+type Quat [4]Q32
+
+var QuatIdent = Quat{0, 0, 0, Q32One}
+
+func QuatFromQuaternion(q *quat.Quaternion) Quat {
+	return Quat{Q32FromFloat32(q[0]), Q32FromFloat32(q[1]), Q32FromFloat32(q[2]), Q32FromFloat32(q[3])}
+}
+
+func (t *Quat) Quaternion() quat.Quaternion {
+	return quat.Quaternion{t[0].Float32(), t[1].Float32(), t[2].Float32(), t[3].Float32()}
+}
+
+// 绕axis旋转angle弧度, axis不要求归一化
+func QuatFromAxisAngle(axis *Vec3, angle Q32) Quat {
+	n := axis.Normalized()
+	s, c := (angle / 2).Sincos()
+	return Quat{n[0].Mul(s), n[1].Mul(s), n[2].Mul(s), c}
+}
+
+func (t *Quat) Len() Q32 {
+	return hypot(t[:])
+}
+
+func (t *Quat) Normalize() *Quat {
+	l := t.Len()
+	if l == 0 {
+		*t = QuatIdent
+		return t
+	}
+	for i := range t {
+		t[i] = t[i].Div(l)
+	}
+	return t
+}
+
+func (t *Quat) Normalized() Quat {
+	r := *t
+	return *r.Normalize()
+}
+
+// 共轭
+func (t *Quat) Conjugate() *Quat {
+	t[0] = -t[0]
+	t[1] = -t[1]
+	t[2] = -t[2]
+	return t
+}
+
+func (t *Quat) Conjugated() Quat {
+	return Quat{-t[0], -t[1], -t[2], t[3]}
+}
+
+// 旋转v, t需为单位四元数
+// v' = v + 2w(q x v) + 2q x (q x v)
+func (t *Quat) RotateVec3(v *Vec3) {
+	*v = t.RotatedVec3(v)
+}
+
+func (t *Quat) RotatedVec3(v *Vec3) Vec3 {
+	q := Vec3{t[0], t[1], t[2]}
+	uv := Vec3Cross(&q, v)
+	uuv := Vec3Cross(&q, &uv)
+	uv.Scale(2 * t[3])
+	uuv.Scale(2 * Q32One)
+	res := *v
+	return *res.Add(&uv).Add(&uuv)
+}
+
+func QuatDot(a, b *Quat) Q32 {
+	return a[0].Mul(b[0]) + a[1].Mul(b[1]) + a[2].Mul(b[2]) + a[3].Mul(b[3])
+}
+
+// a * b, 先应用b再应用a
+func QuatMul(a, b *Quat) Quat {
+	return Quat{
+		a[3].Mul(b[0]) + a[0].Mul(b[3]) + a[1].Mul(b[2]) - a[2].Mul(b[1]),
+		a[3].Mul(b[1]) + a[1].Mul(b[3]) + a[2].Mul(b[0]) - a[0].Mul(b[2]),
+		a[3].Mul(b[2]) + a[2].Mul(b[3]) + a[0].Mul(b[1]) - a[1].Mul(b[0]),
+		a[3].Mul(b[3]) - a[0].Mul(b[0]) - a[1].Mul(b[1]) - a[2].Mul(b[2]),
+	}
+}
+
+// 归一化线性插值, 走最短路径
+func QuatNLerp(a, b *Quat, f Q32) Quat {
+	bb := *b
+	if QuatDot(a, b) < 0 {
+		for i := range bb {
+			bb[i] = -bb[i]
+		}
+	}
+	var res Quat
+	for i := range res {
+		res[i] = a[i].Lerp(bb[i], f)
+	}
+	return *res.Normalize()
+}
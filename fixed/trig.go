@@ -0,0 +1,148 @@
+/*
+ * 定点三角函数  全部为整数运算, 常量表为预先算好的字面值(不在运行时用浮点生成)
+ *   Sin/Cos: 规约到[0,pi/4]后泰勒展开, 误差约1e-9
+ *   Atan2: CORDIC向量模式, 误差约1e-9
+ */
+package fixed
+
+import (
+	"math/bits"
+)
+
+// round(atan(2^-i) * 2^32)
+var cordicAtan = [...]Q32{
+	3373259426, 1991351318, 1052175346, 534100635, 268086748, 134174063, 67103403, 33553749,
+	16777131, 8388597, 4194303, 2097152, 1048576, 524288, 262144, 131072,
+	65536, 32768, 16384, 8192, 4096, 2048, 1024, 512,
+	256, 128, 64, 32, 16, 8, 4, 2,
+	1,
+}
+
+func (t Q32) Sin() Q32 {
+	quadrant, r := reduceAngle(t)
+	switch quadrant {
+	case 0:
+		return sinQuarter(r)
+	case 1:
+		return cosQuarter(r)
+	case 2:
+		return -sinQuarter(r)
+	}
+	return -cosQuarter(r)
+}
+
+func (t Q32) Cos() Q32 {
+	quadrant, r := reduceAngle(t)
+	switch quadrant {
+	case 0:
+		return cosQuarter(r)
+	case 1:
+		return -sinQuarter(r)
+	case 2:
+		return -cosQuarter(r)
+	}
+	return sinQuarter(r)
+}
+
+func (t Q32) Sincos() (sin, cos Q32) {
+	return t.Sin(), t.Cos()
+}
+
+// t = quadrant*pi/2 + r, r在[0,pi/2)
+func reduceAngle(t Q32) (quadrant int, r Q32) {
+	t %= Q32TwoPi
+	if t < 0 {
+		t += Q32TwoPi
+	}
+	quadrant = int(t / Q32HalfPi)
+	r = t - Q32(quadrant)*Q32HalfPi
+	return quadrant & 3, r
+}
+
+// sin(r), r在[0,pi/2)
+func sinQuarter(r Q32) Q32 {
+	if r > Q32QuarterPi {
+		return cosTaylor(Q32HalfPi - r)
+	}
+	return sinTaylor(r)
+}
+
+// cos(r), r在[0,pi/2)
+func cosQuarter(r Q32) Q32 {
+	if r > Q32QuarterPi {
+		return sinTaylor(Q32HalfPi - r)
+	}
+	return cosTaylor(r)
+}
+
+// x*(1 - x^2/(2*3)*(1 - x^2/(4*5)*(...)))
+func sinTaylor(x Q32) Q32 {
+	x2 := x.Mul(x)
+	s := Q32One
+	for _, d := range [...]int32{14 * 15, 12 * 13, 10 * 11, 8 * 9, 6 * 7, 4 * 5, 2 * 3} {
+		s = Q32One - x2.Mul(s).DivInt(d)
+	}
+	return x.Mul(s)
+}
+
+// 1 - x^2/(1*2)*(1 - x^2/(3*4)*(...))
+func cosTaylor(x Q32) Q32 {
+	x2 := x.Mul(x)
+	c := Q32One
+	for _, d := range [...]int32{13 * 14, 11 * 12, 9 * 10, 7 * 8, 5 * 6, 3 * 4, 1 * 2} {
+		c = Q32One - x2.Mul(c).DivInt(d)
+	}
+	return c
+}
+
+// atan2(y, x), 范围(-pi, pi], x=y=0时返回0
+func Q32Atan2(y, x Q32) Q32 {
+	if x == 0 && y == 0 {
+		return 0
+	}
+	var base Q32
+	if x < 0 {
+		// 旋转pi到右半平面
+		if y >= 0 {
+			base = Q32Pi
+		} else {
+			base = -Q32Pi
+		}
+		x, y = -x, -y
+	}
+
+	// 放大到约2^60以保证移位精度, 同时给CORDIC增益(约1.65)和sqrt(2)留出余量
+	m := uint64(x.Abs() | y.Abs())
+	if sh := bits.LeadingZeros64(m) - 3; sh > 0 {
+		x <<= uint(sh)
+		y <<= uint(sh)
+	} else if sh < 0 {
+		x >>= uint(-sh)
+		y >>= uint(-sh)
+	}
+
+	if y == 0 {
+		return base
+	}
+	// 旋转后x>0, 角度应与y同号且不超过pi/2
+	positive := y > 0
+	var z Q32
+	for i, a := range cordicAtan {
+		if y > 0 {
+			x, y = x+y>>uint(i), y-x>>uint(i)
+			z += a
+		} else if y < 0 {
+			x, y = x-y>>uint(i), y+x>>uint(i)
+			z -= a
+		} else {
+			break
+		}
+	}
+	// 最后几步可能越过0或pi/2, 截断到对应象限, 保证结果符号与y一致
+	if positive {
+		z = z.Clamp(0, Q32HalfPi)
+	} else {
+		z = z.Clamp(-Q32HalfPi, 0)
+	}
+	return base + z
+}
@@ -0,0 +1,227 @@
+/*
+ * 定点向量  基于Q32, 接口与vector2/vector3一致
+ *   长度用128位累加平方和, 中间结果不会溢出, 但长度本身需在Q32范围(约±2^31)内
+ */
+package fixed
+
+import (
+	"math/bits"
+
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+)
+
+type Vec2 [2]Q32
+
+type Vec3 [3]Q32
+
+//-------------------------------------------- Vec2 ------------------------------------------------
+
+func Vec2FromVector(v *vector2.Vector) Vec2 {
+	return Vec2{Q32FromFloat32(v[0]), Q32FromFloat32(v[1])}
+}
+
+func (t *Vec2) Vector() vector2.Vector {
+	return vector2.Vector{t[0].Float32(), t[1].Float32()}
+}
+
+func (t *Vec2) IsZero() bool {
+	return t[0] == 0 && t[1] == 0
+}
+
+func (t *Vec2) Add(v *Vec2) *Vec2 {
+	t[0] += v[0]
+	t[1] += v[1]
+	return t
+}
+
+func (t *Vec2) Sub(v *Vec2) *Vec2 {
+	t[0] -= v[0]
+	t[1] -= v[1]
+	return t
+}
+
+func (t *Vec2) Scale(f Q32) *Vec2 {
+	t[0] = t[0].Mul(f)
+	t[1] = t[1].Mul(f)
+	return t
+}
+
+func (t *Vec2) Scaled(f Q32) Vec2 {
+	r := *t
+	return *r.Scale(f)
+}
+
+func (t *Vec2) Inverted() Vec2 {
+	return Vec2{-t[0], -t[1]}
+}
+
+func (t *Vec2) LengthSqr() Q32 {
+	return Vec2Dot(t, t)
+}
+
+func (t *Vec2) Length() Q32 {
+	return hypot(t[:])
+}
+
+// 零向量保持不变
+func (t *Vec2) Normalize() *Vec2 {
+	l := t.Length()
+	if l == 0 {
+		return t
+	}
+	t[0] = t[0].Div(l)
+	t[1] = t[1].Div(l)
+	return t
+}
+
+func (t *Vec2) Normalized() Vec2 {
+	r := *t
+	return *r.Normalize()
+}
+
+// >0逆时针
+func (t *Vec2) Rotate(angle Q32) *Vec2 {
+	*t = t.Rotated(angle)
+	return t
+}
+
+func (t *Vec2) Rotated(angle Q32) Vec2 {
+	s, c := angle.Sincos()
+	return Vec2{
+		t[0].Mul(c) - t[1].Mul(s),
+		t[0].Mul(s) + t[1].Mul(c),
+	}
+}
+
+// 相对于x轴的弧度, 返回(-PI,PI]
+func (t *Vec2) Angle() Q32 {
+	return Q32Atan2(t[1], t[0])
+}
+
+func Vec2Add(a, b *Vec2) Vec2 {
+	return Vec2{a[0] + b[0], a[1] + b[1]}
+}
+
+func Vec2Sub(a, b *Vec2) Vec2 {
+	return Vec2{a[0] - b[0], a[1] - b[1]}
+}
+
+func Vec2Dot(a, b *Vec2) Q32 {
+	return a[0].Mul(b[0]) + a[1].Mul(b[1])
+}
+
+// 2D叉积 (z分量)
+func Vec2Cross(a, b *Vec2) Q32 {
+	return a[0].Mul(b[1]) - a[1].Mul(b[0])
+}
+
+func Vec2Lerp(a, b *Vec2, f Q32) Vec2 {
+	return Vec2{a[0].Lerp(b[0], f), a[1].Lerp(b[1], f)}
+}
+
+//-------------------------------------------- Vec3 ------------------------------------------------
+
+func Vec3FromVector(v *vector3.Vector) Vec3 {
+	return Vec3{Q32FromFloat32(v[0]), Q32FromFloat32(v[1]), Q32FromFloat32(v[2])}
+}
+
+func (t *Vec3) Vector() vector3.Vector {
+	return vector3.Vector{t[0].Float32(), t[1].Float32(), t[2].Float32()}
+}
+
+func (t *Vec3) IsZero() bool {
+	return t[0] == 0 && t[1] == 0 && t[2] == 0
+}
+
+func (t *Vec3) Add(v *Vec3) *Vec3 {
+	t[0] += v[0]
+	t[1] += v[1]
+	t[2] += v[2]
+	return t
+}
+
+func (t *Vec3) Sub(v *Vec3) *Vec3 {
+	t[0] -= v[0]
+	t[1] -= v[1]
+	t[2] -= v[2]
+	return t
+}
+
+func (t *Vec3) Scale(f Q32) *Vec3 {
+	t[0] = t[0].Mul(f)
+	t[1] = t[1].Mul(f)
+	t[2] = t[2].Mul(f)
+	return t
+}
+
+func (t *Vec3) Scaled(f Q32) Vec3 {
+	r := *t
+	return *r.Scale(f)
+}
+
+func (t *Vec3) Inverted() Vec3 {
+	return Vec3{-t[0], -t[1], -t[2]}
+}
+
+func (t *Vec3) LengthSqr() Q32 {
+	return Vec3Dot(t, t)
+}
+
+func (t *Vec3) Length() Q32 {
+	return hypot(t[:])
+}
+
+// 零向量保持不变
+func (t *Vec3) Normalize() *Vec3 {
+	l := t.Length()
+	if l == 0 {
+		return t
+	}
+	t[0] = t[0].Div(l)
+	t[1] = t[1].Div(l)
+	t[2] = t[2].Div(l)
+	return t
+}
+
+func (t *Vec3) Normalized() Vec3 {
+	r := *t
+	return *r.Normalize()
+}
+
+func Vec3Add(a, b *Vec3) Vec3 {
+	return Vec3{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+func Vec3Sub(a, b *Vec3) Vec3 {
+	return Vec3{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func Vec3Dot(a, b *Vec3) Q32 {
+	return a[0].Mul(b[0]) + a[1].Mul(b[1]) + a[2].Mul(b[2])
+}
+
+func Vec3Cross(a, b *Vec3) Vec3 {
+	return Vec3{
+		a[1].Mul(b[2]) - a[2].Mul(b[1]),
+		a[2].Mul(b[0]) - a[0].Mul(b[2]),
+		a[0].Mul(b[1]) - a[1].Mul(b[0]),
+	}
+}
+
+func Vec3Lerp(a, b *Vec3, f Q32) Vec3 {
+	return Vec3{a[0].Lerp(b[0], f), a[1].Lerp(b[1], f), a[2].Lerp(b[2], f)}
+}
+
+// sqrt(sum(c^2)), 平方和按128位累加
+func hypot(cs []Q32) Q32 {
+	var hi, lo uint64
+	for _, c := range cs {
+		a := uint64(c.Abs())
+		ph, pl := bits.Mul64(a, a)
+		var carry uint64
+		lo, carry = bits.Add64(lo, pl, 0)
+		hi += ph + carry
+	}
+	return Q32(isqrt128(hi, lo))
+}
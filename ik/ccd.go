@@ -0,0 +1,55 @@
+/*
+ * CCD  从末端往根逐个转动关节使末端朝向目标, 每步都按关节Limit修正
+ */
+package ik
+
+import (
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 末端与目标距离小于tolerance或迭代maxIter轮后停止
+// 返回迭代轮数及是否到达
+func CCD(c *Chain, target *vector3.Vector, tolerance float32, maxIter int) (iters int, reached bool) {
+	n := len(c.Joints)
+	if n < 2 {
+		return 0, false
+	}
+	for iters < maxIter {
+		l_end := c.End()
+		if vector3.Distance(&l_end, target) <= tolerance {
+			return iters, true
+		}
+		iters++
+		for i := n - 2; i >= 0; i-- {
+			c.ccdStep(i, target)
+		}
+	}
+	l_end := c.End()
+	return iters, vector3.Distance(&l_end, target) <= tolerance
+}
+
+// 转动关节i使末端指向目标
+func (t *Chain) ccdStep(i int, target *vector3.Vector) {
+	j := &t.Joints[i]
+	l_end := t.End()
+	l_toEnd := vector3.Sub(&l_end, &j.Position)
+	l_toTarget := vector3.Sub(target, &j.Position)
+	if l_toEnd.LengthSqr() < 1e-12 || l_toTarget.LengthSqr() < 1e-12 {
+		return
+	}
+	l_delta := quat.FromToQuat(l_toEnd, l_toTarget)
+	if j.Limit != nil {
+		// 转到父空间做限制, 再换算回世界空间的增量
+		l_parent := t.parentRotation(i)
+		l_parentInv := l_parent.Conjugated()
+		l_world := quat.Mul(&l_delta, &j.Rotation)
+		l_local := quat.Mul(&l_parentInv, &l_world)
+		j.Limit.Apply(&l_local)
+		l_world = quat.Mul(&l_parent, &l_local)
+		l_oldInv := j.Rotation.Conjugated()
+		l_delta = quat.Mul(&l_world, &l_oldInv)
+		l_delta.Normalize()
+	}
+	t.rotateFrom(i, &l_delta)
+}
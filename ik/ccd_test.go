@@ -0,0 +1,64 @@
+package ik
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestCCD(t *testing.T) {
+	lens := []float32{1, 1, 1, 1, 1}
+	targets := []vector3.Vector{{2, 1, -1.5}, {-1, 3, 1}, {0, -2, 2}}
+	for _, target := range targets {
+		chain := straightChain(lens...)
+		_, reached := CCD(chain, &target, 1e-3, 100)
+		if end := chain.End(); !reached || vector3.Distance(&end, &target) > 1e-3 {
+			t.Errorf("target %v: reached = %v, end at %v", target, reached, end)
+		}
+		checkChain(t, "CCD", chain, lens)
+	}
+
+	// 够不到
+	far := vector3.Vector{20, 0, 0}
+	chain := straightChain(lens...)
+	if iters, reached := CCD(chain, &far, 1e-3, 20); reached || iters != 20 {
+		t.Errorf("far target: iters = %d, reached = %v", iters, reached)
+	}
+	if end := chain.End(); end[0] < 4.9 {
+		t.Errorf("far target: end at %v, want near (5,0,0)", end)
+	}
+}
+
+// 有限制时每个关节的局部旋转都在范围内
+func TestCCDLimited(t *testing.T) {
+	const cone, twist = 0.9, 0.1
+	lens := []float32{1, 1, 1, 1, 1}
+	chain := straightChain(lens...)
+	for i := range chain.Joints {
+		chain.Joints[i].Limit = &Limit{Axis: vector3.UnitY, Cone: cone, TwistMin: -twist, TwistMax: twist}
+	}
+	target := vector3.Vector{2, 1, -1.5}
+	_, reached := CCD(chain, &target, 1e-3, 100)
+	if end := chain.End(); !reached || vector3.Distance(&end, &target) > 1e-3 {
+		t.Errorf("reached = %v, end at %v", reached, end)
+	}
+	checkChain(t, "CCD limited", chain, lens)
+	for i := range chain.Joints[:len(chain.Joints)-1] {
+		parentInv := chain.parentRotation(i)
+		parentInv.Conjugate()
+		local := quat.Mul(&parentInv, &chain.Joints[i].Rotation)
+		sw, tw := local.SwingTwist(&vector3.UnitY)
+		sa := 2 * math.Acos(math.Min(1, math.Abs(float64(sw[3]))))
+		ta := 2 * math.Atan2(float64(tw[1]), float64(tw[3]))
+		if ta > math.Pi {
+			ta -= 2 * math.Pi
+		} else if ta < -math.Pi {
+			ta += 2 * math.Pi
+		}
+		if sa > cone+1e-3 || math.Abs(ta) > twist+1e-3 {
+			t.Errorf("joint %d: swing %v, twist %v out of limit", i, sa, ta)
+		}
+	}
+}
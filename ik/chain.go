@@ -0,0 +1,103 @@
+/*
+ * IK关节链  位置和朝向都是世界空间, 下标0为根
+ *   求解器只改位置和朝向, 骨骼长度保持不变; 子关节随父关节一起转(局部旋转不变)
+ */
+package ik
+
+import (
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+)
+
+type Joint struct {
+	Position vector3.Vector  // 世界坐标
+	Rotation quat.Quaternion // 世界朝向
+	Limit    *Limit          // 只有CCD使用, nil为不限制
+}
+
+type Chain struct {
+	Joints []Joint
+	Base   quat.Quaternion // 根关节父节点的世界朝向, 用于求根关节的局部旋转
+}
+
+// 关节限制, 作用于关节相对父关节的局部旋转
+// 局部旋转拆成 swing * twist, twist绕Axis
+type Limit struct {
+	Axis     vector3.Vector // 骨骼方向, 父关节空间
+	Cone     float32        // swing最大角度(弧度)
+	TwistMin float32        // twist范围(弧度) [-pi,pi]
+	TwistMax float32
+}
+
+func NewChain(positions []vector3.Vector, rotations []quat.Quaternion) *Chain {
+	if len(positions) != len(rotations) {
+		panic("ik: positions and rotations length mismatch")
+	}
+	l_chain := &Chain{Joints: make([]Joint, len(positions)), Base: quat.Ident}
+	for i := range positions {
+		l_chain.Joints[i] = Joint{Position: positions[i], Rotation: rotations[i]}
+	}
+	return l_chain
+}
+
+// 末端位置
+func (t *Chain) End() vector3.Vector {
+	return t.Joints[len(t.Joints)-1].Position
+}
+
+// 各段骨骼长度之和
+func (t *Chain) Length() float32 {
+	var l_len float32
+	for i := 1; i < len(t.Joints); i++ {
+		l_len += vector3.Distance(&t.Joints[i].Position, &t.Joints[i-1].Position)
+	}
+	return l_len
+}
+
+// 父关节世界朝向
+func (t *Chain) parentRotation(i int) quat.Quaternion {
+	if i == 0 {
+		return t.Base
+	}
+	return t.Joints[i-1].Rotation
+}
+
+// 第i个关节及其后代绕关节i旋转delta
+func (t *Chain) rotateFrom(i int, delta *quat.Quaternion) {
+	pivot := t.Joints[i].Position
+	for j := i; j < len(t.Joints); j++ {
+		if j > i {
+			l_off := vector3.Sub(&t.Joints[j].Position, &pivot)
+			delta.RotateVec3(&l_off)
+			t.Joints[j].Position = vector3.Add(&pivot, &l_off)
+		}
+		t.Joints[j].Rotation = quat.Mul(delta, &t.Joints[j].Rotation)
+		t.Joints[j].Rotation.Normalize()
+	}
+}
+
+// 按限制修正局部旋转
+func (t *Limit) Apply(local *quat.Quaternion) {
+	swing, twist := local.SwingTwist(&t.Axis)
+
+	// twist的有符号角度
+	axis := t.Axis.Normalized()
+	s := twist[0]*axis[0] + twist[1]*axis[1] + twist[2]*axis[2]
+	l_twistAngle := sutil.WrapPi(2 * math.Atan2(s, twist[3]))
+	if l_twistAngle < t.TwistMin || l_twistAngle > t.TwistMax {
+		twist = quat.FromAxisAngle(&axis, sutil.Clamp(l_twistAngle, t.TwistMin, t.TwistMax))
+	}
+
+	// swing走短路径
+	if swing[3] < 0 {
+		swing.Scale(-1)
+	}
+	l_swingAngle := 2 * math.Acos(sutil.Clamp(swing[3], -1, 1))
+	if l_swingAngle > t.Cone {
+		l_swingAxis := vector3.Vector{swing[0], swing[1], swing[2]}
+		swing = quat.FromAxisAngle(&l_swingAxis, t.Cone)
+	}
+	*local = quat.Mul(&swing, &twist)
+}
@@ -0,0 +1,91 @@
+package ik
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 沿+Y的直链, 各段长度为lens
+func straightChain(lens ...float32) *Chain {
+	pos := make([]vector3.Vector, len(lens)+1)
+	rot := make([]quat.Quaternion, len(lens)+1)
+	rot[0] = quat.Ident
+	for i, l := range lens {
+		pos[i+1] = vector3.Vector{0, pos[i][1] + l, 0}
+		rot[i+1] = quat.Ident
+	}
+	return NewChain(pos, rot)
+}
+
+// 骨骼长度不变, 且关节i的局部+Y轴指向子关节
+func checkChain(t *testing.T, name string, c *Chain, lens []float32) {
+	t.Helper()
+	for i := 0; i+1 < len(c.Joints); i++ {
+		d := vector3.Sub(&c.Joints[i+1].Position, &c.Joints[i].Position)
+		if l := d.Length(); math.Abs(float64(l-lens[i])) > 1e-4 {
+			t.Errorf("%s: bone %d length = %v, want %v", name, i, l, lens[i])
+		}
+		y := c.Joints[i].Rotation.RotatedVec3(&vector3.UnitY)
+		d.Normalize()
+		if !d.ApproxEqual(&y, 1e-3) {
+			t.Errorf("%s: joint %d points %v, bone is %v", name, i, y, d)
+		}
+	}
+}
+
+func TestChain(t *testing.T) {
+	c := straightChain(1, 2, 0.5)
+	if c.Length() != 3.5 || c.End() != (vector3.Vector{0, 3.5, 0}) {
+		t.Errorf("Length = %v, End = %v", c.Length(), c.End())
+	}
+	// 转动关节1, 子关节跟着转, 根不动
+	delta := quat.FromZAxisAngle(math.Pi / 2)
+	c.rotateFrom(1, &delta)
+	want := []vector3.Vector{{0, 0, 0}, {0, 1, 0}, {-2, 1, 0}, {-2.5, 1, 0}}
+	for i, w := range want {
+		if !c.Joints[i].Position.ApproxEqual(&w, 1e-5) {
+			t.Errorf("joint %d at %v, want %v", i, c.Joints[i].Position, w)
+		}
+	}
+	checkChain(t, "rotateFrom", c, []float32{1, 2, 0.5})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewChain with mismatched lengths did not panic")
+		}
+	}()
+	NewChain(make([]vector3.Vector, 2), make([]quat.Quaternion, 3))
+}
+
+func TestLimitApply(t *testing.T) {
+	l := Limit{Axis: vector3.UnitY, Cone: 0.5, TwistMin: -0.2, TwistMax: 0.3}
+	tilt := vector3.Vector{1, 0, 0}
+	cases := []struct {
+		name         string
+		swing, twist float32 // 输入的摆动(绕x)及扭转(绕y)角度
+		wantSwing    float32
+		wantTwist    float32
+	}{
+		{"inside", 0.3, 0.1, 0.3, 0.1},
+		{"swing clamped", 1.2, 0.1, 0.5, 0.1},
+		{"twist clamped high", 0.3, 0.8, 0.3, 0.3},
+		{"twist clamped low", -0.3, -1, -0.3, -0.2},
+		{"both clamped", -1, 2, -0.5, 0.3},
+	}
+	for _, c := range cases {
+		sw, tw := quat.FromAxisAngle(&tilt, c.swing), quat.FromYAxisAngle(c.twist)
+		local := quat.Mul(&sw, &tw)
+		l.Apply(&local)
+		sw, tw = quat.FromAxisAngle(&tilt, c.wantSwing), quat.FromYAxisAngle(c.wantTwist)
+		want := quat.Mul(&sw, &tw)
+		if !local.ApproxEqual(&want, 1e-4) {
+			neg := local.Scaled(-1)
+			if !neg.ApproxEqual(&want, 1e-4) {
+				t.Errorf("%s: Apply = %v, want %v", c.name, local, want)
+			}
+		}
+	}
+}
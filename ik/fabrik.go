@@ -0,0 +1,92 @@
+/*
+ * FABRIK  前后向交替拉直关节位置, 收敛后再由位置变化求出各关节朝向
+ *   不使用Limit; 朝向按最短旋转更新, 骨骼绕自身轴的扭转不变
+ */
+package ik
+
+import (
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 末端与目标距离小于tolerance或迭代maxIter次后停止
+// 返回迭代次数及是否到达; 目标超出链长时伸直指向目标
+func FABRIK(c *Chain, target *vector3.Vector, tolerance float32, maxIter int) (iters int, reached bool) {
+	n := len(c.Joints)
+	if n < 2 {
+		return 0, false
+	}
+	l_pos := make([]vector3.Vector, n)
+	l_lens := make([]float32, n-1)
+	var total float32
+	for i := range c.Joints {
+		l_pos[i] = c.Joints[i].Position
+		if i > 0 {
+			l_lens[i-1] = vector3.Distance(&l_pos[i], &l_pos[i-1])
+			total += l_lens[i-1]
+		}
+	}
+	root := l_pos[0]
+
+	if vector3.Distance(&root, target) >= total {
+		// 够不到, 伸直
+		l_dir := vector3.Sub(target, &root)
+		l_dir.Normalize()
+		for i := 1; i < n; i++ {
+			l_step := l_dir.Scaled(l_lens[i-1])
+			l_pos[i] = vector3.Add(&l_pos[i-1], &l_step)
+		}
+		c.applyPositions(l_pos)
+		l_end := c.End()
+		return 0, vector3.Distance(&l_end, target) <= tolerance
+	}
+
+	for iters < maxIter {
+		if vector3.Distance(&l_pos[n-1], target) <= tolerance {
+			reached = true
+			break
+		}
+		iters++
+		// 后向: 末端放到目标
+		l_pos[n-1] = *target
+		for i := n - 2; i >= 0; i-- {
+			l_pos[i] = placeAt(&l_pos[i+1], &l_pos[i], l_lens[i], &c.Joints[i].Position, &c.Joints[i+1].Position)
+		}
+		// 前向: 根放回原处
+		l_pos[0] = root
+		for i := 1; i < n; i++ {
+			l_pos[i] = placeAt(&l_pos[i-1], &l_pos[i], l_lens[i-1], &c.Joints[i].Position, &c.Joints[i-1].Position)
+		}
+	}
+	if !reached {
+		reached = vector3.Distance(&l_pos[n-1], target) <= tolerance
+	}
+	c.applyPositions(l_pos)
+	return iters, reached
+}
+
+// 从anchor朝toward方向走length
+// toward与anchor重合时用原始骨骼方向 (fallbackTo - fallbackFrom)
+func placeAt(anchor, toward *vector3.Vector, length float32, fallbackTo, fallbackFrom *vector3.Vector) vector3.Vector {
+	l_dir := vector3.Sub(toward, anchor)
+	if l_dir.LengthSqr() < 1e-12 {
+		l_dir = vector3.Sub(fallbackTo, fallbackFrom)
+	}
+	l_dir.Normalize()
+	l_dir.Scale(length)
+	return vector3.Add(anchor, &l_dir)
+}
+
+// 由新位置依次转动各骨骼, 同时更新朝向
+func (t *Chain) applyPositions(pos []vector3.Vector) {
+	for i := 0; i < len(t.Joints)-1; i++ {
+		l_delta := quat.FromToQuat(
+			vector3.Sub(&t.Joints[i+1].Position, &t.Joints[i].Position),
+			vector3.Sub(&pos[i+1], &pos[i]))
+		t.rotateFrom(i, &l_delta)
+	}
+	// 消除累计误差
+	for i := range t.Joints {
+		t.Joints[i].Position = pos[i]
+	}
+}
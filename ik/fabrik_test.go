@@ -0,0 +1,45 @@
+package ik
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestFABRIK(t *testing.T) {
+	lens := []float32{1, 1, 1, 1, 1}
+	cases := []struct {
+		name    string
+		target  vector3.Vector
+		reached bool
+		end     vector3.Vector
+	}{
+		{"reach", vector3.Vector{2, 1, -1.5}, true, vector3.Vector{2, 1, -1.5}},
+		{"reach near root", vector3.Vector{0.5, 0.5, 0}, true, vector3.Vector{0.5, 0.5, 0}},
+		{"reach behind", vector3.Vector{0, -3, 1}, true, vector3.Vector{0, -3, 1}},
+		// 够不到时伸直指向目标
+		{"too far", vector3.Vector{20, 0, 0}, false, vector3.Vector{5, 0, 0}},
+		{"too far diagonal", vector3.Vector{0, 30, 40}, false, vector3.Vector{0, 3, 4}},
+	}
+	for _, c := range cases {
+		chain := straightChain(lens...)
+		_, reached := FABRIK(chain, &c.target, 1e-3, 50)
+		if reached != c.reached {
+			t.Errorf("%s: reached = %v, want %v", c.name, reached, c.reached)
+		}
+		if end := chain.End(); vector3.Distance(&end, &c.end) > 2e-3 {
+			t.Errorf("%s: end at %v, want %v", c.name, end, c.end)
+		}
+		if root := chain.Joints[0].Position; root != (vector3.Vector{}) {
+			t.Errorf("%s: root moved to %v", c.name, root)
+		}
+		checkChain(t, c.name, chain, lens)
+	}
+
+	// 已在目标上时不迭代
+	chain := straightChain(lens...)
+	end := chain.End()
+	if iters, ok := FABRIK(chain, &end, 1e-3, 50); iters != 0 || !ok {
+		t.Errorf("FABRIK at target = %d %v", iters, ok)
+	}
+}
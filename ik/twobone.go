@@ -0,0 +1,78 @@
+/*
+ * 两骨骼解析IK  如 肩-肘-腕, 髋-膝-踝
+ *   余弦定理求中间关节位置, 弯曲平面由pole决定; 不使用Limit
+ */
+package ik
+
+import (
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+)
+
+// c需为3个关节 根-中间-末端
+// pole为中间关节弯向的参考点, nil时保持当前弯曲平面
+// 目标超出范围时伸直指向目标; 返回末端是否到达目标
+func TwoBone(c *Chain, target, pole *vector3.Vector) bool {
+	if len(c.Joints) != 3 {
+		panic("ik: TwoBone needs exactly 3 joints")
+	}
+	root, mid, end := c.Joints[0].Position, c.Joints[1].Position, c.Joints[2].Position
+	a := vector3.Distance(&mid, &root)
+	b := vector3.Distance(&end, &mid)
+
+	l_toTarget := vector3.Sub(target, &root)
+	dist := l_toTarget.Length()
+	if a == 0 || b == 0 || dist < 1e-6 {
+		return false
+	}
+	dir := l_toTarget.Scaled(1 / dist)
+
+	// 可达范围 [|a-b|, a+b]
+	reached := true
+	d := dist
+	if d > a+b {
+		d, reached = a+b, false
+	} else if d < math.Abs(a-b) {
+		d, reached = math.Abs(a-b), false
+	}
+
+	// 弯曲方向: pole在垂直于dir平面上的投影
+	l_bend := bendDir(&dir, &root, pole, &mid)
+
+	cosA := sutil.Clamp((a*a+d*d-b*b)/(2*a*d), -1, 1)
+	sinA := math.Sqrt(1 - cosA*cosA)
+	l_along := dir.Scaled(a * cosA)
+	l_up := l_bend.Scaled(a * sinA)
+	newMid := vector3.Add(&root, &l_along)
+	newMid.Add(&l_up)
+	l_reach := dir.Scaled(d)
+	newEnd := vector3.Add(&root, &l_reach)
+
+	// 先转根骨骼对准newMid, 再转中间骨骼对准newEnd
+	l_rootDelta := quat.FromToQuat(vector3.Sub(&mid, &root), vector3.Sub(&newMid, &root))
+	c.rotateFrom(0, &l_rootDelta)
+	l_midDelta := quat.FromToQuat(
+		vector3.Sub(&c.Joints[2].Position, &c.Joints[1].Position),
+		vector3.Sub(&newEnd, &c.Joints[1].Position))
+	c.rotateFrom(1, &l_midDelta)
+	return reached
+}
+
+// 垂直于dir的单位弯曲方向
+// 依次尝试 pole, 当前中间关节, 任意正交方向
+func bendDir(dir, root, pole, mid *vector3.Vector) vector3.Vector {
+	for _, p := range []*vector3.Vector{pole, mid} {
+		if p == nil {
+			continue
+		}
+		l_off := vector3.Sub(p, root)
+		l_proj := dir.Scaled(vector3.Dot(&l_off, dir))
+		l_off.Sub(&l_proj)
+		if l_off.LengthSqr() > 1e-10 {
+			return l_off.Normalized()
+		}
+	}
+	return dir.Normal()
+}
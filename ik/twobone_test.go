@@ -0,0 +1,66 @@
+package ik
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestTwoBone(t *testing.T) {
+	pole := vector3.Vector{0, 1, 5}
+	cases := []struct {
+		name    string
+		lens    []float32
+		target  vector3.Vector
+		pole    *vector3.Vector
+		reached bool
+		end     vector3.Vector // 够不到时末端的位置
+	}{
+		{"reach", []float32{1, 1}, vector3.Vector{1, 1, 0.5}, &pole, true, vector3.Vector{1, 1, 0.5}},
+		{"reach no pole", []float32{1, 1.5}, vector3.Vector{-1, 0.5, 0}, nil, true, vector3.Vector{-1, 0.5, 0}},
+		{"reach behind", []float32{2, 1}, vector3.Vector{0, -1.5, 1}, &pole, true, vector3.Vector{0, -1.5, 1}},
+		// 太远时伸直指向目标
+		{"too far", []float32{1, 1}, vector3.Vector{0, 0, 5}, &pole, false, vector3.Vector{0, 0, 2}},
+		// 太近时末端停在|a-b|处
+		{"too near", []float32{2, 1}, vector3.Vector{0.5, 0, 0}, &pole, false, vector3.Vector{1, 0, 0}},
+	}
+	for _, c := range cases {
+		chain := straightChain(c.lens...)
+		got := TwoBone(chain, &c.target, c.pole)
+		if got != c.reached {
+			t.Errorf("%s: TwoBone = %v, want %v", c.name, got, c.reached)
+		}
+		if end := chain.End(); !end.ApproxEqual(&c.end, 1e-4) {
+			t.Errorf("%s: end at %v, want %v", c.name, end, c.end)
+		}
+		if root := chain.Joints[0].Position; root != (vector3.Vector{}) {
+			t.Errorf("%s: root moved to %v", c.name, root)
+		}
+		checkChain(t, c.name, chain, c.lens)
+	}
+}
+
+// 中间关节弯向pole一侧
+func TestTwoBonePole(t *testing.T) {
+	target := vector3.Vector{0, 1.5, 0}
+	for _, pole := range []vector3.Vector{{0, 1, 5}, {0, 1, -5}, {-3, 0, 0}} {
+		chain := straightChain(1, 1)
+		if !TwoBone(chain, &target, &pole) {
+			t.Errorf("pole %v: not reached", pole)
+		}
+		mid := chain.Joints[1].Position
+		// 中间关节相对根-目标连线的偏移与pole同向
+		off := vector3.Vector{mid[0], 0, mid[2]}
+		pl := vector3.Vector{pole[0], 0, pole[2]}
+		if vector3.Dot(&off, &pl) <= 0 {
+			t.Errorf("pole %v: mid joint at %v bends away", pole, mid)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("TwoBone with 4 joints did not panic")
+		}
+	}()
+	TwoBone(straightChain(1, 1, 1), &target, nil)
+}
@@ -0,0 +1,257 @@
+/*
+ * 区间运算  [Lo,Hi], 结果向外舍入, 保证真实值一定落在区间内
+ *   任一操作数为空区间时结果为Empty
+ *   加减用TwoSum判断舍入方向, 乘法在float64中是精确的再定向转float32
+ *   除法/开方额外放宽1ulp
+ */
+package interval
+
+import (
+	"math"
+)
+
+type Interval struct {
+	Lo float32
+	Hi float32
+}
+
+var (
+	// 空区间
+	Empty = Interval{float32(math.Inf(1)), float32(math.Inf(-1))}
+	// 全体实数
+	Entire = Interval{float32(math.Inf(-1)), float32(math.Inf(1))}
+)
+
+// lo>hi时交换
+func New(lo, hi float32) *Interval {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return &Interval{lo, hi}
+}
+
+// 退化区间 [x,x]
+func Point(x float32) Interval {
+	return Interval{x, x}
+}
+
+func (t *Interval) IsEmpty() bool {
+	return !(t.Lo <= t.Hi)
+}
+
+func (t *Interval) Width() float32 {
+	if t.IsEmpty() {
+		return 0
+	}
+	return t.Hi - t.Lo
+}
+
+// 中点
+func (t *Interval) Mid() float32 {
+	return t.Lo*0.5 + t.Hi*0.5
+}
+
+func (t *Interval) Contains(x float32) bool {
+	return x >= t.Lo && x <= t.Hi
+}
+
+func (t *Interval) ContainsZero() bool {
+	return t.Contains(0)
+}
+
+func (t *Interval) Intersects(o *Interval) bool {
+	return t.Lo <= o.Hi && o.Lo <= t.Hi
+}
+
+// 放大到包含x
+func (t *Interval) Extend(x float32) *Interval {
+	if x < t.Lo {
+		t.Lo = x
+	}
+	if x > t.Hi {
+		t.Hi = x
+	}
+	return t
+}
+
+// 包含a和b的最小区间
+func Hull(a, b *Interval) Interval {
+	return Interval{min(a.Lo, b.Lo), max(a.Hi, b.Hi)}
+}
+
+// 交集, 不相交时为Empty
+func Intersect(a, b *Interval) Interval {
+	r := Interval{max(a.Lo, b.Lo), min(a.Hi, b.Hi)}
+	if r.IsEmpty() {
+		return Empty
+	}
+	return r
+}
+
+func Neg(a *Interval) Interval {
+	return Interval{-a.Hi, -a.Lo}
+}
+
+func Add(a, b *Interval) Interval {
+	if a.IsEmpty() || b.IsEmpty() {
+		return Empty
+	}
+	lo, _ := sumBounds(a.Lo, b.Lo)
+	_, hi := sumBounds(a.Hi, b.Hi)
+	return Interval{lo, hi}
+}
+
+func Sub(a, b *Interval) Interval {
+	if a.IsEmpty() || b.IsEmpty() {
+		return Empty
+	}
+	lo, _ := sumBounds(a.Lo, -b.Hi)
+	_, hi := sumBounds(a.Hi, -b.Lo)
+	return Interval{lo, hi}
+}
+
+func Mul(a, b *Interval) Interval {
+	if a.IsEmpty() || b.IsEmpty() {
+		return Empty
+	}
+	p0 := mulBound(a.Lo, b.Lo)
+	p1 := mulBound(a.Lo, b.Hi)
+	p2 := mulBound(a.Hi, b.Lo)
+	p3 := mulBound(a.Hi, b.Hi)
+	return Interval{
+		down(math.Min(math.Min(p0, p1), math.Min(p2, p3))),
+		up(math.Max(math.Max(p0, p1), math.Max(p2, p3))),
+	}
+}
+
+// 标量乘
+func Scale(a *Interval, f float32) Interval {
+	p := Point(f)
+	return Mul(a, &p)
+}
+
+// b包含0时返回Entire
+func Div(a, b *Interval) Interval {
+	if a.IsEmpty() || b.IsEmpty() {
+		return Empty
+	}
+	if b.ContainsZero() {
+		return Entire
+	}
+	q0 := float64(a.Lo) / float64(b.Lo)
+	q1 := float64(a.Lo) / float64(b.Hi)
+	q2 := float64(a.Hi) / float64(b.Lo)
+	q3 := float64(a.Hi) / float64(b.Hi)
+	return Interval{
+		nextDown(float32(math.Min(math.Min(q0, q1), math.Min(q2, q3)))),
+		nextUp(float32(math.Max(math.Max(q0, q1), math.Max(q2, q3)))),
+	}
+}
+
+func Abs(a *Interval) Interval {
+	if a.IsEmpty() {
+		return Empty
+	}
+	if a.Lo >= 0 {
+		return *a
+	} else if a.Hi <= 0 {
+		return Neg(a)
+	}
+	return Interval{0, max(-a.Lo, a.Hi)}
+}
+
+// 平方, 比Mul(a,a)更紧 (结果非负)
+func Sqr(a *Interval) Interval {
+	if a.IsEmpty() {
+		return Empty
+	}
+	l_abs := Abs(a)
+	return Interval{
+		down(float64(l_abs.Lo) * float64(l_abs.Lo)),
+		up(float64(l_abs.Hi) * float64(l_abs.Hi)),
+	}
+}
+
+// 负数部分截掉, 整个区间为负时返回Empty
+func Sqrt(a *Interval) Interval {
+	if a.IsEmpty() || a.Hi < 0 {
+		return Empty
+	}
+	lo := float32(0)
+	if a.Lo > 0 {
+		lo = max(0, nextDown(float32(math.Sqrt(float64(a.Lo)))))
+	}
+	return Interval{lo, nextUp(float32(math.Sqrt(float64(a.Hi))))}
+}
+
+// 端点乘积, 0乘无穷按0算 (无穷端点只表示无界, 不是区间内的值)
+func mulBound(a, b float32) float64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return float64(a) * float64(b)
+}
+
+// a+b的上下界, 由TwoSum求出舍入误差的符号
+func sumBounds(a, b float32) (lo, hi float32) {
+	s := a + b
+	if math.IsNaN(float64(s)) {
+		return s, s
+	}
+	// 溢出时真实值仍有限, 另一侧界取最大有限值
+	if math.IsInf(float64(s), 1) {
+		return math.MaxFloat32, s
+	}
+	if math.IsInf(float64(s), -1) {
+		return s, -math.MaxFloat32
+	}
+	bb := s - a
+	err := (a - (s - bb)) + (b - bb)
+	lo, hi = s, s
+	if err < 0 {
+		lo = nextDown(s)
+	} else if err > 0 {
+		hi = nextUp(s)
+	}
+	return lo, hi
+}
+
+// 不大于x的最大float32
+func down(x float64) float32 {
+	f := float32(x)
+	if float64(f) > x {
+		f = nextDown(f)
+	}
+	return f
+}
+
+// 不小于x的最小float32
+func up(x float64) float32 {
+	f := float32(x)
+	if float64(f) < x {
+		f = nextUp(f)
+	}
+	return f
+}
+
+func nextDown(f float32) float32 {
+	return math.Nextafter32(f, float32(math.Inf(-1)))
+}
+
+func nextUp(f float32) float32 {
+	return math.Nextafter32(f, float32(math.Inf(1)))
+}
+
+func min(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
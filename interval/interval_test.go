@@ -0,0 +1,169 @@
+package interval
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+var (
+	kInf = float32(math.Inf(1))
+	kMax = float32(math.MaxFloat32)
+)
+
+// 与高精度结果比较, 区间必须包含真实值
+func TestContainsExact(t *testing.T) {
+	contains := func(name string, r Interval, exact *big.Float) {
+		lo, hi := big.NewFloat(float64(r.Lo)), big.NewFloat(float64(r.Hi))
+		if lo.Cmp(exact) > 0 || hi.Cmp(exact) < 0 {
+			t.Errorf("%s = %v, exact %v", name, r, exact)
+		}
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		a := float32(rnd.NormFloat64() * math.Pow(10, float64(rnd.Intn(20)-10)))
+		b := float32(rnd.NormFloat64() * math.Pow(10, float64(rnd.Intn(20)-10)))
+		ia, ib := Point(a), Point(b)
+		ba, bb := new(big.Float).SetPrec(2000).SetFloat64(float64(a)), new(big.Float).SetPrec(2000).SetFloat64(float64(b))
+		contains("Add", Add(&ia, &ib), new(big.Float).SetPrec(2000).Add(ba, bb))
+		contains("Sub", Sub(&ia, &ib), new(big.Float).SetPrec(2000).Sub(ba, bb))
+		contains("Mul", Mul(&ia, &ib), new(big.Float).SetPrec(2000).Mul(ba, bb))
+		contains("Div", Div(&ia, &ib), new(big.Float).SetPrec(2000).Quo(ba, bb))
+		if a > 0 {
+			contains("Sqrt", Sqrt(&ia), new(big.Float).SetPrec(2000).Sqrt(ba))
+		}
+		// 加法最多放宽1ulp
+		if s := Add(&ia, &ib); s.Hi != s.Lo && nextUp(s.Lo) != s.Hi {
+			t.Errorf("Add(%v, %v) = %v, wider than 1ulp", a, b, s)
+		}
+	}
+}
+
+func TestArith(t *testing.T) {
+	cases := []struct {
+		name string
+		got  Interval
+		want Interval
+	}{
+		{"Add", Add(&Interval{1, 2}, &Interval{-3, 0.5}), Interval{-2, 2.5}},
+		{"Sub", Sub(&Interval{1, 2}, &Interval{-3, 0.5}), Interval{0.5, 5}},
+		{"Mul", Mul(&Interval{-2, 3}, &Interval{-2, 3}), Interval{-6, 9}},
+		{"Mul neg", Mul(&Interval{-2, -1}, &Interval{3, 4}), Interval{-8, -3}},
+		{"Sqr", Sqr(&Interval{-2, 3}), Interval{0, 9}},
+		{"Sqr neg", Sqr(&Interval{-3, -2}), Interval{4, 9}},
+		{"Abs", Abs(&Interval{-2, 3}), Interval{0, 3}},
+		{"Abs neg", Abs(&Interval{-3, -2}), Interval{2, 3}},
+		{"Neg", Neg(&Interval{-2, 3}), Interval{-3, 2}},
+		{"Scale", Scale(&Interval{-2, 3}, -2), Interval{-6, 4}},
+		{"Div zero", Div(&Interval{1, 2}, &Interval{-2, 3}), Entire},
+		{"Sqrt neg", Sqrt(&Interval{-3, -1}), Empty},
+		{"Hull", Hull(&Interval{1, 2}, &Interval{-3, 0.5}), Interval{-3, 2}},
+		{"Intersect", Intersect(&Interval{1, 2}, &Interval{-3, 1.5}), Interval{1, 1.5}},
+		{"Intersect disjoint", Intersect(&Interval{1, 2}, &Interval{-3, 0.5}), Empty},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	// 除法/开方放宽1ulp
+	d := Div(&Interval{1, 2}, &Interval{4, 8})
+	if !d.Contains(0.125) || !d.Contains(0.5) || d.Lo != nextDown(0.125) || d.Hi != nextUp(0.5) {
+		t.Errorf("Div = %v", d)
+	}
+	s := Sqrt(&Interval{-2, 4})
+	if s.Lo != 0 || s.Hi != nextUp(2) {
+		t.Errorf("Sqrt = %v", s)
+	}
+}
+
+// 溢出及无穷端点
+func TestInfinities(t *testing.T) {
+	cases := []struct {
+		name string
+		got  Interval
+		want Interval
+	}{
+		// 有限值相加溢出, 另一侧界仍为有限值
+		{"Add overflow", Add(&Interval{kMax, kMax}, &Interval{kMax, kMax}), Interval{kMax, kInf}},
+		{"Sub overflow", Sub(&Interval{-kMax, -kMax}, &Interval{kMax, kMax}), Interval{-kInf, -kMax}},
+		{"Mul overflow", Mul(&Interval{1e30, 1e30}, &Interval{-1e30, 1e30}), Interval{-kInf, kInf}},
+		{"Mul overflow point", Mul(&Interval{1e30, 1e30}, &Interval{1e30, 1e30}), Interval{kMax, kInf}},
+		{"Add entire", Add(&Entire, &Interval{1, 2}), Entire},
+		// 0乘无界区间为0
+		{"Mul entire zero", Mul(&Entire, &Interval{0, 0}), Interval{0, 0}},
+		{"Scale entire zero", Scale(&Entire, 0), Interval{0, 0}},
+		{"Mul half-line", Mul(&Interval{0, kInf}, &Interval{-2, -1}), Interval{-kInf, 0}},
+		{"Mul entire", Mul(&Entire, &Interval{1, 2}), Entire},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+// 任一操作数为空时结果为Empty, 不能变成有限或无界的非空区间
+func TestEmpty(t *testing.T) {
+	x := Interval{1, 2}
+	pos := Interval{1, kInf}
+	other := Interval{3, 1} // 非Empty字面值的空区间
+	cases := []struct {
+		name string
+		got  Interval
+	}{
+		{"Neg", Neg(&Empty)},
+		{"Add", Add(&Empty, &x)},
+		{"Add rhs", Add(&x, &Empty)},
+		{"Sub", Sub(&Empty, &x)},
+		{"Sub rhs", Sub(&x, &Empty)},
+		{"Mul", Mul(&Empty, &x)},
+		{"Mul rhs", Mul(&x, &Empty)},
+		{"Scale", Scale(&Empty, 2)},
+		{"Div", Div(&Empty, &pos)},
+		{"Div rhs", Div(&x, &Empty)},
+		{"Div zero", Div(&Empty, &Entire)},
+		{"Abs", Abs(&Empty)},
+		{"Abs other", Abs(&other)},
+		{"Sqr", Sqr(&Empty)},
+		{"Sqr other", Sqr(&other)},
+		{"Sqrt", Sqrt(&Empty)},
+		{"Intersect", Intersect(&Empty, &Entire)},
+	}
+	for _, c := range cases {
+		if c.got != Empty {
+			t.Errorf("%s = %v, want Empty", c.name, c.got)
+		}
+	}
+	if h := Hull(&Empty, &x); h != x {
+		t.Errorf("Hull(Empty, x) = %v, want %v", h, x)
+	}
+
+	// 空分量经过向量运算后仍为空
+	v := Vec3{x, Empty, x}
+	if l := v.LengthSqr(); l != Empty {
+		t.Errorf("LengthSqr with empty component = %v", l)
+	}
+	w := Vec3{x, x, x}
+	if d := Vec3Dot(&v, &w); d != Empty {
+		t.Errorf("Vec3Dot with empty component = %v", d)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	r := *New(3, -1)
+	if r != (Interval{-1, 3}) || r.Width() != 4 || r.Mid() != 1 || !r.ContainsZero() || r.Contains(3.5) {
+		t.Errorf("New(3, -1) = %v, Width = %v, Mid = %v", r, r.Width(), r.Mid())
+	}
+	if !Empty.IsEmpty() || Empty.Width() != 0 || Entire.IsEmpty() || !Entire.Contains(kMax) {
+		t.Errorf("Empty/Entire wrong")
+	}
+	if !r.Intersects(&Interval{3, 5}) || r.Intersects(&Interval{3.5, 5}) {
+		t.Errorf("Intersects wrong")
+	}
+	if e := Empty; *e.Extend(2).Extend(-1) != (Interval{-1, 2}) {
+		t.Errorf("Extend = %v", e)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * 区间向量  每个分量为一个区间, 用于把包围盒保守地传过变换链
+ *   与vector3.Box.Transformed相比, 这里考虑了浮点舍入, 结果一定包含真实的变换结果
+ */
+package interval
+
+import (
+	"fmt"
+
+	"github.com/tinysss/smath/generic"
+	"github.com/tinysss/smath/vector3"
+)
+
+type Vec3 [3]Interval
+
+func Vec3FromBox(b *vector3.Box) Vec3 {
+	return Vec3{
+		{b.Min[0], b.Max[0]},
+		{b.Min[1], b.Max[1]},
+		{b.Min[2], b.Max[2]},
+	}
+}
+
+func Vec3FromPoint(v *vector3.Vector) Vec3 {
+	return Vec3{Point(v[0]), Point(v[1]), Point(v[2])}
+}
+
+func (t *Vec3) Box() vector3.Box {
+	return vector3.Box{
+		Min: vector3.Vector{t[0].Lo, t[1].Lo, t[2].Lo},
+		Max: vector3.Vector{t[0].Hi, t[1].Hi, t[2].Hi},
+	}
+}
+
+func Vec3Add(a, b *Vec3) Vec3 {
+	return Vec3{Add(&a[0], &b[0]), Add(&a[1], &b[1]), Add(&a[2], &b[2])}
+}
+
+func Vec3Sub(a, b *Vec3) Vec3 {
+	return Vec3{Sub(&a[0], &b[0]), Sub(&a[1], &b[1]), Sub(&a[2], &b[2])}
+}
+
+func Vec3Scale(a *Vec3, f *Interval) Vec3 {
+	return Vec3{Mul(&a[0], f), Mul(&a[1], f), Mul(&a[2], f)}
+}
+
+func Vec3Dot(a, b *Vec3) Interval {
+	x := Mul(&a[0], &b[0])
+	y := Mul(&a[1], &b[1])
+	z := Mul(&a[2], &b[2])
+	xy := Add(&x, &y)
+	return Add(&xy, &z)
+}
+
+// 长度平方
+func (t *Vec3) LengthSqr() Interval {
+	x := Sqr(&t[0])
+	y := Sqr(&t[1])
+	z := Sqr(&t[2])
+	xy := Add(&x, &y)
+	return Add(&xy, &z)
+}
+
+// 用m变换, 约定同vector3.Box.Transformed: 列存储 M*v, 支持3x3 3x4 4x4(只用前3行)
+func (t *Vec3) Transformed(m generic.T) Vec3 {
+	cols, rows := m.Cols(), m.Rows()
+	if cols < 3 || cols > 4 || (rows != 3 && rows != cols) {
+		panic(fmt.Sprintf("unsupported type. cols=%d rows=%d ", cols, rows))
+	}
+	var l_res Vec3
+	for i := 0; i < 3; i++ {
+		l_row := Vec3{Point(m.Get(0, i)), Point(m.Get(1, i)), Point(m.Get(2, i))}
+		l_res[i] = Vec3Dot(&l_row, t)
+		if cols == 4 {
+			l_trans := Point(m.Get(3, i))
+			l_res[i] = Add(&l_res[i], &l_trans)
+		}
+	}
+	return l_res
+}
+
+func (t *Vec3) Transform(m generic.T) *Vec3 {
+	*t = t.Transformed(m)
+	return t
+}
@@ -0,0 +1,81 @@
+package interval
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/mat2"
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/mat3x4"
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestVec3Transformed(t *testing.T) {
+	var m4 mat4.Mat4
+	m4.AssignEulerRotation(0.3, 1.1, -0.4)
+	m4.SetTranslation(&vector3.Vector{1, -2, 5})
+	m34 := mat3x4.FromMat4(&m4)
+	var m3 mat3.Mat3
+	for i := range m3 {
+		m3[i] = vector3.Vector{m4[i][0], m4[i][1], m4[i][2]}
+	}
+	b := vector3.Box{Min: vector3.Vector{-1, 0, 2}, Max: vector3.Vector{3, 0.5, 4}}
+	cases := []struct {
+		name  string
+		m     interface{ Get(col, row int) float32 }
+		trans bool
+		iv    Vec3
+		box   vector3.Box
+	}{
+		{"mat3", &m3, false, func() Vec3 { v := Vec3FromBox(&b); return v.Transformed(&m3) }(), b.Transformed(&m3)},
+		{"mat3x4", &m34, true, func() Vec3 { v := Vec3FromBox(&b); return v.Transformed(&m34) }(), b.Transformed(&m34)},
+		{"mat4", &m4, true, func() Vec3 { v := Vec3FromBox(&b); return v.Transformed(&m4) }(), b.Transformed(&m4)},
+	}
+	for _, c := range cases {
+		// 每个顶点按float64精确变换后都在区间内
+		for _, p := range b.Corners() {
+			for i := 0; i < 3; i++ {
+				var x float64
+				for j := 0; j < 3; j++ {
+					x += float64(c.m.Get(j, i)) * float64(p[j])
+				}
+				if c.trans {
+					x += float64(c.m.Get(3, i))
+				}
+				if float64(c.iv[i].Lo) > x || float64(c.iv[i].Hi) < x {
+					t.Errorf("%s: corner %v axis %d = %v outside %v", c.name, p, i, x, c.iv[i])
+				}
+			}
+		}
+		// 与Box.Transformed只差舍入
+		ib := c.iv.Box()
+		if !ib.Min.ApproxEqual(&c.box.Min, 1e-5) || !ib.Max.ApproxEqual(&c.box.Max, 1e-5) {
+			t.Errorf("%s: Transformed = %v, Box.Transformed = %v", c.name, ib, c.box)
+		}
+	}
+}
+
+func TestVec3Ops(t *testing.T) {
+	a := Vec3FromPoint(&vector3.Vector{1, 2, 3})
+	b := Vec3{{-1, 1}, {0, 2}, {2, 2}}
+	if d := Vec3Dot(&a, &b); d != (Interval{5, 11}) {
+		t.Errorf("Vec3Dot = %v", d)
+	}
+	if l := b.LengthSqr(); l != (Interval{4, 9}) {
+		t.Errorf("LengthSqr = %v", l)
+	}
+	if s := Vec3Sub(&b, &a); s != (Vec3{{-2, 0}, {-2, 0}, {-1, -1}}) {
+		t.Errorf("Vec3Sub = %v", s)
+	}
+	f := Interval{-1, 2}
+	if s := Vec3Scale(&a, &f); s != (Vec3{{-1, 2}, {-2, 4}, {-3, 6}}) {
+		t.Errorf("Vec3Scale = %v", s)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Transformed with a 2x2 matrix did not panic")
+		}
+	}()
+	var m2 mat2.Mat2
+	a.Transformed(&m2)
+}
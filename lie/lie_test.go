@@ -0,0 +1,164 @@
+package lie
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestHatVee(t *testing.T) {
+	w := vector3.Vector{0.3, -1.2, 2}
+	v := vector3.Vector{-0.5, 0.7, 1.1}
+	h := Hat(&w)
+	got := h.MulVec3(&v)
+	if want := vector3.Cross(&w, &v); !got.ApproxEqual(&want, 1e-6) {
+		t.Errorf("Hat(w) * v = %v, want w x v = %v", got, want)
+	}
+	if back := Vee(&h); !back.ApproxEqual(&w, 0) {
+		t.Errorf("Vee(Hat(w)) = %v, want %v", back, w)
+	}
+
+	xi := Twist{w, v}
+	m := xi.Hat()
+	if back := VeeSE3(&m); !back.W.ApproxEqual(&w, 0) || !back.V.ApproxEqual(&v, 0) {
+		t.Errorf("VeeSE3(Hat(xi)) = %v, want %v", back, xi)
+	}
+}
+
+func TestExpLogSO3(t *testing.T) {
+	cases := []struct {
+		name string
+		w    vector3.Vector
+	}{
+		{"zero", vector3.Vector{0, 0, 0}},
+		{"tiny", vector3.Vector{1e-5, 0, 2e-5}},
+		{"generic", vector3.Vector{0.3, -0.2, 0.5}},
+		{"large", vector3.Vector{2.1, 0.4, -1.3}},
+		{"near pi", vector3.Vector{0, 0, 3.1}},
+		{"near pi oblique", vector3.Vector{3.0, 0.1, -0.2}},
+	}
+	for _, c := range cases {
+		r := ExpSO3(&c.w)
+		// 正交且行列式为1
+		var rtr mat3.Mat3
+		rt := r
+		rt.Transpose()
+		rtr.AssignMul(&rt, &r)
+		if !rtr.ApproxEqual(&mat3.Ident, 1e-5) {
+			t.Errorf("%s: exp(w) not orthogonal: %v", c.name, rtr)
+		}
+		if got := LogSO3(&r); !got.ApproxEqual(&c.w, 1e-4) {
+			t.Errorf("%s: log(exp(w)) = %v, want %v", c.name, got, c.w)
+		}
+	}
+
+	// 绕z转90度
+	r := ExpSO3(&vector3.Vector{0, 0, 1.5707964})
+	got := r.MulVec3(&vector3.Vector{1, 0, 0})
+	if want := (vector3.Vector{0, 1, 0}); !got.ApproxEqual(&want, 1e-6) {
+		t.Errorf("exp(pi/2 z) * x = %v", got)
+	}
+}
+
+func TestExpLogSE3(t *testing.T) {
+	cases := []struct {
+		name string
+		xi   Twist
+	}{
+		{"zero", Twist{}},
+		{"translation", Twist{vector3.Vector{}, vector3.Vector{1, 2, 3}}},
+		{"tiny rotation", Twist{vector3.Vector{0, 0, 1e-6}, vector3.Vector{1, 2, 3}}},
+		{"generic", Twist{vector3.Vector{0.4, 0.5, -1.2}, vector3.Vector{1, 2, 3}}},
+		{"large", Twist{vector3.Vector{-2.5, 0.3, 1}, vector3.Vector{-4, 0.5, 2}}},
+	}
+	for _, c := range cases {
+		m := ExpSE3(&c.xi)
+		got := LogSE3(&m)
+		if !got.W.ApproxEqual(&c.xi.W, 1e-4) || !got.V.ApproxEqual(&c.xi.V, 1e-4) {
+			t.Errorf("%s: log(exp(xi)) = %v, want %v", c.name, got, c.xi)
+		}
+	}
+
+	// 绕z转90度同时沿z平移: 原点上的点只沿轴移动
+	m := ExpSE3(&Twist{vector3.Vector{0, 0, 1.5707964}, vector3.Vector{0, 0, 2}})
+	got := m.MulVec3(&vector3.Vector{1, 0, 0})
+	if want := (vector3.Vector{0, 1, 2}); !got.ApproxEqual(&want, 1e-5) {
+		t.Errorf("screw motion = %v, want %v", got, want)
+	}
+}
+
+func TestAdjoint(t *testing.T) {
+	xi := Twist{vector3.Vector{0.4, 0.5, -1.2}, vector3.Vector{1, 2, 3}}
+	eta := Twist{vector3.Vector{-0.3, 0.1, 0.2}, vector3.Vector{0.5, -1, 0.25}}
+	m := ExpSE3(&xi)
+
+	ad := Adjoint(&m)
+	a1 := ad.MulTwist(&eta)
+	a2 := AdjointTwist(&m, &eta)
+	if !a1.W.ApproxEqual(&a2.W, 1e-5) || !a1.V.ApproxEqual(&a2.V, 1e-5) {
+		t.Errorf("Adjoint * eta = %v, AdjointTwist = %v", a1, a2)
+	}
+
+	// exp(Ad_T eta) = T exp(eta) T^-1
+	got := ExpSE3(&a1)
+	e := ExpSE3(&eta)
+	inv := rigidInverse(&m)
+	var tmp, want mat4.Mat4
+	tmp.AssignMul(&m, &e)
+	want.AssignMul(&tmp, &inv)
+	if !got.ApproxEqual(&want, 1e-4) {
+		t.Errorf("exp(Ad eta) = %v, want %v", got, want)
+	}
+
+	// ad_xi eta 为李括号 [xi, eta] = (w1 x w2, w1 x v2 + v1 x w2)
+	br := AdjointAlgebra(&xi)
+	gotBr := br.MulTwist(&eta)
+	wantW := vector3.Cross(&xi.W, &eta.W)
+	wv := vector3.Cross(&xi.W, &eta.V)
+	vw := vector3.Cross(&xi.V, &eta.W)
+	wantV := vector3.Add(&wv, &vw)
+	if !gotBr.W.ApproxEqual(&wantW, 1e-5) || !gotBr.V.ApproxEqual(&wantV, 1e-5) {
+		t.Errorf("ad_xi eta = %v, want (%v, %v)", gotBr, wantW, wantV)
+	}
+}
+
+func TestInterp(t *testing.T) {
+	a := ExpSO3(&vector3.Vector{0.2, 0, 0})
+	b := ExpSO3(&vector3.Vector{0.2, 0, 2})
+	for _, f := range []float32{0, 1} {
+		want := a
+		if f == 1 {
+			want = b
+		}
+		if got := InterpSO3(&a, &b, f); !got.ApproxEqual(&want, 1e-5) {
+			t.Errorf("InterpSO3(%v) = %v, want %v", f, got, want)
+		}
+	}
+	id := mat3.Ident
+	c := ExpSO3(&vector3.Vector{0, 0, 2})
+	mid := InterpSO3(&id, &c, 0.5)
+	if got, want := LogSO3(&mid), (vector3.Vector{0, 0, 1}); !got.ApproxEqual(&want, 1e-5) {
+		t.Errorf("InterpSO3 midpoint = %v, want %v", got, want)
+	}
+
+	m := ExpSE3(&Twist{vector3.Vector{0.4, 0.5, -1.2}, vector3.Vector{1, 2, 3}})
+	ident := mat4.Ident
+	for _, f := range []float32{0, 1} {
+		want := ident
+		if f == 1 {
+			want = m
+		}
+		if got := InterpSE3(&ident, &m, f); !got.ApproxEqual(&want, 1e-5) {
+			t.Errorf("InterpSE3(%v) = %v, want %v", f, got, want)
+		}
+	}
+	// 中点两次复合得到终点
+	h := InterpSE3(&ident, &m, 0.5)
+	var sq mat4.Mat4
+	sq.AssignMul(&h, &h)
+	if !sq.ApproxEqual(&m, 1e-4) {
+		t.Errorf("half * half = %v, want %v", sq, m)
+	}
+}
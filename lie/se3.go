@@ -0,0 +1,187 @@
+/*
+ * SE(3) 李群/李代数  刚体变换(mat4)与twist之间的指数/对数映射, 伴随矩阵
+ *   twist顺序为(W角速度, V线速度), mat4需为无缩放的刚体变换
+ */
+package lie
+
+import (
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 螺旋运动 (ω, v)
+type Twist struct {
+	W vector3.Vector // 角速度
+	V vector3.Vector // 线速度
+}
+
+func (t *Twist) Scale(f float32) *Twist {
+	t.W.Scale(f)
+	t.V.Scale(f)
+	return t
+}
+
+func (t *Twist) Scaled(f float32) Twist {
+	r := *t
+	return *r.Scale(f)
+}
+
+// 4x4 se(3)矩阵 [[ω]x v; 0 0]
+func (t *Twist) Hat() mat4.Mat4 {
+	k := Hat(&t.W)
+	return mat4.Mat4{
+		{k[0][0], k[0][1], k[0][2], 0},
+		{k[1][0], k[1][1], k[1][2], 0},
+		{k[2][0], k[2][1], k[2][2], 0},
+		{t.V[0], t.V[1], t.V[2], 0},
+	}
+}
+
+// Twist.Hat的逆
+func VeeSE3(m *mat4.Mat4) Twist {
+	k := rotationOf(m)
+	return Twist{Vee(&k), vector3.Vector{m[3][0], m[3][1], m[3][2]}}
+}
+
+// exp: R = exp([ω]x), p = J * v
+// J = I + B[ω]x + C[ω]x^2, C = (theta - sin(theta))/theta^3
+func ExpSE3(t *Twist) mat4.Mat4 {
+	theta2 := t.W.LengthSqr()
+	a, b := rodriguesAB(theta2)
+	c := rodriguesC(theta2)
+
+	r := rodrigues(&t.W, a, b)
+	j := rodrigues(&t.W, b, c)
+	p := j.MulVec3(&t.V)
+	return fromRotationTranslation(&r, &p)
+}
+
+// log: ω = log(R), v = J^-1 * p
+// J^-1 = I - 0.5[ω]x + D[ω]x^2, D = (1 - A/(2B))/theta^2
+func LogSE3(m *mat4.Mat4) Twist {
+	r := rotationOf(m)
+	w := LogSO3(&r)
+	theta2 := w.LengthSqr()
+
+	var d float32
+	if theta2 < kSmallAngle*kSmallAngle {
+		d = 1.0/12 + theta2/720
+	} else {
+		a, b := rodriguesAB(theta2)
+		d = (1 - a/(2*b)) / theta2
+	}
+	jinv := rodrigues(&w, -0.5, d)
+	p := vector3.Vector{m[3][0], m[3][1], m[3][2]}
+	return Twist{w, jinv.MulVec3(&p)}
+}
+
+// 测地线插值 a * exp(t * log(a^-1 * b)), t=0为a, t=1为b
+func InterpSE3(a, b *mat4.Mat4, t float32) mat4.Mat4 {
+	ainv := rigidInverse(a)
+	var d mat4.Mat4
+	d.AssignMul(&ainv, b)
+	xi := LogSE3(&d)
+	xi.Scale(t)
+	e := ExpSE3(&xi)
+	var res mat4.Mat4
+	res.AssignMul(a, &e)
+	return res
+}
+
+// 6x6矩阵, 列存储, 作用于(ω, v)
+type Mat6 [6][6]float32
+
+func (t *Mat6) MulTwist(xi *Twist) Twist {
+	var in, out [6]float32
+	copy(in[:3], xi.W[:])
+	copy(in[3:], xi.V[:])
+	for col := 0; col < 6; col++ {
+		for row := 0; row < 6; row++ {
+			out[row] += t[col][row] * in[col]
+		}
+	}
+	return Twist{
+		vector3.Vector{out[0], out[1], out[2]},
+		vector3.Vector{out[3], out[4], out[5]},
+	}
+}
+
+// 伴随矩阵 Ad_T = [[R, 0], [[p]x R, R]]
+// 将物体坐标系下的twist变换到世界坐标系: xi' = Ad_T * xi
+func Adjoint(m *mat4.Mat4) Mat6 {
+	r := rotationOf(m)
+	p := vector3.Vector{m[3][0], m[3][1], m[3][2]}
+	ph := Hat(&p)
+	pr := mat3.Mul(&ph, &r)
+
+	var res Mat6
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			res[col][row] = r[col][row]
+			res[col+3][row+3] = r[col][row]
+			res[col][row+3] = pr[col][row]
+		}
+	}
+	return res
+}
+
+// 不构造矩阵直接计算 Ad_T * xi
+func AdjointTwist(m *mat4.Mat4, xi *Twist) Twist {
+	r := rotationOf(m)
+	p := vector3.Vector{m[3][0], m[3][1], m[3][2]}
+	w := r.MulVec3(&xi.W)
+	v := r.MulVec3(&xi.V)
+	pw := vector3.Cross(&p, &w)
+	return Twist{w, *v.Add(&pw)}
+}
+
+// 李括号的矩阵形式 ad_xi = [[ω]x, 0], [[v]x, [ω]x]]
+func AdjointAlgebra(xi *Twist) Mat6 {
+	wh := Hat(&xi.W)
+	vh := Hat(&xi.V)
+	var res Mat6
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			res[col][row] = wh[col][row]
+			res[col+3][row+3] = wh[col][row]
+			res[col][row+3] = vh[col][row]
+		}
+	}
+	return res
+}
+
+func rodriguesC(theta2 float32) float32 {
+	if theta2 < kSmallAngle*kSmallAngle {
+		return 1.0/6 - theta2/120
+	}
+	theta := math.Sqrt(theta2)
+	return (theta - math.Sin(theta)) / (theta2 * theta)
+}
+
+func rotationOf(m *mat4.Mat4) mat3.Mat3 {
+	return mat3.Mat3{
+		{m[0][0], m[0][1], m[0][2]},
+		{m[1][0], m[1][1], m[1][2]},
+		{m[2][0], m[2][1], m[2][2]},
+	}
+}
+
+func fromRotationTranslation(r *mat3.Mat3, p *vector3.Vector) mat4.Mat4 {
+	return mat4.Mat4{
+		{r[0][0], r[0][1], r[0][2], 0},
+		{r[1][0], r[1][1], r[1][2], 0},
+		{r[2][0], r[2][1], r[2][2], 0},
+		{p[0], p[1], p[2], 1},
+	}
+}
+
+// 刚体变换的逆 (R^T, -R^T p)
+func rigidInverse(m *mat4.Mat4) mat4.Mat4 {
+	rt := rotationOf(m)
+	rt.Transpose()
+	p := vector3.Vector{-m[3][0], -m[3][1], -m[3][2]}
+	p = rt.MulVec3(&p)
+	return fromRotationTranslation(&rt, &p)
+}
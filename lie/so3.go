@@ -0,0 +1,115 @@
+/*
+ * SO(3) 李群/李代数  旋转矩阵与旋转向量(轴*角)之间的指数/对数映射
+ */
+package lie
+
+import (
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 小角度时改用泰勒展开
+const kSmallAngle = 1e-3
+
+// 反对称矩阵 [w]x, 满足 Hat(w) * v = w x v
+func Hat(w *vector3.Vector) mat3.Mat3 {
+	return mat3.Mat3{
+		{0, w[2], -w[1]},
+		{-w[2], 0, w[0]},
+		{w[1], -w[0], 0},
+	}
+}
+
+// Hat的逆, 取m的反对称部分
+func Vee(m *mat3.Mat3) vector3.Vector {
+	return vector3.Vector{
+		0.5 * (m[1][2] - m[2][1]),
+		0.5 * (m[2][0] - m[0][2]),
+		0.5 * (m[0][1] - m[1][0]),
+	}
+}
+
+// Rodrigues: exp([w]x) = I + A[w]x + B[w]x^2
+// A = sin(theta)/theta, B = (1-cos(theta))/theta^2, theta = |w|
+func ExpSO3(w *vector3.Vector) mat3.Mat3 {
+	theta2 := w.LengthSqr()
+	a, b := rodriguesAB(theta2)
+	return rodrigues(w, a, b)
+}
+
+// 旋转矩阵 -> 旋转向量, 角度在[0,pi]
+// r需为正交矩阵
+func LogSO3(r *mat3.Mat3) vector3.Vector {
+	c := (r.Trace() - 1) * 0.5
+	if c > 1 {
+		c = 1
+	} else if c < -1 {
+		c = -1
+	}
+	v := Vee(r) // sin(theta) * axis
+	// 用atan2, 比acos在0和pi附近精度高
+	theta := math.Atan2(v.Length(), c)
+
+	if theta < kSmallAngle {
+		// theta/sin(theta) ~ 1 + theta^2/6
+		return *v.Scale(1 + theta*theta/6)
+	}
+	if c > -0.5 {
+		return *v.Scale(theta / math.Sin(theta))
+	}
+
+	// 接近pi时sin(theta)过小, 由对称部分 (R+R^T)/2 = cI + (1-c)nn^T 求轴
+	var nn [3]vector3.Vector
+	k := 0
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			s := 0.5 * (r[i][j] + r[j][i])
+			if i == j {
+				s -= c
+			}
+			nn[i][j] = s / (1 - c)
+		}
+		if nn[i][i] > nn[k][k] {
+			k = i
+		}
+	}
+	axis := nn[k].Scaled(1 / math.Sqrt(nn[k][k]))
+	if vector3.Dot(&axis, &v) < 0 {
+		axis = axis.Inverted()
+	}
+	return *axis.Scale(theta)
+}
+
+// 测地线插值 a * exp(t * log(a^T * b)), t=0为a, t=1为b
+func InterpSO3(a, b *mat3.Mat3, t float32) mat3.Mat3 {
+	at := *a
+	at.Transpose()
+	d := mat3.Mul(&at, b)
+	w := LogSO3(d)
+	w.Scale(t)
+	e := ExpSO3(&w)
+	return *mat3.Mul(a, &e)
+}
+
+func rodriguesAB(theta2 float32) (a, b float32) {
+	if theta2 < kSmallAngle*kSmallAngle {
+		return 1 - theta2/6, 0.5 - theta2/24
+	}
+	theta := math.Sqrt(theta2)
+	s, c := math.Sincos(theta)
+	return s / theta, (1 - c) / theta2
+}
+
+// I + a[w]x + b[w]x^2
+func rodrigues(w *vector3.Vector, a, b float32) mat3.Mat3 {
+	k := Hat(w)
+	k2 := mat3.Mul(&k, &k)
+	res := mat3.Ident
+	for i := range res {
+		for j := range res[i] {
+			res[i][j] += a*k[i][j] + b*k2[i][j]
+		}
+	}
+	return res
+}
@@ -82,6 +82,16 @@ func (t *Mat2) IsZero() bool {
 
 //-------------------------------------------- 实现generic.T end -------------------------------------
 
+// 各元素在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual)
+func (t *Mat2) ApproxEqual(o *Mat2, tol float32) bool {
+	for i := range t {
+		if !t[i].ApproxEqual(&o[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
 func (t *Mat2) Scale(f float32) *Mat2 {
 	t[0][0] *= f
 	t[1][1] *= f
@@ -0,0 +1,23 @@
+package mat2
+
+import "testing"
+
+func TestApproxEqual(t *testing.T) {
+	tests := []struct {
+		a, b Mat2
+		tol  float32
+		want bool
+	}{
+		{Ident, Ident, 0, true},
+		{Ident, Mat2{{1, 1e-7}, {0, 1}}, 1e-6, true},
+		{Ident, Mat2{{1, 0}, {0, 1.01}}, 1e-3, false},
+		// 大数按相对误差
+		{Mat2{{1e6, 0}, {0, 1e6}}, Mat2{{1e6 + 0.5, 0}, {0, 1e6}}, 1e-6, true},
+		{Ident, Mat2{{0, 1}, {1, 0}}, 1e-3, false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.ApproxEqual(&tt.b, tt.tol); got != tt.want {
+			t.Errorf("%v.ApproxEqual(%v, %v) = %v, want %v", tt.a, tt.b, tt.tol, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * 2行3列矩阵  2D仿射变换 [A|t], 省略的第三行为(0,0,1)
+ *   列存储 每个vec代表一列, 第3列为平移; 内存布局(列步长8字节)与std430下的GLSL mat3x2一致,
+ *   std140要求列按16字节对齐, 用Std140()
+ */
+package mat2x3
+
+import (
+	"unsafe"
+
+	"github.com/tinysss/smath/mat2"
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector2"
+)
+
+type Mat2x3 [3]vector2.Vector
+
+var (
+	Zero  = Mat2x3{}
+	Ident = Mat2x3{
+		{1, 0},
+		{0, 1},
+		{0, 0},
+	}
+)
+
+func New(v1, v2, v3 vector2.Vector) *Mat2x3 {
+	return &Mat2x3{v1, v2, v3}
+}
+
+// 线性部分m, 平移trans
+func FromMat2(m *mat2.Mat2, trans *vector2.Vector) Mat2x3 {
+	return Mat2x3{m[0], m[1], *trans}
+}
+
+// 取mat3的前两行, mat3需为仿射矩阵(第三行为0,0,1)
+func FromMat3(m *mat3.Mat3) Mat2x3 {
+	return Mat2x3{
+		{m[0][0], m[0][1]},
+		{m[1][0], m[1][1]},
+		{m[2][0], m[2][1]},
+	}
+}
+
+func (t *Mat2x3) Array() *[6]float32 {
+	return (*[6]float32)(unsafe.Pointer(t))
+}
+
+//-------------------------------------------- 实现generic.T begin-------------------------------------
+func (t *Mat2x3) Cols() int {
+	return 3
+}
+
+func (t *Mat2x3) Rows() int {
+	return 2
+}
+
+func (t *Mat2x3) Size() int {
+	return 6
+}
+
+func (t *Mat2x3) Slice() []float32 {
+	return t.Array()[:]
+}
+
+func (t *Mat2x3) Get(col, row int) float32 {
+	return t[col][row]
+}
+
+func (t *Mat2x3) IsZero() bool {
+	return *t == Zero
+}
+
+//-------------------------------------------- 实现generic.T end -------------------------------------
+
+// 各元素在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual)
+func (t *Mat2x3) ApproxEqual(o *Mat2x3, tol float32) bool {
+	for i := range t {
+		if !t[i].ApproxEqual(&o[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// 补上第三行(0,0,1)
+func (t *Mat2x3) ToMat3() mat3.Mat3 {
+	return mat3.Mat3{
+		{t[0][0], t[0][1], 0},
+		{t[1][0], t[1][1], 0},
+		{t[2][0], t[2][1], 1},
+	}
+}
+
+// 线性部分
+func (t *Mat2x3) Mat2() mat2.Mat2 {
+	return mat2.Mat2{t[0], t[1]}
+}
+
+func (t *Mat2x3) Translation() vector2.Vector {
+	return t[2]
+}
+
+func (t *Mat2x3) SetTranslation(v *vector2.Vector) *Mat2x3 {
+	t[2] = *v
+	return t
+}
+
+// 变换点 A*p + t
+func (t *Mat2x3) TransformPoint(p *vector2.Vector) vector2.Vector {
+	return vector2.Vector{
+		t[0][0]*p[0] + t[1][0]*p[1] + t[2][0],
+		t[0][1]*p[0] + t[1][1]*p[1] + t[2][1],
+	}
+}
+
+// 变换方向 A*v, 不含平移
+func (t *Mat2x3) TransformDir(v *vector2.Vector) vector2.Vector {
+	return vector2.Vector{
+		t[0][0]*v[0] + t[1][0]*v[1],
+		t[0][1]*v[0] + t[1][1]*v[1],
+	}
+}
+
+// t = a * b, 先应用b再应用a
+func (t *Mat2x3) AssignMul(a, b *Mat2x3) *Mat2x3 {
+	*t = Mul(a, b)
+	return t
+}
+
+func (t *Mat2x3) Det() float32 {
+	return t[0][0]*t[1][1] - t[1][0]*t[0][1]
+}
+
+// 仿射逆 [A^-1 | -A^-1 t], 线性部分奇异时ok为false
+func (t *Mat2x3) InverseAffine() (inv Mat2x3, ok bool) {
+	det := t.Det()
+	if sutil.FloatEqualThreshold(det, 0, sutil.MinNormal) {
+		return Ident, false
+	}
+	oo := 1 / det
+	inv[0] = vector2.Vector{t[1][1] * oo, -t[0][1] * oo}
+	inv[1] = vector2.Vector{-t[1][0] * oo, t[0][0] * oo}
+	trans := inv.TransformDir(&t[2])
+	inv[2] = trans.Inverted()
+	return inv, true
+}
+
+// std140布局, 每列补齐到vec4
+func (t *Mat2x3) Std140() [3][4]float32 {
+	return [3][4]float32{
+		{t[0][0], t[0][1]},
+		{t[1][0], t[1][1]},
+		{t[2][0], t[2][1]},
+	}
+}
+
+func Mul(a, b *Mat2x3) Mat2x3 {
+	return Mat2x3{
+		a.TransformDir(&b[0]),
+		a.TransformDir(&b[1]),
+		a.TransformPoint(&b[2]),
+	}
+}
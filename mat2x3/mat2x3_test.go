@@ -0,0 +1,112 @@
+package mat2x3
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/tinysss/smath/mat2"
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 旋转, 缩放后平移的2D仿射mat3
+func affine(angle, sx float32, trans vector2.Vector) mat3.Mat3 {
+	var m mat3.Mat3
+	m.AssignZRotation(angle)
+	m[0].Scale(sx)
+	m[2] = vector3.Vector{trans[0], trans[1], 1}
+	return m
+}
+
+func TestConvert(t *testing.T) {
+	m3 := affine(0.5, 2, vector2.Vector{3, -1})
+	a := FromMat3(&m3)
+	if back := a.ToMat3(); back != m3 {
+		t.Errorf("ToMat3(FromMat3(m)) = %v, want %v", back, m3)
+	}
+	m2 := a.Mat2()
+	tr := a.Translation()
+	if b := FromMat2(&m2, &tr); b != a {
+		t.Errorf("FromMat2(Mat2, Translation) = %v, want %v", b, a)
+	}
+	var s Mat2x3
+	if s.SetTranslation(&vector2.Vector{4, 5}); s[2] != (vector2.Vector{4, 5}) {
+		t.Errorf("SetTranslation = %v", s)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	m3 := affine(0.5, 2, vector2.Vector{3, -1})
+	a := FromMat3(&m3)
+	for _, p := range []vector2.Vector{{0, 0}, {1, 2}, {-3, 0.5}} {
+		p3 := vector3.Vector{p[0], p[1], 1}
+		w := m3.MulVec3(&p3)
+		want := vector2.Vector{w[0], w[1]}
+		if got := a.TransformPoint(&p); !got.ApproxEqual(&want, 1e-5) {
+			t.Errorf("TransformPoint(%v) = %v, want %v", p, got, want)
+		}
+		m2 := mat2.Mat2{a[0], a[1]}
+		wantDir := m2.MulVec2(&p)
+		if got := a.TransformDir(&p); !got.ApproxEqual(&wantDir, 1e-5) {
+			t.Errorf("TransformDir(%v) = %v, want %v", p, got, wantDir)
+		}
+	}
+}
+
+func TestMulInverse(t *testing.T) {
+	m3 := affine(0.5, 2, vector2.Vector{3, -1})
+	n3 := affine(-1.2, 0.5, vector2.Vector{0, 4})
+	a := FromMat3(&m3)
+	b := FromMat3(&n3)
+
+	var ab3 mat3.Mat3
+	ab3.AssignMul(&m3, &n3)
+	var ab Mat2x3
+	ab.AssignMul(&a, &b)
+	if got := ab.ToMat3(); !got.ApproxEqual(&ab3, 1e-5) {
+		t.Errorf("Mul = %v, want %v", got, ab3)
+	}
+	if d := a.Det(); !sutil.AlmostEqual(d, 2, 1e-5, 1e-5) {
+		t.Errorf("Det = %v, want 2", d)
+	}
+
+	inv, ok := a.InverseAffine()
+	if !ok {
+		t.Fatalf("InverseAffine failed")
+	}
+	if id := Mul(&a, &inv); !id.ApproxEqual(&Ident, 1e-5) {
+		t.Errorf("a * inv = %v", id)
+	}
+	if id := Mul(&inv, &a); !id.ApproxEqual(&Ident, 1e-5) {
+		t.Errorf("inv * a = %v", id)
+	}
+
+	sing := Mat2x3{{1, 2}, {2, 4}, {1, 1}}
+	if _, ok := sing.InverseAffine(); ok {
+		t.Errorf("InverseAffine of singular matrix")
+	}
+}
+
+// std430下GLSL mat3x2: 3列vec2, 列步长8; std140列步长16
+func TestLayout(t *testing.T) {
+	var a Mat2x3
+	if s := unsafe.Sizeof(a); s != 24 {
+		t.Errorf("sizeof Mat2x3 = %d, want 24", s)
+	}
+	a = Mat2x3{{1, 2}, {3, 4}, {5, 6}}
+	arr := a.Array()
+	for i := range arr {
+		if arr[i] != float32(i+1) {
+			t.Fatalf("Array() = %v", *arr)
+		}
+	}
+	want := [3][4]float32{{1, 2, 0, 0}, {3, 4, 0, 0}, {5, 6, 0, 0}}
+	if got := a.Std140(); got != want {
+		t.Errorf("Std140 = %v, want %v", got, want)
+	}
+	if s := unsafe.Sizeof(a.Std140()); s != 48 {
+		t.Errorf("sizeof Std140 = %d, want 48", s)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+ * 2D仿射变换  列存储, 第2列为平移, 最后一行为(0,0,1)
+ *   带2的方法都只读写前两行, 按仿射矩阵处理
+ */
+package mat3
+
+import (
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector2"
+)
+
+// 平移阵
+func (t *Mat3) AssignTranslation2(v *vector2.Vector) *Mat3 {
+	*t = Ident
+	t[2][0] = v[0]
+	t[2][1] = v[1]
+	return t
+}
+
+// 缩放阵
+func (t *Mat3) AssignScaling2(s *vector2.Vector) *Mat3 {
+	*t = Ident
+	t[0][0] = s[0]
+	t[1][1] = s[1]
+	return t
+}
+
+// 绕pivot逆时针旋转angle弧度  T(pivot) * R * T(-pivot)
+func (t *Mat3) AssignRotationAround2(pivot *vector2.Vector, angle float32) *Mat3 {
+	t.AssignZRotation(angle)
+	c, s := t[0][0], t[0][1]
+	t[2][0] = pivot[0] - c*pivot[0] + s*pivot[1]
+	t[2][1] = pivot[1] - s*pivot[0] - c*pivot[1]
+	return t
+}
+
+func (t *Mat3) AssignRotationAround2Of(pivot *vector2.Vector, angle sutil.Angle) *Mat3 {
+	return t.AssignRotationAround2(pivot, float32(angle.Rad()))
+}
+
+// 错切阵  x' = x + tan(xAngle)*y, y' = y + tan(yAngle)*x
+func (t *Mat3) AssignSkew2(xAngle, yAngle float32) *Mat3 {
+	*t = Ident
+	t[1][0] = math.Tan(xAngle)
+	t[0][1] = math.Tan(yAngle)
+	return t
+}
+
+func (t *Mat3) AssignSkew2Of(xAngle, yAngle sutil.Angle) *Mat3 {
+	return t.AssignSkew2(float32(xAngle.Rad()), float32(yAngle.Rad()))
+}
+
+// T * R * S, 先缩放再旋转最后平移
+func (t *Mat3) AssignTRS2(trans *vector2.Vector, angle float32, scale *vector2.Vector) *Mat3 {
+	sina, cosa := math.Sincos(angle)
+	*t = Mat3{
+		{cosa * scale[0], sina * scale[0], 0},
+		{-sina * scale[1], cosa * scale[1], 0},
+		{trans[0], trans[1], 1},
+	}
+	return t
+}
+
+func (t *Mat3) AssignTRS2Of(trans *vector2.Vector, angle sutil.Angle, scale *vector2.Vector) *Mat3 {
+	return t.AssignTRS2(trans, float32(angle.Rad()), scale)
+}
+
+// 拆成AssignTRS2的参数
+// 旋转取自第0列, scale[1]带行列式符号(镜像体现为y缩放为负); 含错切时错切部分丢失
+func (t *Mat3) Decompose2() (trans vector2.Vector, angle float32, scale vector2.Vector) {
+	trans = vector2.Vector{t[2][0], t[2][1]}
+	sx := math.Sqrt(t[0][0]*t[0][0] + t[0][1]*t[0][1])
+	if sx == 0 {
+		// 第0列退化, 用第1列求旋转
+		sy := math.Sqrt(t[1][0]*t[1][0] + t[1][1]*t[1][1])
+		return trans, math.Atan2(-t[1][0], t[1][1]), vector2.Vector{0, sy}
+	}
+	angle = math.Atan2(t[0][1], t[0][0])
+	scale = vector2.Vector{sx, t.Det2() / sx}
+	return trans, angle, scale
+}
+
+func (t *Mat3) Decompose2Radians() (trans vector2.Vector, angle sutil.Radians, scale vector2.Vector) {
+	trans, a, scale := t.Decompose2()
+	return trans, sutil.Radians(a), scale
+}
+
+func (t *Mat3) Translation2() vector2.Vector {
+	return vector2.Vector{t[2][0], t[2][1]}
+}
+
+// 左上2x2的行列式
+func (t *Mat3) Det2() float32 {
+	return t[0][0]*t[1][1] - t[1][0]*t[0][1]
+}
+
+// 变换点 A*p + t
+func (t *Mat3) TransformPoint2(p *vector2.Vector) vector2.Vector {
+	return vector2.Vector{
+		t[0][0]*p[0] + t[1][0]*p[1] + t[2][0],
+		t[0][1]*p[0] + t[1][1]*p[1] + t[2][1],
+	}
+}
+
+// 变换方向 A*v, 不含平移
+func (t *Mat3) TransformDir2(v *vector2.Vector) vector2.Vector {
+	return vector2.Vector{
+		t[0][0]*v[0] + t[1][0]*v[1],
+		t[0][1]*v[0] + t[1][1]*v[1],
+	}
+}
+
+// 在当前变换之后绕pivot旋转  t = R(pivot) * t
+func (t *Mat3) RotateAround2(pivot *vector2.Vector, angle float32) *Mat3 {
+	var l_rot Mat3
+	l_rot.AssignRotationAround2(pivot, angle)
+	l_temp := *t
+	return t.AssignAffineMul2(&l_rot, &l_temp)
+}
+
+// t = a * b, 只算仿射部分, 比AssignMul少算最后一行
+func (t *Mat3) AssignAffineMul2(a, b *Mat3) *Mat3 {
+	c0 := a.TransformDir2(&vector2.Vector{b[0][0], b[0][1]})
+	c1 := a.TransformDir2(&vector2.Vector{b[1][0], b[1][1]})
+	c2 := a.TransformPoint2(&vector2.Vector{b[2][0], b[2][1]})
+	*t = Mat3{
+		{c0[0], c0[1], 0},
+		{c1[0], c1[1], 0},
+		{c2[0], c2[1], 1},
+	}
+	return t
+}
+
+// 仿射逆 [A^-1 | -A^-1 t], 线性部分奇异时ok为false
+func (t *Mat3) InverseAffine2() (inv Mat3, ok bool) {
+	det := t.Det2()
+	if sutil.FloatEqualThreshold(det, 0, sutil.MinNormal) {
+		return Ident, false
+	}
+	oo := 1 / det
+	inv = Mat3{
+		{t[1][1] * oo, -t[0][1] * oo, 0},
+		{-t[1][0] * oo, t[0][0] * oo, 0},
+		{0, 0, 1},
+	}
+	trans := inv.TransformDir2(&vector2.Vector{t[2][0], t[2][1]})
+	inv[2][0] = -trans[0]
+	inv[2][1] = -trans[1]
+	return inv, true
+}
+
+// 变换后rect的轴对齐包围盒 (Arvo)
+func (t *Mat3) TransformRect2(r *vector2.Rect) vector2.Rect {
+	res := vector2.Rect{
+		Min: vector2.Vector{t[2][0], t[2][1]},
+		Max: vector2.Vector{t[2][0], t[2][1]},
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			a := t[j][i] * r.Min[j]
+			b := t[j][i] * r.Max[j]
+			if a < b {
+				res.Min[i] += a
+				res.Max[i] += b
+			} else {
+				res.Min[i] += b
+				res.Max[i] += a
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,153 @@
+package mat3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector2"
+)
+
+const kHalfPi = float32(math.Pi / 2)
+
+func TestAffine2Builders(t *testing.T) {
+	var tr, sc, rot, sk, trs Mat3
+	tr.AssignTranslation2(&vector2.Vector{3, -2})
+	sc.AssignScaling2(&vector2.Vector{2, -0.5})
+	rot.AssignRotationAround2(&vector2.Vector{1, 1}, kHalfPi)
+	sk.AssignSkew2(float32(math.Atan(0.5)), 0)
+	trs.AssignTRS2(&vector2.Vector{3, -2}, kHalfPi, &vector2.Vector{2, 1})
+	cases := []struct {
+		name     string
+		m        *Mat3
+		p, point vector2.Vector
+		dir      vector2.Vector
+	}{
+		{"translation", &tr, vector2.Vector{1, 1}, vector2.Vector{4, -1}, vector2.Vector{1, 1}},
+		{"scaling", &sc, vector2.Vector{1, 4}, vector2.Vector{2, -2}, vector2.Vector{2, -2}},
+		// 绕(1,1)转90度, pivot不动
+		{"rotation pivot", &rot, vector2.Vector{1, 1}, vector2.Vector{1, 1}, vector2.Vector{-1, 1}},
+		{"rotation", &rot, vector2.Vector{2, 1}, vector2.Vector{1, 2}, vector2.Vector{-1, 2}},
+		{"skew", &sk, vector2.Vector{0, 2}, vector2.Vector{1, 2}, vector2.Vector{1, 2}},
+		// 先缩放(2,1), 再转90度, 再平移
+		{"trs", &trs, vector2.Vector{1, 0}, vector2.Vector{3, 0}, vector2.Vector{0, 2}},
+	}
+	for _, c := range cases {
+		if got := c.m.TransformPoint2(&c.p); !got.ApproxEqual(&c.point, 1e-5) {
+			t.Errorf("%s: TransformPoint2(%v) = %v, want %v", c.name, c.p, got, c.point)
+		}
+		if got := c.m.TransformDir2(&c.p); !got.ApproxEqual(&c.dir, 1e-5) {
+			t.Errorf("%s: TransformDir2(%v) = %v, want %v", c.name, c.p, got, c.dir)
+		}
+	}
+}
+
+func TestDecompose2(t *testing.T) {
+	cases := []struct {
+		trans vector2.Vector
+		angle float32
+		scale vector2.Vector
+		exact bool // 能否还原出原参数 (x缩放为负时只能还原矩阵)
+	}{
+		{vector2.Vector{0, 0}, 0, vector2.Vector{1, 1}, true},
+		{vector2.Vector{3, -2}, 0.7, vector2.Vector{2, 3}, true},
+		{vector2.Vector{3, -2}, -2.5, vector2.Vector{2, -0.5}, true},
+		{vector2.Vector{1, 1}, 0.3, vector2.Vector{-2, 1}, false},
+	}
+	for _, c := range cases {
+		var m, back Mat3
+		m.AssignTRS2(&c.trans, c.angle, &c.scale)
+		trans, angle, scale := m.Decompose2()
+		if c.exact && (!trans.ApproxEqual(&c.trans, 1e-5) ||
+			!sutil.AlmostEqual(angle, c.angle, 1e-5, 1e-5) || !scale.ApproxEqual(&c.scale, 1e-5)) {
+			t.Errorf("Decompose2(TRS(%v, %v, %v)) = %v %v %v", c.trans, c.angle, c.scale, trans, angle, scale)
+		}
+		if back.AssignTRS2(&trans, angle, &scale); !back.ApproxEqual(&m, 1e-5) {
+			t.Errorf("TRS(%v, %v, %v): recomposed %v, want %v", c.trans, c.angle, c.scale, back, m)
+		}
+		if got := m.Det2(); !sutil.AlmostEqual(got, c.scale[0]*c.scale[1], 1e-5, 1e-5) {
+			t.Errorf("TRS(%v, %v, %v): Det2 = %v", c.trans, c.angle, c.scale, got)
+		}
+	}
+
+	// 第0列退化时从第1列取旋转
+	var m Mat3
+	m.AssignTRS2(&vector2.Vector{1, 2}, 0.5, &vector2.Vector{0, 3})
+	if _, angle, scale := m.Decompose2(); !sutil.AlmostEqual(angle, 0.5, 1e-5, 1e-5) || !scale.ApproxEqual(&vector2.Vector{0, 3}, 1e-5) {
+		t.Errorf("degenerate Decompose2 = %v %v", angle, scale)
+	}
+}
+
+func TestInverseAffine2(t *testing.T) {
+	var skew Mat3
+	skew.AssignSkew2(0.4, -0.2)
+	skew.Translate(&vector2.Vector{5, 7})
+	cases := []Mat3{Ident, skew}
+	var trs Mat3
+	cases = append(cases, *trs.AssignTRS2(&vector2.Vector{3, -2}, 0.7, &vector2.Vector{2, -0.5}))
+	for _, m := range cases {
+		inv, ok := m.InverseAffine2()
+		if !ok {
+			t.Errorf("%v: InverseAffine2 not ok", m)
+			continue
+		}
+		var id Mat3
+		if id.AssignAffineMul2(&m, &inv); !id.ApproxEqual(&Ident, 1e-5) {
+			t.Errorf("%v * inverse = %v", m, id)
+		}
+		p := vector2.Vector{1.5, 4}
+		q := m.TransformPoint2(&p)
+		if got := inv.TransformPoint2(&q); !got.ApproxEqual(&p, 1e-5) {
+			t.Errorf("%v: inverse maps %v back to %v", m, q, got)
+		}
+	}
+
+	var flat Mat3
+	flat.AssignScaling2(&vector2.Vector{2, 0})
+	if inv, ok := flat.InverseAffine2(); ok || inv != Ident {
+		t.Errorf("singular InverseAffine2 = %v %v", inv, ok)
+	}
+}
+
+func TestAffineMul2(t *testing.T) {
+	var a, b, got, want Mat3
+	a.AssignTRS2(&vector2.Vector{3, -2}, 0.7, &vector2.Vector{2, -0.5})
+	b.AssignSkew2(0.4, 0.1)
+	b.Translate(&vector2.Vector{-1, 6})
+	got.AssignAffineMul2(&a, &b)
+	want.AssignMul(&a, &b)
+	if !got.ApproxEqual(&want, 1e-5) {
+		t.Errorf("AssignAffineMul2 = %v, want %v", got, want)
+	}
+
+	// RotateAround2在当前变换之后旋转
+	pivot := vector2.Vector{1, 1}
+	var r Mat3
+	r.AssignRotationAround2(&pivot, 0.3)
+	got = a
+	got.RotateAround2(&pivot, 0.3)
+	want.AssignMul(&r, &a)
+	if !got.ApproxEqual(&want, 1e-5) {
+		t.Errorf("RotateAround2 = %v, want %v", got, want)
+	}
+}
+
+func TestTransformRect2(t *testing.T) {
+	var trs, skew Mat3
+	trs.AssignTRS2(&vector2.Vector{3, -2}, 0.7, &vector2.Vector{2, -0.5})
+	skew.AssignSkew2(0.4, -0.2)
+	rect := vector2.Rect{Min: vector2.Vector{-1, 0}, Max: vector2.Vector{2, 3}}
+	for _, m := range []Mat3{Ident, trs, skew} {
+		// 与四个角变换后的包围盒一致
+		want := vector2.Rect{Min: vector2.MaxVal, Max: vector2.MinVal}
+		for _, c := range []vector2.Vector{rect.Min, {rect.Max[0], rect.Min[1]}, {rect.Min[0], rect.Max[1]}, rect.Max} {
+			w := m.TransformPoint2(&c)
+			want.Min = vector2.Min(&want.Min, &w)
+			want.Max = vector2.Max(&want.Max, &w)
+		}
+		got := m.TransformRect2(&rect)
+		if !got.Min.ApproxEqual(&want.Min, 1e-5) || !got.Max.ApproxEqual(&want.Max, 1e-5) {
+			t.Errorf("%v: TransformRect2 = %v, want %v", m, got, want)
+		}
+	}
+}
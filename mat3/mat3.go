@@ -15,8 +15,8 @@ import (
 	"github.com/tinysss/smath/generic"
 	"github.com/tinysss/smath/mat2"
 	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector2"
 	"github.com/tinysss/smath/vector3"
-	"github.com/ungerik/go3d/vec2"
 )
 
 // 列存储 每个vec代表一列
@@ -91,6 +91,16 @@ func (t *Mat3) IsZero() bool {
 
 //-------------------------------------------- 实现generic.T end -------------------------------------
 
+// 各元素在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual)
+func (t *Mat3) ApproxEqual(o *Mat3, tol float32) bool {
+	for i := range t {
+		if !t[i].ApproxEqual(&o[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
 func (t *Mat3) Scale(f float32) *Mat3 {
 	t[0][0] *= f
 	t[1][1] *= f
@@ -114,19 +124,19 @@ func (t *Mat3) SetScaling(s *vector3.Vector) *Mat3 {
 	return t
 }
 
-func (t *Mat3) ScaleVec2(s *vec2.T) *Mat3 {
+func (t *Mat3) ScaleVec2(s *vector2.Vector) *Mat3 {
 	t[0][0] *= s[0]
 	t[1][1] *= s[1]
 	return t
 }
 
-func (t *Mat3) SetTranslation(s *vec2.T) *Mat3 {
+func (t *Mat3) SetTranslation(s *vector2.Vector) *Mat3 {
 	t[2][0] = s[0]
 	t[2][1] = s[1]
 	return t
 }
 
-func (t *Mat3) Translate(s *vec2.T) *Mat3 {
+func (t *Mat3) Translate(s *vector2.Vector) *Mat3 {
 	t[2][0] += s[0]
 	t[2][1] += s[1]
 	return t
@@ -255,6 +265,19 @@ func (t *Mat3) AssignZRotation(angle float32) *Mat3 {
 	return t
 }
 
+// 带单位的版本, 如 AssignXRotationOf(sutil.Degrees(90))
+func (t *Mat3) AssignXRotationOf(angle sutil.Angle) *Mat3 {
+	return t.AssignXRotation(float32(angle.Rad()))
+}
+
+func (t *Mat3) AssignYRotationOf(angle sutil.Angle) *Mat3 {
+	return t.AssignYRotation(float32(angle.Rad()))
+}
+
+func (t *Mat3) AssignZRotationOf(angle sutil.Angle) *Mat3 {
+	return t.AssignZRotation(float32(angle.Rad()))
+}
+
 // 通过euler构建mat3
 func (t *Mat3) AssignEulerRotation(yHead, xPitch, zBank float32) *Mat3 {
 	xPitch, yHead, zBank = sutil.CanonizeEuler(xPitch, yHead, zBank)
@@ -278,6 +301,10 @@ func (t *Mat3) AssignEulerRotation(yHead, xPitch, zBank float32) *Mat3 {
 	return t
 }
 
+func (t *Mat3) AssignEulerRotationOf(yHead, xPitch, zBank sutil.Angle) *Mat3 {
+	return t.AssignEulerRotation(float32(yHead.Rad()), float32(xPitch.Rad()), float32(zBank.Rad()))
+}
+
 // 提取euler
 func (t *Mat3) ExtractEulerAngles() (yHead, xPitch, zBank float32) {
 	sp := -t[2][1]
@@ -301,6 +328,12 @@ func (t *Mat3) ExtractEulerAngles() (yHead, xPitch, zBank float32) {
 	return
 }
 
+// 提取euler, 返回弧度类型
+func (t *Mat3) ExtractEulerRadians() (yHead, xPitch, zBank sutil.Radians) {
+	h, p, b := t.ExtractEulerAngles()
+	return sutil.Radians(h), sutil.Radians(p), sutil.Radians(b)
+}
+
 func (t *Mat3) AssignCoordinateSystem(x, y, z *vector3.Vector) *Mat3 {
 	t[0] = *x
 	t[1] = *y
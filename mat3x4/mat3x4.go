@@ -0,0 +1,172 @@
+/*
+ * 3行4列矩阵  3D仿射变换 [R|t], 省略的第四行为(0,0,0,1)
+ *   列存储 每个vec代表一列, 第4列为平移; 48字节紧凑存储
+ *   std140/std430中vec3列会补齐到16字节, 上传GPU时用Transposed()得到mat4x3.Mat4x3
+ */
+package mat3x4
+
+import (
+	"unsafe"
+
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/mat4x3"
+	"github.com/tinysss/smath/vector3"
+)
+
+type Mat3x4 [4]vector3.Vector
+
+var (
+	Zero  = Mat3x4{}
+	Ident = Mat3x4{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+		{0, 0, 0},
+	}
+)
+
+func New(v1, v2, v3, v4 vector3.Vector) *Mat3x4 {
+	return &Mat3x4{v1, v2, v3, v4}
+}
+
+// 线性部分m, 平移trans
+func FromMat3(m *mat3.Mat3, trans *vector3.Vector) Mat3x4 {
+	return Mat3x4{m[0], m[1], m[2], *trans}
+}
+
+// 取mat4的前三行, mat4需为仿射矩阵(第四行为0,0,0,1)
+func FromMat4(m *mat4.Mat4) Mat3x4 {
+	return Mat3x4{
+		{m[0][0], m[0][1], m[0][2]},
+		{m[1][0], m[1][1], m[1][2]},
+		{m[2][0], m[2][1], m[2][2]},
+		{m[3][0], m[3][1], m[3][2]},
+	}
+}
+
+func FromMat4x3(m *mat4x3.Mat4x3) Mat3x4 {
+	return Mat3x4{
+		{m[0][0], m[1][0], m[2][0]},
+		{m[0][1], m[1][1], m[2][1]},
+		{m[0][2], m[1][2], m[2][2]},
+		{m[0][3], m[1][3], m[2][3]},
+	}
+}
+
+func (t *Mat3x4) Array() *[12]float32 {
+	return (*[12]float32)(unsafe.Pointer(t))
+}
+
+//-------------------------------------------- 实现generic.T begin-------------------------------------
+func (t *Mat3x4) Cols() int {
+	return 4
+}
+
+func (t *Mat3x4) Rows() int {
+	return 3
+}
+
+func (t *Mat3x4) Size() int {
+	return 12
+}
+
+func (t *Mat3x4) Slice() []float32 {
+	return t.Array()[:]
+}
+
+func (t *Mat3x4) Get(col, row int) float32 {
+	return t[col][row]
+}
+
+func (t *Mat3x4) IsZero() bool {
+	return *t == Zero
+}
+
+//-------------------------------------------- 实现generic.T end -------------------------------------
+
+// 各元素在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual)
+func (t *Mat3x4) ApproxEqual(o *Mat3x4, tol float32) bool {
+	for i := range t {
+		if !t[i].ApproxEqual(&o[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// 补上第四行(0,0,0,1)
+func (t *Mat3x4) ToMat4() mat4.Mat4 {
+	return mat4.Mat4{
+		{t[0][0], t[0][1], t[0][2], 0},
+		{t[1][0], t[1][1], t[1][2], 0},
+		{t[2][0], t[2][1], t[2][2], 0},
+		{t[3][0], t[3][1], t[3][2], 1},
+	}
+}
+
+// 转置, GPU布局
+func (t *Mat3x4) Transposed() mat4x3.Mat4x3 {
+	return mat4x3.Mat4x3{
+		{t[0][0], t[1][0], t[2][0], t[3][0]},
+		{t[0][1], t[1][1], t[2][1], t[3][1]},
+		{t[0][2], t[1][2], t[2][2], t[3][2]},
+	}
+}
+
+// 线性部分
+func (t *Mat3x4) Mat3() mat3.Mat3 {
+	return mat3.Mat3{t[0], t[1], t[2]}
+}
+
+func (t *Mat3x4) Translation() vector3.Vector {
+	return t[3]
+}
+
+func (t *Mat3x4) SetTranslation(v *vector3.Vector) *Mat3x4 {
+	t[3] = *v
+	return t
+}
+
+// 变换点 R*p + t
+func (t *Mat3x4) TransformPoint(p *vector3.Vector) vector3.Vector {
+	return vector3.Vector{
+		t[0][0]*p[0] + t[1][0]*p[1] + t[2][0]*p[2] + t[3][0],
+		t[0][1]*p[0] + t[1][1]*p[1] + t[2][1]*p[2] + t[3][1],
+		t[0][2]*p[0] + t[1][2]*p[1] + t[2][2]*p[2] + t[3][2],
+	}
+}
+
+// 变换方向 R*v, 不含平移
+func (t *Mat3x4) TransformDir(v *vector3.Vector) vector3.Vector {
+	return vector3.Vector{
+		t[0][0]*v[0] + t[1][0]*v[1] + t[2][0]*v[2],
+		t[0][1]*v[0] + t[1][1]*v[1] + t[2][1]*v[2],
+		t[0][2]*v[0] + t[1][2]*v[1] + t[2][2]*v[2],
+	}
+}
+
+// t = a * b, 先应用b再应用a
+func (t *Mat3x4) AssignMul(a, b *Mat3x4) *Mat3x4 {
+	*t = Mul(a, b)
+	return t
+}
+
+// 仿射逆 [R^-1 | -R^-1 t], 线性部分奇异时ok为false
+func (t *Mat3x4) InverseAffine() (inv Mat3x4, ok bool) {
+	tr := t.Transposed()
+	trinv, ok := tr.InverseAffine()
+	if !ok {
+		return Ident, false
+	}
+	return FromMat4x3(&trinv), true
+}
+
+func Mul(a, b *Mat3x4) Mat3x4 {
+	return Mat3x4{
+		a.TransformDir(&b[0]),
+		a.TransformDir(&b[1]),
+		a.TransformDir(&b[2]),
+		a.TransformPoint(&b[3]),
+	}
+}
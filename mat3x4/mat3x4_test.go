@@ -0,0 +1,115 @@
+package mat3x4
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/mat4x3"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 旋转, 非均匀缩放, 平移组成的仿射mat4
+func affine(rx, rz float32, scale, trans vector3.Vector) mat4.Mat4 {
+	var m, mx, mz mat4.Mat4
+	mx.AssignXRotation(rx)
+	mz.AssignZRotation(rz)
+	m.AssignMul(&mx, &mz)
+	m.ScaleVec3(&scale)
+	m.SetTranslation(&trans)
+	return m
+}
+
+func TestConvert(t *testing.T) {
+	m4 := affine(0.7, -1.1, vector3.Vector{2, 0.5, 3}, vector3.Vector{1, 2, 3})
+	a := FromMat4(&m4)
+	if back := a.ToMat4(); back != m4 {
+		t.Errorf("ToMat4(FromMat4(m)) = %v, want %v", back, m4)
+	}
+	r := a.Mat3()
+	tr := a.Translation()
+	if b := FromMat3(&r, &tr); b != a {
+		t.Errorf("FromMat3(Mat3, Translation) = %v, want %v", b, a)
+	}
+	gpu := a.Transposed()
+	if b := FromMat4x3(&gpu); b != a {
+		t.Errorf("FromMat4x3(Transposed) = %v, want %v", b, a)
+	}
+	if m := mat4x3.FromMat4(&m4); m != gpu {
+		t.Errorf("Transposed = %v, mat4x3.FromMat4 = %v", gpu, m)
+	}
+
+	var s Mat3x4
+	s.SetTranslation(&vector3.Vector{4, 5, 6})
+	if s[3] != (vector3.Vector{4, 5, 6}) {
+		t.Errorf("SetTranslation = %v", s)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	m4 := affine(0.7, -1.1, vector3.Vector{2, 0.5, 3}, vector3.Vector{1, 2, 3})
+	a := FromMat4(&m4)
+	gpu := a.Transposed()
+	for _, p := range []vector3.Vector{{0, 0, 0}, {0.3, -4, 2}, {1, 1, 1}} {
+		want := m4.MulVec3(&p)
+		if got := a.TransformPoint(&p); !got.ApproxEqual(&want, 1e-5) {
+			t.Errorf("TransformPoint(%v) = %v, want %v", p, got, want)
+		}
+		if got := gpu.TransformPoint(&p); !got.ApproxEqual(&want, 1e-5) {
+			t.Errorf("mat4x3 TransformPoint(%v) = %v, want %v", p, got, want)
+		}
+		wantDir := m4.MulVec3W(&p, 0)
+		if got := a.TransformDir(&p); !got.ApproxEqual(&wantDir, 1e-5) {
+			t.Errorf("TransformDir(%v) = %v, want %v", p, got, wantDir)
+		}
+	}
+}
+
+func TestMulInverse(t *testing.T) {
+	m4 := affine(0.7, -1.1, vector3.Vector{2, 0.5, 3}, vector3.Vector{1, 2, 3})
+	n4 := affine(-0.3, 2, vector3.Vector{1, 1, 1}, vector3.Vector{-5, 0, 0.5})
+	a := FromMat4(&m4)
+	b := FromMat4(&n4)
+
+	var ab4 mat4.Mat4
+	ab4.AssignMul(&m4, &n4)
+	var ab Mat3x4
+	ab.AssignMul(&a, &b)
+	if got := ab.ToMat4(); !got.ApproxEqual(&ab4, 1e-5) {
+		t.Errorf("Mul = %v, want %v", got, ab4)
+	}
+
+	inv, ok := a.InverseAffine()
+	if !ok {
+		t.Fatalf("InverseAffine failed")
+	}
+	if id := Mul(&a, &inv); !id.ApproxEqual(&Ident, 1e-5) {
+		t.Errorf("a * inv = %v", id)
+	}
+	if id := Mul(&inv, &a); !id.ApproxEqual(&Ident, 1e-5) {
+		t.Errorf("inv * a = %v", id)
+	}
+
+	sing := FromMat3(&mat3.Mat3{{1, 0, 0}, {2, 0, 0}, {0, 0, 1}}, &vector3.Vector{1, 2, 3})
+	if _, ok := sing.InverseAffine(); ok {
+		t.Errorf("InverseAffine of singular matrix")
+	}
+}
+
+func TestLayout(t *testing.T) {
+	var a Mat3x4
+	if s := unsafe.Sizeof(a); s != 48 {
+		t.Errorf("sizeof Mat3x4 = %d, want 48", s)
+	}
+	a = Mat3x4{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {10, 11, 12}}
+	arr := a.Array()
+	for i := range arr {
+		if arr[i] != float32(i+1) {
+			t.Fatalf("Array() = %v, want column major", *arr)
+		}
+	}
+	if a.Get(3, 1) != 11 || a.Cols() != 4 || a.Rows() != 3 || a.Size() != 12 {
+		t.Errorf("generic.T accessors")
+	}
+}
@@ -108,6 +108,16 @@ func (t Mat4) String() string {
 
 //-------------------------------------------- 实现generic.T end -------------------------------------
 
+// 各元素在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual)
+func (t *Mat4) ApproxEqual(o *Mat4, tol float32) bool {
+	for i := range t {
+		if !t[i].ApproxEqual(&o[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
 func (t *Mat4) Scale(f float32) *Mat4 {
 	t[0][0] *= f
 	t[1][1] *= f
@@ -159,8 +169,8 @@ func (t *Mat4) Trace3() float32 {
 
 func (t *Mat4) AssignMat2x2(m *mat2.Mat2) *Mat4 {
 	*t = Mat4{
-		vector4.Vector{t[0][0], t[0][1], 0, 0},
-		vector4.Vector{t[1][0], t[1][1], 0, 0},
+		vector4.Vector{m[0][0], m[0][1], 0, 0},
+		vector4.Vector{m[1][0], m[1][1], 0, 0},
 		vector4.Vector{0, 0, 1, 0},
 		vector4.Vector{0, 0, 0, 1},
 	}
@@ -169,9 +179,9 @@ func (t *Mat4) AssignMat2x2(m *mat2.Mat2) *Mat4 {
 
 func (t *Mat4) AssignMat3x3(m *mat3.Mat3) *Mat4 {
 	*t = Mat4{
-		vector4.Vector{t[0][0], t[0][1], t[0][2], 0},
-		vector4.Vector{t[1][0], t[1][1], t[1][2], 0},
-		vector4.Vector{t[2][0], t[2][1], t[2][2], 0},
+		vector4.Vector{m[0][0], m[0][1], m[0][2], 0},
+		vector4.Vector{m[1][0], m[1][1], m[1][2], 0},
+		vector4.Vector{m[2][0], m[2][1], m[2][2], 0},
 		vector4.Vector{0, 0, 0, 1},
 	}
 	return t
@@ -365,6 +375,19 @@ func (t *Mat4) AssignZRotation(angle float32) *Mat4 {
 	return t
 }
 
+// 带单位的版本, 如 AssignXRotationOf(sutil.Degrees(90))
+func (t *Mat4) AssignXRotationOf(angle sutil.Angle) *Mat4 {
+	return t.AssignXRotation(float32(angle.Rad()))
+}
+
+func (t *Mat4) AssignYRotationOf(angle sutil.Angle) *Mat4 {
+	return t.AssignYRotation(float32(angle.Rad()))
+}
+
+func (t *Mat4) AssignZRotationOf(angle sutil.Angle) *Mat4 {
+	return t.AssignZRotation(float32(angle.Rad()))
+}
+
 func (t *Mat4) AssignCoordinateSystem(x, y, z *vector3.Vector) *Mat4 {
 	t[0][0] = x[0]
 	t[0][1] = x[1]
@@ -420,6 +443,10 @@ func (t *Mat4) AssignEulerRotation(yHead, xPitch, zBank float32) *Mat4 {
 	return t
 }
 
+func (t *Mat4) AssignEulerRotationOf(yHead, xPitch, zBank sutil.Angle) *Mat4 {
+	return t.AssignEulerRotation(float32(yHead.Rad()), float32(xPitch.Rad()), float32(zBank.Rad()))
+}
+
 // 提取euler
 func (t *Mat4) ExtractEulerAngles() (yHead, xPitch, zBank float32) {
 	sp := -t[2][1]
@@ -443,6 +470,12 @@ func (t *Mat4) ExtractEulerAngles() (yHead, xPitch, zBank float32) {
 	return
 }
 
+// 提取euler, 返回弧度类型
+func (t *Mat4) ExtractEulerRadians() (yHead, xPitch, zBank sutil.Radians) {
+	h, p, b := t.ExtractEulerAngles()
+	return sutil.Radians(h), sutil.Radians(p), sutil.Radians(b)
+}
+
 //
 func (t *Mat4) Det3x3() float32 {
 	return t[0][0]*t[1][1]*t[2][2] +
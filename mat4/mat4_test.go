@@ -0,0 +1,100 @@
+package mat4
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/mat2"
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/sutil"
+)
+
+func TestApproxEqual(t *testing.T) {
+	almost := Ident
+	almost[3][0] = 1e-7
+	far := Ident
+	far[2][2] = 1.01
+	big := Ident.Scaled(1e6)
+	bigNear := big
+	bigNear[1][1] += 0.5
+	tests := []struct {
+		a, b Mat4
+		tol  float32
+		want bool
+	}{
+		{Ident, Ident, 0, true},
+		{Ident, almost, 1e-6, true},
+		{Ident, far, 1e-3, false},
+		// 大数按相对误差
+		{big, bigNear, 1e-6, true},
+		{Ident, Zero, 1e-3, false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.ApproxEqual(&tt.b, tt.tol); got != tt.want {
+			t.Errorf("%v.ApproxEqual(%v, %v) = %v, want %v", tt.a, tt.b, tt.tol, got, tt.want)
+		}
+	}
+}
+
+// 带单位的版本与弧度版本一致
+func TestRotationOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want func(m *Mat4) *Mat4
+	}{
+		{"x", func(m *Mat4) *Mat4 { return m.AssignXRotationOf(sutil.Degrees(90)) },
+			func(m *Mat4) *Mat4 { return m.AssignXRotation(sutil.KPiOver2) }},
+		{"y", func(m *Mat4) *Mat4 { return m.AssignYRotationOf(sutil.Degrees(-30)) },
+			func(m *Mat4) *Mat4 { return m.AssignYRotation(-sutil.KPi / 6) }},
+		{"z", func(m *Mat4) *Mat4 { return m.AssignZRotationOf(sutil.Radians(1.2)) },
+			func(m *Mat4) *Mat4 { return m.AssignZRotation(1.2) }},
+		{"euler", func(m *Mat4) *Mat4 {
+			return m.AssignEulerRotationOf(sutil.Degrees(30), sutil.Radians(0.4), sutil.Degrees(-45))
+		}, func(m *Mat4) *Mat4 { return m.AssignEulerRotation(sutil.KPi/6, 0.4, -sutil.KPi/4) }},
+	}
+	for _, tt := range tests {
+		var got, want Mat4
+		tt.got(&got)
+		tt.want(&want)
+		if !got.ApproxEqual(&want, 1e-6) {
+			t.Errorf("%s: %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestExtractEulerRadians(t *testing.T) {
+	var m Mat4
+	m.AssignEulerRotation(0.5, -0.3, 1.1)
+	h, p, b := m.ExtractEulerRadians()
+	wantH, wantP, wantB := m.ExtractEulerAngles()
+	if float32(h) != wantH || float32(p) != wantP || float32(b) != wantB {
+		t.Errorf("ExtractEulerRadians = %v %v %v, want %v %v %v", h, p, b, wantH, wantP, wantB)
+	}
+	if !sutil.AlmostEqual(float32(h), 0.5, 1e-5, 0) || !sutil.AlmostEqual(float32(p), -0.3, 1e-5, 0) ||
+		!sutil.AlmostEqual(float32(b), 1.1, 1e-5, 0) {
+		t.Errorf("ExtractEulerRadians = %v %v %v, want 0.5 -0.3 1.1", h, p, b)
+	}
+}
+
+// 结果取自参数, 与t原来的值无关
+func TestAssignMat(t *testing.T) {
+	m2 := mat2.Mat2{{1, 2}, {3, 4}}
+	m3 := mat3.Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+	tests := []struct {
+		name   string
+		assign func(m *Mat4) *Mat4
+		want   Mat4
+	}{
+		{"2x2", func(m *Mat4) *Mat4 { return m.AssignMat2x2(&m2) },
+			Mat4{{1, 2, 0, 0}, {3, 4, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}},
+		{"3x3", func(m *Mat4) *Mat4 { return m.AssignMat3x3(&m3) },
+			Mat4{{1, 2, 3, 0}, {4, 5, 6, 0}, {7, 8, 9, 0}, {0, 0, 0, 1}}},
+	}
+	for _, tt := range tests {
+		for _, init := range []Mat4{Zero, Ident, Ident.Scaled(-7)} {
+			m := init
+			if got := tt.assign(&m); *got != tt.want || got != &m {
+				t.Errorf("%s from %v: %v, want %v", tt.name, init, *got, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+/*
+ * 4行3列矩阵  即mat3x4.Mat3x4的转置, 每个vec存仿射变换[R|t]的一行
+ *   48字节, 列步长16, 与std140/std430下的GLSL mat3x4一致, 着色器中用 vec4(p,1) * m 变换
+ *   常用于蒙皮矩阵调色板
+ */
+package mat4x3
+
+import (
+	"unsafe"
+
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+	"github.com/tinysss/smath/vector4"
+)
+
+// 列存储 第i列为仿射变换的第i行
+type Mat4x3 [3]vector4.Vector
+
+var (
+	Zero  = Mat4x3{}
+	Ident = Mat4x3{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+	}
+)
+
+func New(v1, v2, v3 vector4.Vector) *Mat4x3 {
+	return &Mat4x3{v1, v2, v3}
+}
+
+// 取mat4的前三行, mat4需为仿射矩阵(第四行为0,0,0,1)
+func FromMat4(m *mat4.Mat4) Mat4x3 {
+	return Mat4x3{
+		{m[0][0], m[1][0], m[2][0], m[3][0]},
+		{m[0][1], m[1][1], m[2][1], m[3][1]},
+		{m[0][2], m[1][2], m[2][2], m[3][2]},
+	}
+}
+
+// 线性部分m, 平移trans
+func FromMat3(m *mat3.Mat3, trans *vector3.Vector) Mat4x3 {
+	return Mat4x3{
+		{m[0][0], m[1][0], m[2][0], trans[0]},
+		{m[0][1], m[1][1], m[2][1], trans[1]},
+		{m[0][2], m[1][2], m[2][2], trans[2]},
+	}
+}
+
+func (t *Mat4x3) Array() *[12]float32 {
+	return (*[12]float32)(unsafe.Pointer(t))
+}
+
+//-------------------------------------------- 实现generic.T begin-------------------------------------
+func (t *Mat4x3) Cols() int {
+	return 3
+}
+
+func (t *Mat4x3) Rows() int {
+	return 4
+}
+
+func (t *Mat4x3) Size() int {
+	return 12
+}
+
+func (t *Mat4x3) Slice() []float32 {
+	return t.Array()[:]
+}
+
+func (t *Mat4x3) Get(col, row int) float32 {
+	return t[col][row]
+}
+
+func (t *Mat4x3) IsZero() bool {
+	return *t == Zero
+}
+
+//-------------------------------------------- 实现generic.T end -------------------------------------
+
+// 各元素在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual)
+func (t *Mat4x3) ApproxEqual(o *Mat4x3, tol float32) bool {
+	for i := range t {
+		if !t[i].ApproxEqual(&o[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// 补上第四行(0,0,0,1)
+func (t *Mat4x3) ToMat4() mat4.Mat4 {
+	return mat4.Mat4{
+		{t[0][0], t[1][0], t[2][0], 0},
+		{t[0][1], t[1][1], t[2][1], 0},
+		{t[0][2], t[1][2], t[2][2], 0},
+		{t[0][3], t[1][3], t[2][3], 1},
+	}
+}
+
+// 线性部分
+func (t *Mat4x3) Mat3() mat3.Mat3 {
+	return mat3.Mat3{
+		{t[0][0], t[1][0], t[2][0]},
+		{t[0][1], t[1][1], t[2][1]},
+		{t[0][2], t[1][2], t[2][2]},
+	}
+}
+
+func (t *Mat4x3) Translation() vector3.Vector {
+	return vector3.Vector{t[0][3], t[1][3], t[2][3]}
+}
+
+func (t *Mat4x3) SetTranslation(v *vector3.Vector) *Mat4x3 {
+	t[0][3] = v[0]
+	t[1][3] = v[1]
+	t[2][3] = v[2]
+	return t
+}
+
+// 变换点 R*p + t
+func (t *Mat4x3) TransformPoint(p *vector3.Vector) vector3.Vector {
+	return vector3.Vector{
+		t[0][0]*p[0] + t[0][1]*p[1] + t[0][2]*p[2] + t[0][3],
+		t[1][0]*p[0] + t[1][1]*p[1] + t[1][2]*p[2] + t[1][3],
+		t[2][0]*p[0] + t[2][1]*p[1] + t[2][2]*p[2] + t[2][3],
+	}
+}
+
+// 变换方向 R*v, 不含平移
+func (t *Mat4x3) TransformDir(v *vector3.Vector) vector3.Vector {
+	return vector3.Vector{
+		t[0][0]*v[0] + t[0][1]*v[1] + t[0][2]*v[2],
+		t[1][0]*v[0] + t[1][1]*v[1] + t[1][2]*v[2],
+		t[2][0]*v[0] + t[2][1]*v[1] + t[2][2]*v[2],
+	}
+}
+
+// t = a * b, 先应用b再应用a
+func (t *Mat4x3) AssignMul(a, b *Mat4x3) *Mat4x3 {
+	*t = Mul(a, b)
+	return t
+}
+
+// 仿射逆 [R^-1 | -R^-1 t], 线性部分奇异时ok为false
+func (t *Mat4x3) InverseAffine() (inv Mat4x3, ok bool) {
+	r0 := vector3.Vector{t[0][0], t[0][1], t[0][2]}
+	r1 := vector3.Vector{t[1][0], t[1][1], t[1][2]}
+	r2 := vector3.Vector{t[2][0], t[2][1], t[2][2]}
+	// R^-1的列为行向量两两叉积/det
+	c0 := vector3.Cross(&r1, &r2)
+	c1 := vector3.Cross(&r2, &r0)
+	c2 := vector3.Cross(&r0, &r1)
+	det := vector3.Dot(&r0, &c0)
+	if sutil.FloatEqualThreshold(det, 0, sutil.MinNormal) {
+		return Ident, false
+	}
+	oo := 1 / det
+	for i := 0; i < 3; i++ {
+		inv[i] = vector4.Vector{c0[i] * oo, c1[i] * oo, c2[i] * oo, 0}
+	}
+	trans := t.Translation()
+	trans = inv.TransformDir(&trans)
+	return *inv.SetTranslation(trans.Scale(-1)), true
+}
+
+func Mul(a, b *Mat4x3) Mat4x3 {
+	var res Mat4x3
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 4; col++ {
+			res[row][col] = a[row][0]*b[0][col] + a[row][1]*b[1][col] + a[row][2]*b[2][col]
+		}
+		res[row][3] += a[row][3]
+	}
+	return res
+}
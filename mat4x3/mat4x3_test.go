@@ -0,0 +1,110 @@
+package mat4x3
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/vector3"
+)
+
+func affine(rx, rz float32, scale, trans vector3.Vector) mat4.Mat4 {
+	var m, mx, mz mat4.Mat4
+	mx.AssignXRotation(rx)
+	mz.AssignZRotation(rz)
+	m.AssignMul(&mx, &mz)
+	m.ScaleVec3(&scale)
+	m.SetTranslation(&trans)
+	return m
+}
+
+func TestConvert(t *testing.T) {
+	m4 := affine(0.7, -1.1, vector3.Vector{2, 0.5, 3}, vector3.Vector{1, 2, 3})
+	a := FromMat4(&m4)
+	if back := a.ToMat4(); back != m4 {
+		t.Errorf("ToMat4(FromMat4(m)) = %v, want %v", back, m4)
+	}
+	r := a.Mat3()
+	tr := a.Translation()
+	if tr != (vector3.Vector{1, 2, 3}) {
+		t.Errorf("Translation = %v", tr)
+	}
+	if b := FromMat3(&r, &tr); b != a {
+		t.Errorf("FromMat3(Mat3, Translation) = %v, want %v", b, a)
+	}
+	// 每个vec为一行
+	if a[1][3] != 2 || a[0][1] != m4[1][0] {
+		t.Errorf("row layout: %v", a)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	m4 := affine(0.7, -1.1, vector3.Vector{2, 0.5, 3}, vector3.Vector{1, 2, 3})
+	a := FromMat4(&m4)
+	for _, p := range []vector3.Vector{{0, 0, 0}, {0.3, -4, 2}, {1, 1, 1}} {
+		want := m4.MulVec3(&p)
+		if got := a.TransformPoint(&p); !got.ApproxEqual(&want, 1e-5) {
+			t.Errorf("TransformPoint(%v) = %v, want %v", p, got, want)
+		}
+		wantDir := m4.MulVec3W(&p, 0)
+		if got := a.TransformDir(&p); !got.ApproxEqual(&wantDir, 1e-5) {
+			t.Errorf("TransformDir(%v) = %v, want %v", p, got, wantDir)
+		}
+	}
+}
+
+func TestMulInverse(t *testing.T) {
+	m4 := affine(0.7, -1.1, vector3.Vector{2, 0.5, 3}, vector3.Vector{1, 2, 3})
+	n4 := affine(-0.3, 2, vector3.Vector{1, 1, 1}, vector3.Vector{-5, 0, 0.5})
+	a := FromMat4(&m4)
+	b := FromMat4(&n4)
+
+	var ab4 mat4.Mat4
+	ab4.AssignMul(&m4, &n4)
+	var ab Mat4x3
+	ab.AssignMul(&a, &b)
+	if got := ab.ToMat4(); !got.ApproxEqual(&ab4, 1e-5) {
+		t.Errorf("Mul = %v, want %v", got, ab4)
+	}
+
+	inv, ok := a.InverseAffine()
+	if !ok {
+		t.Fatalf("InverseAffine failed")
+	}
+	if id := Mul(&a, &inv); !id.ApproxEqual(&Ident, 1e-5) {
+		t.Errorf("a * inv = %v", id)
+	}
+	if id := Mul(&inv, &a); !id.ApproxEqual(&Ident, 1e-5) {
+		t.Errorf("inv * a = %v", id)
+	}
+
+	sing := FromMat3(&mat3.Mat3{{1, 0, 0}, {2, 0, 0}, {0, 0, 1}}, &vector3.Vector{1, 2, 3})
+	if _, ok := sing.InverseAffine(); ok {
+		t.Errorf("InverseAffine of singular matrix")
+	}
+}
+
+// std140/std430下GLSL mat3x4: 3列, 每列vec4, 列步长16
+func TestLayout(t *testing.T) {
+	var a Mat4x3
+	if s := unsafe.Sizeof(a); s != 48 {
+		t.Errorf("sizeof Mat4x3 = %d, want 48", s)
+	}
+	if off := unsafe.Offsetof(struct {
+		m Mat4x3
+		f float32
+	}{}.f); off != 48 {
+		t.Errorf("offset after Mat4x3 = %d, want 48", off)
+	}
+	a = Mat4x3{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}}
+	arr := a.Array()
+	for i := range arr {
+		if arr[i] != float32(i+1) {
+			t.Fatalf("Array() = %v", *arr)
+		}
+	}
+	if a.Get(2, 3) != 12 || a.Cols() != 3 || a.Rows() != 4 {
+		t.Errorf("generic.T accessors")
+	}
+}
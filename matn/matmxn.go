@@ -0,0 +1,157 @@
+/*
+ * 任意尺寸稠密矩阵  列存储, 实现generic.T
+ *   与定长类型互转: MatMxNFrom(&m4) / mat4.FromNew(m)
+ */
+package matn
+
+import (
+	"fmt"
+
+	"github.com/tinysss/smath/generic"
+)
+
+// rows行cols列, data按列存储
+type MatMxN struct {
+	rows, cols int
+	data       []float32
+}
+
+func NewMatMxN(rows, cols int) *MatMxN {
+	return &MatMxN{rows, cols, make([]float32, rows*cols)}
+}
+
+// n阶单位阵
+func NewIdent(n int) *MatMxN {
+	res := NewMatMxN(n, n)
+	for i := 0; i < n; i++ {
+		res.Set(i, i, 1)
+	}
+	return res
+}
+
+func MatMxNFrom(other generic.T) *MatMxN {
+	res := NewMatMxN(other.Rows(), other.Cols())
+	for col := 0; col < res.cols; col++ {
+		for row := 0; row < res.rows; row++ {
+			res.Set(col, row, other.Get(col, row))
+		}
+	}
+	return res
+}
+
+//-------------------------------------------- 实现generic.T begin-------------------------------------
+func (t *MatMxN) Cols() int {
+	return t.cols
+}
+
+func (t *MatMxN) Rows() int {
+	return t.rows
+}
+
+func (t *MatMxN) Size() int {
+	return t.rows * t.cols
+}
+
+func (t *MatMxN) Slice() []float32 {
+	return t.data
+}
+
+func (t *MatMxN) Get(col, row int) float32 {
+	return t.data[col*t.rows+row]
+}
+
+func (t *MatMxN) IsZero() bool {
+	return VecN(t.data).IsZero()
+}
+
+//-------------------------------------------- 实现generic.T end -------------------------------------
+
+func (t *MatMxN) Set(col, row int, v float32) {
+	t.data[col*t.rows+row] = v
+}
+
+// 第col列, 与矩阵共享内存
+func (t *MatMxN) Col(col int) VecN {
+	return t.data[col*t.rows : (col+1)*t.rows]
+}
+
+// 各元素在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual), 尺寸不同时不相等
+func (t *MatMxN) ApproxEqual(o *MatMxN, tol float32) bool {
+	return t.rows == o.rows && t.cols == o.cols && VecN(t.data).ApproxEqual(o.data, tol)
+}
+
+func (t *MatMxN) Clone() *MatMxN {
+	return &MatMxN{t.rows, t.cols, append([]float32(nil), t.data...)}
+}
+
+func (t *MatMxN) Scale(f float32) *MatMxN {
+	VecN(t.data).Scale(f)
+	return t
+}
+
+func (t *MatMxN) Add(o *MatMxN) *MatMxN {
+	checkDims(t, o)
+	VecN(t.data).Add(o.data)
+	return t
+}
+
+func (t *MatMxN) Sub(o *MatMxN) *MatMxN {
+	checkDims(t, o)
+	VecN(t.data).Sub(o.data)
+	return t
+}
+
+func (t *MatMxN) Transposed() *MatMxN {
+	res := NewMatMxN(t.cols, t.rows)
+	for col := 0; col < t.cols; col++ {
+		for row := 0; row < t.rows; row++ {
+			res.Set(row, col, t.Get(col, row))
+		}
+	}
+	return res
+}
+
+// t * v
+func (t *MatMxN) MulVec(v VecN) VecN {
+	if len(v) != t.cols {
+		panic(fmt.Sprintf("matn: %dx%d * vec%d", t.rows, t.cols, len(v)))
+	}
+	res := NewVecN(t.rows)
+	for col := 0; col < t.cols; col++ {
+		res.AddScaled(v[col], t.Col(col))
+	}
+	return res
+}
+
+// t^T * v, 不构造转置
+func (t *MatMxN) MulTransVec(v VecN) VecN {
+	if len(v) != t.rows {
+		panic(fmt.Sprintf("matn: (%dx%d)^T * vec%d", t.rows, t.cols, len(v)))
+	}
+	res := NewVecN(t.cols)
+	for col := 0; col < t.cols; col++ {
+		res[col] = t.Col(col).Dot(v)
+	}
+	return res
+}
+
+// a * b
+func Mul(a, b *MatMxN) *MatMxN {
+	if a.cols != b.rows {
+		panic(fmt.Sprintf("matn: %dx%d * %dx%d", a.rows, a.cols, b.rows, b.cols))
+	}
+	res := NewMatMxN(a.rows, b.cols)
+	for col := 0; col < b.cols; col++ {
+		rc := res.Col(col)
+		for k := 0; k < a.cols; k++ {
+			rc.AddScaled(b.Get(col, k), a.Col(k))
+		}
+	}
+	return res
+}
+
+func checkDims(a, b *MatMxN) {
+	if a.rows != b.rows || a.cols != b.cols {
+		panic(fmt.Sprintf("matn: dims mismatch %dx%d != %dx%d", a.rows, a.cols, b.rows, b.cols))
+	}
+}
@@ -0,0 +1,133 @@
+package matn
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 按行书写的矩阵, 便于阅读
+func fromRows(rows ...[]float32) *MatMxN {
+	m := NewMatMxN(len(rows), len(rows[0]))
+	for r, row := range rows {
+		for c, v := range row {
+			m.Set(c, r, v)
+		}
+	}
+	return m
+}
+
+func TestVecN(t *testing.T) {
+	a := VecN{1, 2, 2}
+	b := VecN{3, -1, 0.5}
+	cases := []struct {
+		name      string
+		got, want VecN
+	}{
+		{"Add", a.Clone().Add(b), VecN{4, 1, 2.5}},
+		{"Sub", a.Clone().Sub(b), VecN{-2, 3, 1.5}},
+		{"Scale", a.Clone().Scale(2), VecN{2, 4, 4}},
+		{"AddScaled", a.Clone().AddScaled(2, b), VecN{7, 0, 3}},
+		{"Normalize", a.Clone().Normalize(), VecN{1.0 / 3, 2.0 / 3, 2.0 / 3}},
+		{"Normalize zero", NewVecN(2).Normalize(), VecN{0, 0}},
+	}
+	for _, c := range cases {
+		if !c.got.ApproxEqual(c.want, 1e-6) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if d := a.Dot(b); d != 2 {
+		t.Errorf("Dot = %v, want 2", d)
+	}
+	if l := a.Length(); l != 3 {
+		t.Errorf("Length = %v, want 3", l)
+	}
+	if a.ApproxEqual(VecN{1, 2}, 1) {
+		t.Errorf("ApproxEqual with different length")
+	}
+}
+
+func TestMatMxN(t *testing.T) {
+	a := fromRows(
+		[]float32{1, 2, 3},
+		[]float32{4, 5, 6},
+	)
+	b := fromRows(
+		[]float32{1, 0},
+		[]float32{0, 2},
+		[]float32{-1, 1},
+	)
+	v := VecN{1, -1, 2}
+	u := VecN{2, -1}
+	cases := []struct {
+		name      string
+		got, want *MatMxN
+	}{
+		{"Transposed", a.Transposed(), fromRows([]float32{1, 4}, []float32{2, 5}, []float32{3, 6})},
+		{"Mul", Mul(a, b), fromRows([]float32{-2, 7}, []float32{-2, 16})},
+		{"Add", a.Clone().Add(a), fromRows([]float32{2, 4, 6}, []float32{8, 10, 12})},
+		{"Sub", a.Clone().Sub(a), NewMatMxN(2, 3)},
+		{"Scale", a.Clone().Scale(-1), fromRows([]float32{-1, -2, -3}, []float32{-4, -5, -6})},
+		{"NewIdent", NewIdent(2), fromRows([]float32{1, 0}, []float32{0, 1})},
+	}
+	for _, c := range cases {
+		if !c.got.ApproxEqual(c.want, 1e-6) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if got, want := a.MulVec(v), (VecN{5, 11}); !got.ApproxEqual(want, 1e-6) {
+		t.Errorf("MulVec = %v, want %v", got, want)
+	}
+	if got, want := a.MulTransVec(u), a.Transposed().MulVec(u); !got.ApproxEqual(want, 1e-6) {
+		t.Errorf("MulTransVec = %v, want %v", got, want)
+	}
+	if a.ApproxEqual(a.Transposed(), 1) {
+		t.Errorf("ApproxEqual with different dims")
+	}
+	if !NewMatMxN(2, 2).IsZero() || a.IsZero() {
+		t.Errorf("IsZero")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	m3 := mat3.Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 10}}
+	m := MatMxNFrom(&m3)
+	if m.Rows() != 3 || m.Cols() != 3 {
+		t.Fatalf("dims %dx%d", m.Rows(), m.Cols())
+	}
+	if back := mat3.FromNew(m); *back != m3 {
+		t.Errorf("mat3 round trip = %v, want %v", *back, m3)
+	}
+	v3 := vector3.Vector{1, 2, 3}
+	v := VecNFrom(&v3)
+	if back := vector3.FromNew(v); *back != v3 {
+		t.Errorf("vector3 round trip = %v, want %v", *back, v3)
+	}
+	want := m3.MulVec3(&v3)
+	if got := m.MulVec(v); !got.ApproxEqual(want[:], 1e-5) {
+		t.Errorf("MulVec = %v, mat3 = %v", got, want)
+	}
+}
+
+func TestDimsPanic(t *testing.T) {
+	cases := []struct {
+		name string
+		f    func()
+	}{
+		{"Add", func() { VecN{1}.Add(VecN{1, 2}) }},
+		{"Mul", func() { Mul(NewMatMxN(2, 3), NewMatMxN(2, 3)) }},
+		{"MulVec", func() { NewMatMxN(2, 3).MulVec(VecN{1, 2}) }},
+		{"MatAdd", func() { NewMatMxN(2, 3).Add(NewMatMxN(3, 2)) }},
+	}
+	for _, c := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", c.name)
+				}
+			}()
+			c.f()
+		}()
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * 线性方程组求解  Cholesky分解, 高斯消元(列主元), 共轭梯度
+ */
+package matn
+
+import (
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath/sutil"
+)
+
+// 主元小于此值视为奇异
+const kSingular = 1e-12
+
+// 对称正定矩阵的Cholesky分解 t = L * L^T, 返回下三角L
+// t非方阵或非正定时ok为false; 只读取t的下三角
+func (t *MatMxN) Cholesky() (l *MatMxN, ok bool) {
+	n := t.rows
+	if t.cols != n {
+		return nil, false
+	}
+	l = NewMatMxN(n, n)
+	for j := 0; j < n; j++ {
+		d := t.Get(j, j)
+		for k := 0; k < j; k++ {
+			d -= l.Get(k, j) * l.Get(k, j)
+		}
+		if d <= kSingular {
+			return nil, false
+		}
+		d = math.Sqrt(d)
+		l.Set(j, j, d)
+		for i := j + 1; i < n; i++ {
+			s := t.Get(j, i)
+			for k := 0; k < j; k++ {
+				s -= l.Get(k, i) * l.Get(k, j)
+			}
+			l.Set(j, i, s/d)
+		}
+	}
+	return l, true
+}
+
+// 由Cholesky结果L解 L * L^T * x = b
+func CholeskySolve(l *MatMxN, b VecN) VecN {
+	n := l.rows
+	checkLen(b, NewVecN(n))
+	// L * y = b
+	y := b.Clone()
+	for i := 0; i < n; i++ {
+		for k := 0; k < i; k++ {
+			y[i] -= l.Get(k, i) * y[k]
+		}
+		y[i] /= l.Get(i, i)
+	}
+	// L^T * x = y
+	for i := n - 1; i >= 0; i-- {
+		for k := i + 1; k < n; k++ {
+			y[i] -= l.Get(i, k) * y[k]
+		}
+		y[i] /= l.Get(i, i)
+	}
+	return y
+}
+
+// 列主元高斯消元解 a * x = b, a为方阵, 奇异时ok为false; 不修改a,b
+func SolveGauss(a *MatMxN, b VecN) (x VecN, ok bool) {
+	n := a.rows
+	if a.cols != n || len(b) != n {
+		return nil, false
+	}
+	m := a.Clone()
+	x = b.Clone()
+	for j := 0; j < n; j++ {
+		p := j
+		for i := j + 1; i < n; i++ {
+			if sutil.Abs(m.Get(j, i)) > sutil.Abs(m.Get(j, p)) {
+				p = i
+			}
+		}
+		if sutil.Abs(m.Get(j, p)) <= kSingular {
+			return nil, false
+		}
+		if p != j {
+			m.swapRows(p, j)
+			x[p], x[j] = x[j], x[p]
+		}
+		inv := 1 / m.Get(j, j)
+		for i := j + 1; i < n; i++ {
+			f := m.Get(j, i) * inv
+			if f == 0 {
+				continue
+			}
+			for k := j; k < n; k++ {
+				m.Set(k, i, m.Get(k, i)-f*m.Get(k, j))
+			}
+			x[i] -= f * x[j]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for k := i + 1; k < n; k++ {
+			x[i] -= m.Get(k, i) * x[k]
+		}
+		x[i] /= m.Get(i, i)
+	}
+	return x, true
+}
+
+// 列主元高斯-约当消元求逆, 对[t | I]一次消元得到[I | t^-1], 奇异时ok为false; 不修改t
+func (t *MatMxN) Inverted() (inv *MatMxN, ok bool) {
+	n := t.rows
+	if t.cols != n {
+		return nil, false
+	}
+	m := t.Clone()
+	inv = NewIdent(n)
+	for j := 0; j < n; j++ {
+		p := j
+		for i := j + 1; i < n; i++ {
+			if sutil.Abs(m.Get(j, i)) > sutil.Abs(m.Get(j, p)) {
+				p = i
+			}
+		}
+		if sutil.Abs(m.Get(j, p)) <= kSingular {
+			return nil, false
+		}
+		if p != j {
+			m.swapRows(p, j)
+			inv.swapRows(p, j)
+		}
+		// 主元行归一
+		d := 1 / m.Get(j, j)
+		for k := 0; k < n; k++ {
+			m.Set(k, j, m.Get(k, j)*d)
+			inv.Set(k, j, inv.Get(k, j)*d)
+		}
+		// 消去其余各行(上下都消)的第j列
+		for i := 0; i < n; i++ {
+			f := m.Get(j, i)
+			if i == j || f == 0 {
+				continue
+			}
+			for k := 0; k < n; k++ {
+				m.Set(k, i, m.Get(k, i)-f*m.Get(k, j))
+				inv.Set(k, i, inv.Get(k, i)-f*inv.Get(k, j))
+			}
+		}
+	}
+	return inv, true
+}
+
+// 共轭梯度法解 a * x = b, a需对称正定
+// x0为初值(可为nil), 残差|r| <= tol*|b| 或达到maxIter时停止, 返回解及迭代次数
+func SolveCG(a *MatMxN, b, x0 VecN, maxIter int, tol float32) (x VecN, iters int) {
+	if x0 != nil {
+		x = x0.Clone()
+	} else {
+		x = NewVecN(len(b))
+	}
+	r := b.Clone().Sub(a.MulVec(x))
+	p := r.Clone()
+	rr := r.Dot(r)
+	limit := tol * tol * b.Dot(b)
+
+	for iters = 0; iters < maxIter && rr > limit; iters++ {
+		ap := a.MulVec(p)
+		pap := p.Dot(ap)
+		if pap <= 0 {
+			break
+		}
+		alpha := rr / pap
+		x.AddScaled(alpha, p)
+		r.AddScaled(-alpha, ap)
+		rrNew := r.Dot(r)
+		p.Scale(rrNew / rr).Add(r)
+		rr = rrNew
+	}
+	return x, iters
+}
+
+func (t *MatMxN) swapRows(i, j int) {
+	for col := 0; col < t.cols; col++ {
+		a, b := t.Get(col, i), t.Get(col, j)
+		t.Set(col, i, b)
+		t.Set(col, j, a)
+	}
+}
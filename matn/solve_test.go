@@ -0,0 +1,134 @@
+package matn
+
+import (
+	"testing"
+)
+
+// 对称正定矩阵 a^T a + I
+func spd() *MatMxN {
+	a := fromRows(
+		[]float32{2, -1, 0, 1},
+		[]float32{1, 3, 1, 0},
+		[]float32{0, 1, 4, -2},
+		[]float32{1, 0, -1, 2},
+		[]float32{0.5, 1, 0, 1},
+	)
+	return Mul(a.Transposed(), a).Add(NewIdent(4))
+}
+
+func TestSolve(t *testing.T) {
+	a := spd()
+	want := VecN{1, -2, 0.5, 3}
+	b := a.MulVec(want)
+
+	l, ok := a.Cholesky()
+	if !ok {
+		t.Fatalf("Cholesky failed on SPD matrix")
+	}
+	if llt := Mul(l, l.Transposed()); !llt.ApproxEqual(a, 1e-5) {
+		t.Errorf("L*L^T = %v, want %v", llt, a)
+	}
+	for c := 0; c < 4; c++ {
+		for r := 0; r < c; r++ {
+			if l.Get(c, r) != 0 {
+				t.Errorf("L not lower triangular at (%d,%d)", r, c)
+			}
+		}
+	}
+
+	x1 := CholeskySolve(l, b)
+	x2, ok := SolveGauss(a, b)
+	if !ok {
+		t.Fatalf("SolveGauss failed")
+	}
+	x3, iters := SolveCG(a, b, nil, 50, 1e-6)
+	cases := []struct {
+		name string
+		x    VecN
+	}{
+		{"Cholesky", x1},
+		{"Gauss", x2},
+		{"CG", x3},
+	}
+	for _, c := range cases {
+		if !c.x.ApproxEqual(want, 1e-4) {
+			t.Errorf("%s: x = %v, want %v", c.name, c.x, want)
+		}
+	}
+	// 精确算术下n步收敛
+	if iters > 8 {
+		t.Errorf("CG took %d iterations for n=4", iters)
+	}
+	if _, iters := SolveCG(a, b, want, 50, 1e-6); iters != 0 {
+		t.Errorf("CG from exact solution took %d iterations", iters)
+	}
+}
+
+func TestSolveGaussPivot(t *testing.T) {
+	// 左上角为0, 需要换行
+	a := fromRows(
+		[]float32{0, 1, 2},
+		[]float32{1, 0, 3},
+		[]float32{4, -3, 8},
+	)
+	want := VecN{1, 2, -1}
+	x, ok := SolveGauss(a, a.MulVec(want))
+	if !ok || !x.ApproxEqual(want, 1e-5) {
+		t.Errorf("SolveGauss = %v %v, want %v", x, ok, want)
+	}
+}
+
+func TestInverted(t *testing.T) {
+	cases := []struct {
+		name string
+		m    *MatMxN
+	}{
+		{"spd", spd()},
+		{"pivot", fromRows(
+			[]float32{0, 1, 2},
+			[]float32{1, 0, 3},
+			[]float32{4, -3, 8},
+		)},
+		{"1x1", fromRows([]float32{4})},
+	}
+	for _, c := range cases {
+		orig := c.m.Clone()
+		inv, ok := c.m.Inverted()
+		if !ok {
+			t.Errorf("%s: Inverted failed", c.name)
+			continue
+		}
+		n := c.m.Rows()
+		if p := Mul(c.m, inv); !p.ApproxEqual(NewIdent(n), 1e-5) {
+			t.Errorf("%s: m * inv = %v", c.name, p)
+		}
+		if p := Mul(inv, c.m); !p.ApproxEqual(NewIdent(n), 1e-5) {
+			t.Errorf("%s: inv * m = %v", c.name, p)
+		}
+		if !c.m.ApproxEqual(orig, 0) {
+			t.Errorf("%s: Inverted modified its receiver", c.name)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	sing := fromRows(
+		[]float32{1, 2},
+		[]float32{2, 4},
+	)
+	if _, ok := sing.Inverted(); ok {
+		t.Errorf("Inverted of singular matrix")
+	}
+	if _, ok := SolveGauss(sing, VecN{1, 1}); ok {
+		t.Errorf("SolveGauss of singular matrix")
+	}
+	if _, ok := sing.Cholesky(); ok {
+		t.Errorf("Cholesky of semidefinite matrix")
+	}
+	if _, ok := NewMatMxN(2, 3).Inverted(); ok {
+		t.Errorf("Inverted of non-square matrix")
+	}
+	if _, ok := NewMatMxN(2, 3).Cholesky(); ok {
+		t.Errorf("Cholesky of non-square matrix")
+	}
+}
@@ -0,0 +1,142 @@
+/*
+ * 任意维稠密向量  列向量, 实现generic.T
+ *   与定长类型互转: VecNFrom(&v3) / vector3.FromNew(vn)
+ */
+package matn
+
+import (
+	"fmt"
+
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath/generic"
+	"github.com/tinysss/smath/sutil"
+)
+
+type VecN []float32
+
+func NewVecN(n int) VecN {
+	return make(VecN, n)
+}
+
+// 从任意generic.T拷贝, 按列展开
+func VecNFrom(other generic.T) VecN {
+	res := make(VecN, 0, other.Size())
+	for col := 0; col < other.Cols(); col++ {
+		for row := 0; row < other.Rows(); row++ {
+			res = append(res, other.Get(col, row))
+		}
+	}
+	return res
+}
+
+//-------------------------------------------- 实现generic.T begin-------------------------------------
+func (t VecN) Cols() int {
+	return 1
+}
+
+func (t VecN) Rows() int {
+	return len(t)
+}
+
+func (t VecN) Size() int {
+	return len(t)
+}
+
+func (t VecN) Slice() []float32 {
+	return t
+}
+
+func (t VecN) Get(col, row int) float32 {
+	return t[row]
+}
+
+func (t VecN) IsZero() bool {
+	for _, v := range t {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+//-------------------------------------------- 实现generic.T end -------------------------------------
+
+// 各分量在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual), 长度不同时不相等
+func (t VecN) ApproxEqual(o VecN, tol float32) bool {
+	if len(t) != len(o) {
+		return false
+	}
+	for i := range t {
+		if !sutil.AlmostEqual(t[i], o[i], tol, tol) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t VecN) Clone() VecN {
+	return append(VecN(nil), t...)
+}
+
+func (t VecN) Add(o VecN) VecN {
+	checkLen(t, o)
+	for i := range t {
+		t[i] += o[i]
+	}
+	return t
+}
+
+func (t VecN) Sub(o VecN) VecN {
+	checkLen(t, o)
+	for i := range t {
+		t[i] -= o[i]
+	}
+	return t
+}
+
+func (t VecN) Scale(f float32) VecN {
+	for i := range t {
+		t[i] *= f
+	}
+	return t
+}
+
+// t += f * o
+func (t VecN) AddScaled(f float32, o VecN) VecN {
+	checkLen(t, o)
+	for i := range t {
+		t[i] += f * o[i]
+	}
+	return t
+}
+
+func (t VecN) Dot(o VecN) float32 {
+	checkLen(t, o)
+	var res float32
+	for i := range t {
+		res += t[i] * o[i]
+	}
+	return res
+}
+
+func (t VecN) LengthSqr() float32 {
+	return t.Dot(t)
+}
+
+func (t VecN) Length() float32 {
+	return math.Sqrt(t.LengthSqr())
+}
+
+func (t VecN) Normalize() VecN {
+	l := t.Length()
+	if l == 0 {
+		return t
+	}
+	return t.Scale(1 / l)
+}
+
+func checkLen(a, b VecN) {
+	if len(a) != len(b) {
+		panic(fmt.Sprintf("matn: length mismatch %d != %d", len(a), len(b)))
+	}
+}
@@ -0,0 +1,280 @@
+/*
+ * 三角网格  法线, 切线, 包围体及体积等派生数据
+ */
+package mesh
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+	"github.com/tinysss/smath/vector4"
+)
+
+// 三角形列表, Indices每3个一组, 逆时针为正面
+type Mesh struct {
+	Positions []vector3.Vector
+	Indices   []uint32
+}
+
+func New(positions []vector3.Vector, indices []uint32) *Mesh {
+	return &Mesh{positions, indices}
+}
+
+func (t *Mesh) TriangleCount() int {
+	return len(t.Indices) / 3
+}
+
+// 第i个三角形
+func (t *Mesh) Triangle(i int) vector3.Triangle {
+	return vector3.Triangle{
+		A: t.Positions[t.Indices[i*3]],
+		B: t.Positions[t.Indices[i*3+1]],
+		C: t.Positions[t.Indices[i*3+2]],
+	}
+}
+
+// 每个三角形的单位法线
+func (t *Mesh) FaceNormals() []vector3.Vector {
+	normals := make([]vector3.Vector, t.TriangleCount())
+	for i := range normals {
+		tri := t.Triangle(i)
+		n := tri.Normal()
+		normals[i] = n.Normalized()
+	}
+	return normals
+}
+
+// 顶点法线, 按相邻三角形面积加权 (未归一化的叉积长度即两倍面积)
+func (t *Mesh) VertexNormals() []vector3.Vector {
+	normals := make([]vector3.Vector, len(t.Positions))
+	for i := 0; i < t.TriangleCount(); i++ {
+		tri := t.Triangle(i)
+		n := tri.Normal()
+		for k := 0; k < 3; k++ {
+			normals[t.Indices[i*3+k]].Add(&n)
+		}
+	}
+	for i := range normals {
+		normals[i].Normalize()
+	}
+	return normals
+}
+
+// 切线 (MikkTSpace), 每个三角形角一个, 与Indices一一对应
+// W分量为副切线方向(±1): bitangent = W * cross(normal, tangent)
+// 面切线由uv导数求得, 在每个角先对该顶点法线正交化, 再按投影后的角度加权累加
+// 同一顶点上uv朝向(镜像)不同的三角形分开累加, 即MikkTSpace的顶点拆分, 这些角得到不同的切线
+// normals, uvs须与Positions等长
+func (t *Mesh) Tangents(normals []vector3.Vector, uvs []vector2.Vector) ([]vector4.Vector, error) {
+	if len(normals) != len(t.Positions) || len(uvs) != len(t.Positions) {
+		return nil, fmt.Errorf("mesh: Tangents needs %d normals and uvs, got %d and %d",
+			len(t.Positions), len(normals), len(uvs))
+	}
+	for _, idx := range t.Indices {
+		if int(idx) >= len(t.Positions) {
+			return nil, fmt.Errorf("mesh: index %d out of range [0,%d)", idx, len(t.Positions))
+		}
+	}
+
+	// 每个顶点按uv朝向分两组累加: [0]保持朝向 [1]镜像
+	type accum struct {
+		tan   vector3.Vector
+		bitan vector3.Vector
+		used  bool
+	}
+	groups := make([][2]accum, len(t.Positions))
+	// 每个三角形的朝向组, -1为uv退化
+	orient := make([]int, t.TriangleCount())
+
+	for i := range orient {
+		idx := [3]uint32{t.Indices[i*3], t.Indices[i*3+1], t.Indices[i*3+2]}
+		p0, p1, p2 := &t.Positions[idx[0]], &t.Positions[idx[1]], &t.Positions[idx[2]]
+		w0, w1, w2 := &uvs[idx[0]], &uvs[idx[1]], &uvs[idx[2]]
+
+		e1 := vector3.Sub(p1, p0)
+		e2 := vector3.Sub(p2, p0)
+		du1, dv1 := w1[0]-w0[0], w1[1]-w0[1]
+		du2, dv2 := w2[0]-w0[0], w2[1]-w0[1]
+		det := du1*dv2 - du2*dv1
+		if det == 0 {
+			orient[i] = -1
+			continue
+		}
+		r := 1 / det
+		ft := vector3.Vector{
+			(e1[0]*dv2 - e2[0]*dv1) * r,
+			(e1[1]*dv2 - e2[1]*dv1) * r,
+			(e1[2]*dv2 - e2[2]*dv1) * r,
+		}
+		fb := vector3.Vector{
+			(e2[0]*du1 - e1[0]*du2) * r,
+			(e2[1]*du1 - e1[1]*du2) * r,
+			(e2[2]*du1 - e1[2]*du2) * r,
+		}
+		if det < 0 {
+			orient[i] = 1
+		}
+
+		corners := [3][3]uint32{{idx[0], idx[1], idx[2]}, {idx[1], idx[2], idx[0]}, {idx[2], idx[0], idx[1]}}
+		for k := 0; k < 3; k++ {
+			v := corners[k][0]
+			n := &normals[v]
+			// 面切线及两条边都投影到该顶点法线的切平面上
+			ct := projectNormalized(&ft, n)
+			cb := projectNormalized(&fb, n)
+			a := vector3.Sub(&t.Positions[corners[k][1]], &t.Positions[v])
+			b := vector3.Sub(&t.Positions[corners[k][2]], &t.Positions[v])
+			a = projectNormalized(&a, n)
+			b = projectNormalized(&b, n)
+			angle := cornerAngle(&a, &b)
+
+			g := &groups[v][orient[i]]
+			g.tan.Add(ct.Scale(angle))
+			g.bitan.Add(cb.Scale(angle))
+			g.used = true
+		}
+	}
+
+	res := make([]vector4.Vector, len(t.Indices))
+	for i, v := range t.Indices {
+		o := orient[i/3]
+		if o < 0 { // uv退化的三角形借用该顶点已有的组
+			o = 0
+			if !groups[v][0].used && groups[v][1].used {
+				o = 1
+			}
+		}
+		g := &groups[v][o]
+		n := &normals[v]
+		ti := projectNormalized(&g.tan, n)
+		if ti.IsZero() {
+			ti = n.Normal()
+		}
+		w := float32(1)
+		c := vector3.Cross(n, &ti)
+		if vector3.Dot(&c, &g.bitan) < 0 {
+			w = -1
+		}
+		res[i] = vector4.Vector{ti[0], ti[1], ti[2], w}
+	}
+	return res, nil
+}
+
+// v去掉n方向的分量后归一化, 退化时为0
+func projectNormalized(v, n *vector3.Vector) vector3.Vector {
+	proj := n.Scaled(vector3.Dot(n, v))
+	res := vector3.Sub(v, &proj)
+	if res.LengthSqr() < 1e-24 {
+		return vector3.Zero
+	}
+	return *res.Normalize()
+}
+
+// a,b夹角, 退化时为0
+func cornerAngle(a, b *vector3.Vector) float32 {
+	if a.IsZero() || b.IsZero() {
+		return 0
+	}
+	return vector3.Angle(a, b)
+}
+
+// 包围盒
+func (t *Mesh) Bounds() vector3.Box {
+	return vector3.Hull(t.Positions).Bounds()
+}
+
+// 包围球 (Ritter), 不是最小包围球但通常在5%以内
+func (t *Mesh) BoundingSphere() vector3.Sphere {
+	if len(t.Positions) == 0 {
+		return vector3.Sphere{}
+	}
+	y := farthest(t.Positions, &t.Positions[0])
+	z := farthest(t.Positions, &y)
+
+	center := vector3.Interpolate(&y, &z, 0.5)
+	radius := vector3.Distance(&y, &z) * 0.5
+	for i := range t.Positions {
+		p := &t.Positions[i]
+		d := vector3.Distance(p, &center)
+		if d <= radius {
+			continue
+		}
+		// 扩大球使其刚好包含p
+		newRadius := (radius + d) * 0.5
+		dir := vector3.Sub(p, &center)
+		dir.Scale((newRadius - radius) / d)
+		center.Add(&dir)
+		radius = newRadius
+	}
+	return vector3.Sphere{Center: center, Radius: radius}
+}
+
+func farthest(points []vector3.Vector, from *vector3.Vector) vector3.Vector {
+	res := points[0]
+	best := float32(-1)
+	for i := range points {
+		if d := vector3.SquareDistance(&points[i], from); d > best {
+			res, best = points[i], d
+		}
+	}
+	return res
+}
+
+// 表面积
+func (t *Mesh) SurfaceArea() float32 {
+	var area float64
+	for i := 0; i < t.TriangleCount(); i++ {
+		tri := t.Triangle(i)
+		area += float64(tri.Area())
+	}
+	return float32(area)
+}
+
+// 体积, 网格须封闭且法线朝外, 否则结果无意义
+// 以原点为顶点的有符号四面体体积之和
+func (t *Mesh) Volume() float32 {
+	volume, _ := t.volumeCentroid()
+	return float32(volume)
+}
+
+// 质心, 封闭网格取实体质心, 体积为0时(开放网格)取表面按面积加权的质心
+func (t *Mesh) Centroid() vector3.Vector {
+	volume, centroid := t.volumeCentroid()
+	if math.Abs(volume) > 1e-12 {
+		return centroid
+	}
+
+	var sum vector3.Vector
+	var area float32
+	for i := 0; i < t.TriangleCount(); i++ {
+		tri := t.Triangle(i)
+		a := tri.Area()
+		c := tri.Center()
+		sum.Add(c.Scale(a))
+		area += a
+	}
+	if area == 0 {
+		return vector3.Zero
+	}
+	return *sum.Scale(1 / area)
+}
+
+func (t *Mesh) volumeCentroid() (volume float64, centroid vector3.Vector) {
+	var cx, cy, cz float64
+	for i := 0; i < t.TriangleCount(); i++ {
+		tri := t.Triangle(i)
+		c := vector3.Cross(&tri.B, &tri.C)
+		v := float64(vector3.Dot(&tri.A, &c)) / 6
+		volume += v
+		// 四面体质心 (0+a+b+c)/4
+		cx += v * float64(tri.A[0]+tri.B[0]+tri.C[0]) / 4
+		cy += v * float64(tri.A[1]+tri.B[1]+tri.C[1]) / 4
+		cz += v * float64(tri.A[2]+tri.B[2]+tri.C[2]) / 4
+	}
+	if volume != 0 {
+		centroid = vector3.Vector{float32(cx / volume), float32(cy / volume), float32(cz / volume)}
+	}
+	return volume, centroid
+}
@@ -0,0 +1,168 @@
+package mesh
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+	"github.com/tinysss/smath/vector4"
+)
+
+// 单位立方体 [0,1]^3, 法线朝外
+func unitCube() *Mesh {
+	pos := []vector3.Vector{
+		{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 1, 0},
+		{0, 0, 1}, {1, 0, 1}, {1, 1, 1}, {0, 1, 1},
+	}
+	idx := []uint32{
+		0, 2, 1, 0, 3, 2, // z=0
+		4, 5, 6, 4, 6, 7, // z=1
+		0, 1, 5, 0, 5, 4, // y=0
+		3, 7, 6, 3, 6, 2, // y=1
+		0, 4, 7, 0, 7, 3, // x=0
+		1, 2, 6, 1, 6, 5, // x=1
+	}
+	return New(pos, idx)
+}
+
+func TestMeasures(t *testing.T) {
+	m := unitCube()
+	cases := []struct {
+		name      string
+		got, want float32
+	}{
+		{"area", m.SurfaceArea(), 6},
+		{"volume", m.Volume(), 1},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if c := m.Centroid(); !c.ApproxEqual(&vector3.Vector{0.5, 0.5, 0.5}, 1e-6) {
+		t.Errorf("Centroid = %v", c)
+	}
+	if b := m.Bounds(); b != (vector3.Box{Min: vector3.Vector{0, 0, 0}, Max: vector3.Vector{1, 1, 1}}) {
+		t.Errorf("Bounds = %v", b)
+	}
+	s := m.BoundingSphere()
+	for i := range m.Positions {
+		if !s.ContainsPoint(&m.Positions[i]) && vector3.Distance(&m.Positions[i], &s.Center)-s.Radius > 1e-5 {
+			t.Errorf("BoundingSphere %v misses %v", s, m.Positions[i])
+		}
+	}
+
+	// 开放网格取表面质心
+	open := New(m.Positions, m.Indices[:6])
+	if c := open.Centroid(); !c.ApproxEqual(&vector3.Vector{0.5, 0.5, 0}, 1e-6) {
+		t.Errorf("open Centroid = %v", c)
+	}
+}
+
+func TestNormals(t *testing.T) {
+	m := unitCube()
+	fn := m.FaceNormals()
+	want := []vector3.Vector{{0, 0, -1}, {0, 0, 1}, {0, -1, 0}, {0, 1, 0}, {-1, 0, 0}, {1, 0, 0}}
+	for i := range fn {
+		if !fn[i].ApproxEqual(&want[i/2], 1e-6) {
+			t.Errorf("face %d normal = %v, want %v", i, fn[i], want[i/2])
+		}
+	}
+	// 角顶点法线朝外且为单位向量
+	vn := m.VertexNormals()
+	for i := range vn {
+		d := vector3.Sub(&m.Positions[i], &vector3.Vector{0.5, 0.5, 0.5})
+		if vector3.Dot(&d, &vn[i]) <= 0 || !approx(vn[i].Length(), 1) {
+			t.Errorf("vertex %d normal = %v", i, vn[i])
+		}
+	}
+}
+
+// 3x2个顶点的平面条带, 左右两个quad共享中间一列顶点
+func strip() *Mesh {
+	pos := []vector3.Vector{
+		{0, 0, 0}, {1, 0, 0}, {2, 0, 0},
+		{0, 1, 0}, {1, 1, 0}, {2, 1, 0},
+	}
+	idx := []uint32{0, 1, 4, 0, 4, 3, 1, 2, 5, 1, 5, 4}
+	return New(pos, idx)
+}
+
+func TestTangents(t *testing.T) {
+	m := strip()
+	normals := make([]vector3.Vector, len(m.Positions))
+	for i := range normals {
+		normals[i] = vector3.UnitZ
+	}
+	plusX := vector4.Vector{1, 0, 0, 1}
+	minusX := vector4.Vector{-1, 0, 0, -1}
+
+	cases := []struct {
+		name string
+		uvs  []vector2.Vector
+		want []vector4.Vector // 每个角
+	}{
+		{"continuous", []vector2.Vector{{0, 0}, {0.5, 0}, {1, 0}, {0, 1}, {0.5, 1}, {1, 1}},
+			[]vector4.Vector{plusX, plusX, plusX, plusX, plusX, plusX, plusX, plusX, plusX, plusX, plusX, plusX}},
+		// 右边的quad镜像了u, 共享顶点1,4在两侧得到不同的切线
+		{"mirrored", []vector2.Vector{{0, 0}, {1, 0}, {0, 0}, {0, 1}, {1, 1}, {0, 1}},
+			[]vector4.Vector{plusX, plusX, plusX, plusX, plusX, plusX, minusX, minusX, minusX, minusX, minusX, minusX}},
+	}
+	for _, c := range cases {
+		got, err := m.Tangents(normals, c.uvs)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		for i := range got {
+			if !got[i].ApproxEqual(&c.want[i], 1e-6) {
+				t.Errorf("%s: corner %d tangent = %v, want %v", c.name, i, got[i], c.want[i])
+			}
+		}
+	}
+}
+
+func TestTangentsProjectPerFace(t *testing.T) {
+	// 顶点法线与面法线不同时, 切线仍与顶点法线正交且为单位向量
+	m := unitCube()
+	normals := m.VertexNormals()
+	uvs := make([]vector2.Vector, len(m.Positions))
+	for i, p := range m.Positions {
+		uvs[i] = vector2.Vector{p[0] + 0.3*p[2], p[1]}
+	}
+	got, err := m.Tangents(normals, uvs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range m.Indices {
+		tan := vector3.Vector{got[i][0], got[i][1], got[i][2]}
+		if d := vector3.Dot(&tan, &normals[v]); !approx(d, 0) || !approx(tan.Length(), 1) {
+			t.Errorf("corner %d: tangent %v not unit or not orthogonal to %v", i, tan, normals[v])
+		}
+	}
+}
+
+func TestTangentsBadInput(t *testing.T) {
+	m := strip()
+	normals := make([]vector3.Vector, len(m.Positions))
+	uvs := make([]vector2.Vector, len(m.Positions))
+	cases := []struct {
+		name    string
+		mesh    *Mesh
+		normals []vector3.Vector
+		uvs     []vector2.Vector
+	}{
+		{"short normals", m, normals[:2], uvs},
+		{"short uvs", m, normals, uvs[:5]},
+		{"bad index", New(m.Positions, []uint32{0, 1, 9}), normals, uvs},
+	}
+	for _, c := range cases {
+		if _, err := c.mesh.Tangents(c.normals, c.uvs); err == nil {
+			t.Errorf("%s: want error", c.name)
+		}
+	}
+}
+
+func approx(a, b float32) bool {
+	d := a - b
+	return d <= 1e-5 && d >= -1e-5
+}
@@ -0,0 +1,139 @@
+/*
+ * 分形组合  fBm, ridged, domain warp, 梯度按链式法则一并累加
+ */
+package noise
+
+import (
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 返回值及梯度的噪声函数, 例如 New(seed).Perlin2
+type Func2 func(p *vector2.Vector) (float32, vector2.Vector)
+type Func3 func(p *vector3.Vector) (float32, vector3.Vector)
+
+// 分形布朗运动  Σ gain^i * f(p * lacunarity^i), 按振幅和归一化
+func FBm2(f Func2, p *vector2.Vector, octaves int, lacunarity, gain float32) (float32, vector2.Vector) {
+	var value, norm float64
+	var grad [2]float64
+	amp, freq := 1.0, 1.0
+	for i := 0; i < octaves; i++ {
+		q := vector2.Vector{float32(mul(float64(p[0]), freq)), float32(mul(float64(p[1]), freq))}
+		v, g := f(&q)
+		value += mul(amp, float64(v))
+		for k := range grad {
+			grad[k] += mul(mul(amp, freq), float64(g[k]))
+		}
+		norm += amp
+		amp = mul(amp, float64(gain))
+		freq = mul(freq, float64(lacunarity))
+	}
+	if norm == 0 {
+		return 0, vector2.Zero
+	}
+	return float32(value / norm), vector2.Vector{float32(grad[0] / norm), float32(grad[1] / norm)}
+}
+
+func FBm3(f Func3, p *vector3.Vector, octaves int, lacunarity, gain float32) (float32, vector3.Vector) {
+	var value, norm float64
+	var grad [3]float64
+	amp, freq := 1.0, 1.0
+	for i := 0; i < octaves; i++ {
+		q := vector3.Vector{float32(mul(float64(p[0]), freq)), float32(mul(float64(p[1]), freq)), float32(mul(float64(p[2]), freq))}
+		v, g := f(&q)
+		value += mul(amp, float64(v))
+		for k := range grad {
+			grad[k] += mul(mul(amp, freq), float64(g[k]))
+		}
+		norm += amp
+		amp = mul(amp, float64(gain))
+		freq = mul(freq, float64(lacunarity))
+	}
+	if norm == 0 {
+		return 0, vector3.Zero
+	}
+	return float32(value / norm), vector3.Vector{float32(grad[0] / norm), float32(grad[1] / norm), float32(grad[2] / norm)}
+}
+
+// 每层取 (1-|f|)^2 形成山脊, 结果在[0,1]
+func Ridged2(f Func2, p *vector2.Vector, octaves int, lacunarity, gain float32) (float32, vector2.Vector) {
+	ridge := func(q *vector2.Vector) (float32, vector2.Vector) {
+		v, g := f(q)
+		r, dr := ridgeOf(float64(v))
+		return float32(r), vector2.Vector{float32(mul(dr, float64(g[0]))), float32(mul(dr, float64(g[1])))}
+	}
+	return FBm2(ridge, p, octaves, lacunarity, gain)
+}
+
+func Ridged3(f Func3, p *vector3.Vector, octaves int, lacunarity, gain float32) (float32, vector3.Vector) {
+	ridge := func(q *vector3.Vector) (float32, vector3.Vector) {
+		v, g := f(q)
+		r, dr := ridgeOf(float64(v))
+		return float32(r), vector3.Vector{float32(mul(dr, float64(g[0]))), float32(mul(dr, float64(g[1]))), float32(mul(dr, float64(g[2])))}
+	}
+	return FBm3(ridge, p, octaves, lacunarity, gain)
+}
+
+// (1-|v|)^2 及其对v的导数
+func ridgeOf(v float64) (r, dr float64) {
+	sign := 1.0
+	if v < 0 {
+		v, sign = -v, -1
+	}
+	r = 1 - v
+	return mul(r, r), mul(-2*sign, r)
+}
+
+// 域扭曲  f(p + amount * (warp(p), warp(p+offset)))
+// 梯度为 J^T * ∇f, J = I + amount * [∇warp(p); ∇warp(p+offset)]
+func Warp2(f, warp Func2, p *vector2.Vector, amount float32) (float32, vector2.Vector) {
+	a := float64(amount)
+	wx, gx := warp(p)
+	py := vector2.Add(p, &kWarpOffset2)
+	wy, gy := warp(&py)
+
+	q := vector2.Vector{
+		float32(float64(p[0]) + mul(a, float64(wx))),
+		float32(float64(p[1]) + mul(a, float64(wy))),
+	}
+	v, g := f(&q)
+	fx, fy := float64(g[0]), float64(g[1])
+	return v, vector2.Vector{
+		float32(fx + mul(a, mul(fx, float64(gx[0]))+mul(fy, float64(gy[0])))),
+		float32(fy + mul(a, mul(fx, float64(gx[1]))+mul(fy, float64(gy[1])))),
+	}
+}
+
+func Warp3(f, warp Func3, p *vector3.Vector, amount float32) (float32, vector3.Vector) {
+	a := float64(amount)
+	var w [3]float64
+	var gw [3]vector3.Vector
+	for k := range w {
+		off := kWarpOffset3[k]
+		pk := vector3.Add(p, &off)
+		v, g := warp(&pk)
+		w[k], gw[k] = float64(v), g
+	}
+
+	q := vector3.Vector{
+		float32(float64(p[0]) + mul(a, w[0])),
+		float32(float64(p[1]) + mul(a, w[1])),
+		float32(float64(p[2]) + mul(a, w[2])),
+	}
+	v, g := f(&q)
+	var res vector3.Vector
+	for j := range res {
+		sum := float64(g[j])
+		for k := range w {
+			sum += mul(a, mul(float64(g[k]), float64(gw[k][j])))
+		}
+		res[j] = float32(sum)
+	}
+	return v, res
+}
+
+// 不同分量的扭曲取样偏移, 避免各分量相关
+var (
+	kWarpOffset2 = vector2.Vector{5.2, 1.3}
+	kWarpOffset3 = [3]vector3.Vector{{0, 0, 0}, {5.2, 1.3, 2.8}, {1.7, 9.2, 4.1}}
+)
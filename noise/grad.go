@@ -0,0 +1,102 @@
+/*
+ * 梯度表及归一化系数
+ */
+package noise
+
+const kDiag = 0.70710678118654752
+
+var grad2 = [8][]float64{
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+	{kDiag, kDiag}, {-kDiag, kDiag}, {kDiag, -kDiag}, {-kDiag, -kDiag},
+}
+
+// 立方体12条棱的中点方向
+var grad3 = [12][]float64{
+	{1, 1, 0}, {-1, 1, 0}, {1, -1, 0}, {-1, -1, 0},
+	{1, 0, 1}, {-1, 0, 1}, {1, 0, -1}, {-1, 0, -1},
+	{0, 1, 1}, {0, -1, 1}, {0, 1, -1}, {0, -1, -1},
+}
+
+// 超立方体32条棱的中点方向
+var grad4 = [32][]float64{
+	{0, 1, 1, 1}, {0, 1, 1, -1}, {0, 1, -1, 1}, {0, 1, -1, -1},
+	{0, -1, 1, 1}, {0, -1, 1, -1}, {0, -1, -1, 1}, {0, -1, -1, -1},
+	{1, 0, 1, 1}, {1, 0, 1, -1}, {1, 0, -1, 1}, {1, 0, -1, -1},
+	{-1, 0, 1, 1}, {-1, 0, 1, -1}, {-1, 0, -1, 1}, {-1, 0, -1, -1},
+	{1, 1, 0, 1}, {1, 1, 0, -1}, {1, -1, 0, 1}, {1, -1, 0, -1},
+	{-1, 1, 0, 1}, {-1, 1, 0, -1}, {-1, -1, 0, 1}, {-1, -1, 0, -1},
+	{1, 1, 1, 0}, {1, 1, -1, 0}, {1, -1, 1, 0}, {1, -1, -1, 0},
+	{-1, 1, 1, 0}, {-1, 1, -1, 0}, {-1, -1, 1, 0}, {-1, -1, -1, 0},
+}
+
+// OpenSimplex2 2D: 24个方向, 22.5°+45°k 及 45°k±7.5°
+var osGrad2 = [24][]float64{
+	{kC1, kS1}, {kS1, kC1}, {-kS1, kC1}, {-kC1, kS1},
+	{-kC1, -kS1}, {-kS1, -kC1}, {kS1, -kC1}, {kC1, -kS1},
+	{kC2, -kS2}, {kC2, kS2}, {kC3, kS3}, {kS3, kC3},
+	{kS2, kC2}, {-kS2, kC2}, {-kS3, kC3}, {-kC3, kS3},
+	{-kC2, kS2}, {-kC2, -kS2}, {-kC3, -kS3}, {-kS3, -kC3},
+	{-kS2, -kC2}, {kS2, -kC2}, {kS3, -kC3}, {kC3, -kS3},
+}
+
+const (
+	kC1 = 0.92387953251128674 // cos 22.5°
+	kS1 = 0.38268343236508977
+	kC2 = 0.99144486137381041 // cos 7.5°
+	kS2 = 0.13052619222005159
+	kC3 = 0.79335334029123517 // cos 37.5°
+	kS3 = 0.60876142900872064
+)
+
+// OpenSimplex2 3D: 立方体12条棱方向, 每条附近4个方向, 共48个, 已归一化
+var osGrad3 = [48][]float64{
+	{kA3, kA3, kB3}, {kA3, kA3, -kB3}, {kC3d, kD3, 0}, {kD3, kC3d, 0},
+	{kA3, -kA3, kB3}, {kA3, -kA3, -kB3}, {kC3d, -kD3, 0}, {kD3, -kC3d, 0},
+	{-kA3, kA3, kB3}, {-kA3, kA3, -kB3}, {-kC3d, kD3, 0}, {-kD3, kC3d, 0},
+	{-kA3, -kA3, kB3}, {-kA3, -kA3, -kB3}, {-kC3d, -kD3, 0}, {-kD3, -kC3d, 0},
+	{kA3, kB3, kA3}, {kA3, -kB3, kA3}, {kC3d, 0, kD3}, {kD3, 0, kC3d},
+	{kA3, kB3, -kA3}, {kA3, -kB3, -kA3}, {kC3d, 0, -kD3}, {kD3, 0, -kC3d},
+	{-kA3, kB3, kA3}, {-kA3, -kB3, kA3}, {-kC3d, 0, kD3}, {-kD3, 0, kC3d},
+	{-kA3, kB3, -kA3}, {-kA3, -kB3, -kA3}, {-kC3d, 0, -kD3}, {-kD3, 0, -kC3d},
+	{kB3, kA3, kA3}, {-kB3, kA3, kA3}, {0, kC3d, kD3}, {0, kD3, kC3d},
+	{kB3, kA3, -kA3}, {-kB3, kA3, -kA3}, {0, kC3d, -kD3}, {0, kD3, -kC3d},
+	{kB3, -kA3, kA3}, {-kB3, -kA3, kA3}, {0, -kC3d, kD3}, {0, -kD3, kC3d},
+	{kB3, -kA3, -kA3}, {-kB3, -kA3, -kA3}, {0, -kC3d, -kD3}, {0, -kD3, -kC3d},
+}
+
+const (
+	kA3  = 0.67388733867900499
+	kB3  = 0.30290544652788268
+	kC3d = 0.93484692283504821
+	kD3  = 0.35505102572143250
+)
+
+// OpenSimplex2 4D: 超立方体32条棱中点方向及16个顶点方向, 已归一化
+var osGrad4 = [48][]float64{
+	{0, kE4, kE4, kE4}, {0, kE4, kE4, -kE4}, {0, kE4, -kE4, kE4}, {0, kE4, -kE4, -kE4},
+	{0, -kE4, kE4, kE4}, {0, -kE4, kE4, -kE4}, {0, -kE4, -kE4, kE4}, {0, -kE4, -kE4, -kE4},
+	{kE4, 0, kE4, kE4}, {kE4, 0, kE4, -kE4}, {kE4, 0, -kE4, kE4}, {kE4, 0, -kE4, -kE4},
+	{-kE4, 0, kE4, kE4}, {-kE4, 0, kE4, -kE4}, {-kE4, 0, -kE4, kE4}, {-kE4, 0, -kE4, -kE4},
+	{kE4, kE4, 0, kE4}, {kE4, kE4, 0, -kE4}, {kE4, -kE4, 0, kE4}, {kE4, -kE4, 0, -kE4},
+	{-kE4, kE4, 0, kE4}, {-kE4, kE4, 0, -kE4}, {-kE4, -kE4, 0, kE4}, {-kE4, -kE4, 0, -kE4},
+	{kE4, kE4, kE4, 0}, {kE4, kE4, -kE4, 0}, {kE4, -kE4, kE4, 0}, {kE4, -kE4, -kE4, 0},
+	{-kE4, kE4, kE4, 0}, {-kE4, kE4, -kE4, 0}, {-kE4, -kE4, kE4, 0}, {-kE4, -kE4, -kE4, 0},
+	{0.5, 0.5, 0.5, 0.5}, {0.5, 0.5, 0.5, -0.5}, {0.5, 0.5, -0.5, 0.5}, {0.5, 0.5, -0.5, -0.5},
+	{0.5, -0.5, 0.5, 0.5}, {0.5, -0.5, 0.5, -0.5}, {0.5, -0.5, -0.5, 0.5}, {0.5, -0.5, -0.5, -0.5},
+	{-0.5, 0.5, 0.5, 0.5}, {-0.5, 0.5, 0.5, -0.5}, {-0.5, 0.5, -0.5, 0.5}, {-0.5, 0.5, -0.5, -0.5},
+	{-0.5, -0.5, 0.5, 0.5}, {-0.5, -0.5, 0.5, -0.5}, {-0.5, -0.5, -0.5, 0.5}, {-0.5, -0.5, -0.5, -0.5},
+}
+
+const kE4 = 0.57735026918962576 // 1/sqrt(3)
+
+// 输出缩放, 使结果落在[-1,1]
+// 取上界 max_p Σ w_i * max_g(g·d_i) 的倒数, 即每个格点都取最不利的梯度时的最大值, 与种子无关
+// 上界由多起点爬山求得, 再缩小1e-6留出余量; 2D Perlin的上界为sqrt(2)/2, 在格子中心取到
+const (
+	kPerlinScale2      = 1.4142135623730951
+	kPerlinScale3      = 0.9649204636 // 1/1.0363538112
+	kPerlinScale4      = 0.6507942841 // 1/1.5365823340
+	kOpenSimplexScale2 = 99.83675463  // 1/0.0100163412
+	kOpenSimplexScale3 = 41.42313913  // 1/0.0241410724
+	kOpenSimplexScale4 = 43.67315901  // 1/0.0228973361
+)
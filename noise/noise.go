@@ -0,0 +1,312 @@
+/*
+ * 梯度噪声  Perlin, OpenSimplex2, Worley, 同时返回解析梯度
+ *
+ * OpenSimplex2按KdotJPG的格子与核函数实现: 2D为三角形格子, 3D为两套错开半格的立方格子(BCC),
+ * 并按OpenSimplex2的做法绕(1,1,1)转180度, 4D为五套沿对角线错开0.2的A4格子; 核函数为(r2-|d|^2)^4
+ * 参考实现只挑选部分格点, 这里对可能落在核半径内的格点逐个求和, 结果处处连续
+ * 梯度表2D/3D取自OpenSimplex2, 4D用超立方体棱中点及顶点方向; 哈希用自己的置换表, 数值与参考实现不同
+ *
+ * 跨平台确定性: 置换表由整数随机数生成; 内部用float64计算, 乘积都显式转换一次,
+ * 避免编译器在arm64等平台上融合成FMA导致结果不一致
+ */
+package noise
+
+import (
+	"math"
+
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+	"github.com/tinysss/smath/vector4"
+)
+
+type Noise struct {
+	seed int64
+	perm [256]uint8
+}
+
+func New(seed int64) *Noise {
+	t := &Noise{seed: seed}
+	for i := range t.perm {
+		t.perm[i] = uint8(i)
+	}
+	// Fisher-Yates, splitmix64作随机源
+	state := uint64(seed)
+	for i := len(t.perm) - 1; i > 0; i-- {
+		state = splitmix64(state)
+		j := int(state % uint64(i+1))
+		t.perm[i], t.perm[j] = t.perm[j], t.perm[i]
+	}
+	return t
+}
+
+func (t *Noise) Seed() int64 {
+	return t.seed
+}
+
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	z := x
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// 格点哈希
+func (t *Noise) hash(cell []int64) int {
+	h := 0
+	for _, c := range cell {
+		h = int(t.perm[(h+int(c&0xff))&0xff])
+	}
+	return h
+}
+
+func mul(a, b float64) float64 {
+	return float64(a * b)
+}
+
+// Perlin 2D, 返回值约在[-1,1]及梯度
+func (t *Noise) Perlin2(p *vector2.Vector) (float32, vector2.Vector) {
+	var g [2]float64
+	v := t.perlin([]float64{float64(p[0]), float64(p[1])}, g[:], grad2[:]) * kPerlinScale2
+	return float32(v), vector2.Vector{float32(g[0] * kPerlinScale2), float32(g[1] * kPerlinScale2)}
+}
+
+// Perlin 3D
+func (t *Noise) Perlin3(p *vector3.Vector) (float32, vector3.Vector) {
+	var g [3]float64
+	v := t.perlin([]float64{float64(p[0]), float64(p[1]), float64(p[2])}, g[:], grad3[:]) * kPerlinScale3
+	return float32(v), vector3.Vector{float32(g[0] * kPerlinScale3), float32(g[1] * kPerlinScale3), float32(g[2] * kPerlinScale3)}
+}
+
+// Perlin 4D
+func (t *Noise) Perlin4(p *vector4.Vector) (float32, vector4.Vector) {
+	var g [4]float64
+	v := t.perlin([]float64{float64(p[0]), float64(p[1]), float64(p[2]), float64(p[3])}, g[:], grad4[:]) * kPerlinScale4
+	return float32(v), vector4.Vector{float32(g[0] * kPerlinScale4), float32(g[1] * kPerlinScale4), float32(g[2] * kPerlinScale4), float32(g[3] * kPerlinScale4)}
+}
+
+// OpenSimplex2 2D, 返回值在[-1,1]内及梯度
+func (t *Noise) OpenSimplex2(p *vector2.Vector) (float32, vector2.Vector) {
+	var g [2]float64
+	v := t.openSimplex2(float64(p[0]), float64(p[1]), g[:]) * kOpenSimplexScale2
+	return float32(v), vector2.Vector{float32(g[0] * kOpenSimplexScale2), float32(g[1] * kOpenSimplexScale2)}
+}
+
+// OpenSimplex2 3D
+func (t *Noise) OpenSimplex3(p *vector3.Vector) (float32, vector3.Vector) {
+	var g [3]float64
+	v := t.openSimplex3([3]float64{float64(p[0]), float64(p[1]), float64(p[2])}, g[:]) * kOpenSimplexScale3
+	return float32(v), vector3.Vector{float32(g[0] * kOpenSimplexScale3), float32(g[1] * kOpenSimplexScale3), float32(g[2] * kOpenSimplexScale3)}
+}
+
+// OpenSimplex2 4D
+func (t *Noise) OpenSimplex4(p *vector4.Vector) (float32, vector4.Vector) {
+	var g [4]float64
+	v := t.openSimplex4([4]float64{float64(p[0]), float64(p[1]), float64(p[2]), float64(p[3])}, g[:]) * kOpenSimplexScale4
+	return float32(v), vector4.Vector{float32(g[0] * kOpenSimplexScale4), float32(g[1] * kOpenSimplexScale4), float32(g[2] * kOpenSimplexScale4), float32(g[3] * kOpenSimplexScale4)}
+}
+
+// 五次平滑曲线 6t^5-15t^4+10t^3 及其导数
+func fade(f float64) (s, ds float64) {
+	f2 := mul(f, f)
+	f3 := mul(f2, f)
+	s = mul(f3, mul(f, mul(f, 6)-15)+10)
+	ds = mul(mul(f2, 30), mul(f, f-2)+1)
+	return
+}
+
+// n维Perlin噪声, 对2^n个格点的梯度贡献做多线性插值
+func (t *Noise) perlin(p []float64, grad []float64, grads [][]float64) float64 {
+	n := len(p)
+	var cellBuf, fracBuf, sBuf, dsBuf [4]float64
+	var cornerBuf [4]int64
+	cell, frac, s, ds := cellBuf[:n], fracBuf[:n], sBuf[:n], dsBuf[:n]
+	corner := cornerBuf[:n]
+	for k := range p {
+		cell[k] = math.Floor(p[k])
+		frac[k] = p[k] - cell[k]
+		s[k], ds[k] = fade(frac[k])
+	}
+
+	var value float64
+	for c := 0; c < 1<<uint(n); c++ {
+		// 格点的梯度与偏移的点积
+		var dot float64
+		for k := 0; k < n; k++ {
+			bit := float64((c >> uint(k)) & 1)
+			corner[k] = int64(cell[k]) + int64(bit)
+		}
+		g := grads[t.hash(corner)%len(grads)]
+		for k := 0; k < n; k++ {
+			bit := float64((c >> uint(k)) & 1)
+			dot += mul(g[k], frac[k]-bit)
+		}
+
+		// 插值权重 w = Π (s 或 1-s), 对第j维的偏导把第j项换成 ±ds
+		w := 1.0
+		for k := 0; k < n; k++ {
+			if (c>>uint(k))&1 == 1 {
+				w = mul(w, s[k])
+			} else {
+				w = mul(w, 1-s[k])
+			}
+		}
+		value += mul(w, dot)
+
+		for j := 0; j < n; j++ {
+			dw := 1.0
+			for k := 0; k < n; k++ {
+				one := (c>>uint(k))&1 == 1
+				switch {
+				case k == j && one:
+					dw = mul(dw, ds[k])
+				case k == j:
+					dw = mul(dw, -ds[k])
+				case one:
+					dw = mul(dw, s[k])
+				default:
+					dw = mul(dw, 1-s[k])
+				}
+			}
+			grad[j] += mul(w, g[j]) + mul(dw, dot)
+		}
+	}
+	return value
+}
+
+const (
+	kSkew2      = 0.366025403784438647  // (sqrt(3)-1)/2
+	kUnskew2    = -0.211324865405187118 // (1/sqrt(3)-1)/2
+	kSkew4      = -0.138196601125010515 // (1/sqrt(5)-1)/4
+	kUnskew4    = 0.309016994374947424  // (sqrt(5)-1)/4
+	kLatStep4   = 0.2                   // 4D各套格子沿对角线的错开量
+	kRSquared2  = 0.5
+	kRSquared3  = 0.6
+	kRSquared4  = 0.6
+	kRotation3D = 2.0 / 3.0
+)
+
+// 格点对p的贡献 (r2-|d|^2)^4 * (g·d), d为p相对格点的偏移, 梯度累加到grad
+func (t *Noise) contribute(d []float64, r2 float64, cell []int64, grads [][]float64, grad []float64) float64 {
+	var dd float64
+	for k := range d {
+		dd += mul(d[k], d[k])
+	}
+	a := r2 - dd
+	if a <= 0 {
+		return 0
+	}
+	g := grads[t.hash(cell)%len(grads)]
+	var dot float64
+	for k := range d {
+		dot += mul(g[k], d[k])
+	}
+	a2 := mul(a, a)
+	a4 := mul(a2, a2)
+	// d/dp (a^4 * dot) = a^4 * g - 8 a^3 * dot * d
+	a3dot := mul(mul(a2, a), dot)
+	for k := range d {
+		grad[k] += mul(a4, g[k]) - mul(mul(8, a3dot), d[k])
+	}
+	return mul(a4, dot)
+}
+
+// 三角形格子, 只有所在三角形的三个顶点可能落在核半径内
+func (t *Noise) openSimplex2(x, y float64, grad []float64) float64 {
+	s := mul(x+y, kSkew2)
+	xs, ys := x+s, y+s
+	xb, yb := math.Floor(xs), math.Floor(ys)
+	xi, yi := xs-xb, ys-yb
+	u := mul(xi+yi, kUnskew2)
+	x0, y0 := xi+u, yi+u
+
+	// 斜切空间中的顶点偏移, 第三个顶点取决于在哪个三角形
+	verts := [3][2]int64{{0, 0}, {1, 1}, {1, 0}}
+	if y0 > x0 {
+		verts[2] = [2]int64{0, 1}
+	}
+	var value float64
+	var d [2]float64
+	var cell [2]int64
+	for _, v := range verts {
+		vu := mul(float64(v[0]+v[1]), kUnskew2)
+		d[0] = x0 - float64(v[0]) - vu
+		d[1] = y0 - float64(v[1]) - vu
+		cell[0], cell[1] = int64(xb)+v[0], int64(yb)+v[1]
+		value += t.contribute(d[:], kRSquared2, cell[:], osGrad2[:], grad)
+	}
+	return value
+}
+
+// 两套立方格子, 核半径内的格点都在p所在单位立方体的8个角上
+func (t *Noise) openSimplex3(p [3]float64, grad []float64) float64 {
+	// 绕(1,1,1)转180度: pr = 2/3*sum(p) - p, 该变换是自身的逆
+	r := mul(p[0]+p[1]+p[2], kRotation3D)
+	var pr [3]float64
+	for k := range p {
+		pr[k] = r - p[k]
+	}
+
+	var value float64
+	var gr [3]float64
+	var d [3]float64
+	var cell [4]int64
+	for c := 0; c < 2; c++ {
+		var base, frac [3]float64
+		for k := range pr {
+			sh := pr[k] - 0.5*float64(c)
+			base[k] = math.Floor(sh)
+			frac[k] = sh - base[k]
+		}
+		cell[3] = int64(c)
+		for v := 0; v < 8; v++ {
+			for k := 0; k < 3; k++ {
+				bit := int64((v >> uint(k)) & 1)
+				d[k] = frac[k] - float64(bit)
+				cell[k] = int64(base[k]) + bit
+			}
+			value += t.contribute(d[:], kRSquared3, cell[:], osGrad3[:], gr[:])
+		}
+	}
+
+	// 梯度转回原坐标系
+	gs := mul(gr[0]+gr[1]+gr[2], kRotation3D)
+	for k := range gr {
+		grad[k] += gs - gr[k]
+	}
+	return value
+}
+
+// 五套A4格子, 每套在核半径内的格点都在p所在斜切单位超立方体的16个角上
+func (t *Noise) openSimplex4(p [4]float64, grad []float64) float64 {
+	s := mul(p[0]+p[1]+p[2]+p[3], kSkew4)
+
+	var value float64
+	var d [4]float64
+	var cell [5]int64
+	for c := 0; c < 5; c++ {
+		var base, frac [4]float64
+		for k := range p {
+			sh := p[k] + s - mul(kLatStep4, float64(c))
+			base[k] = math.Floor(sh)
+			frac[k] = sh - base[k]
+		}
+		cell[4] = int64(c)
+		for v := 0; v < 16; v++ {
+			var sum float64
+			for k := 0; k < 4; k++ {
+				bit := int64((v >> uint(k)) & 1)
+				d[k] = frac[k] - float64(bit)
+				sum += d[k]
+				cell[k] = int64(base[k]) + bit
+			}
+			// 斜切空间的偏移转回原空间
+			su := mul(sum, kUnskew4)
+			for k := range d {
+				d[k] += su
+			}
+			value += t.contribute(d[:], kRSquared4, cell[:], osGrad4[:], grad)
+		}
+	}
+	return value
+}
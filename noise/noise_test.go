@@ -0,0 +1,306 @@
+package noise
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+	"github.com/tinysss/smath/vector4"
+)
+
+// 把各维噪声统一成 []float32 -> (值, 梯度)
+type evalFunc func(p []float32) (float32, []float32)
+
+func noiseFuncs(n *Noise) []struct {
+	name string
+	dim  int
+	f    evalFunc
+} {
+	return []struct {
+		name string
+		dim  int
+		f    evalFunc
+	}{
+		{"Perlin2", 2, func(p []float32) (float32, []float32) {
+			v, g := n.Perlin2(&vector2.Vector{p[0], p[1]})
+			return v, g[:]
+		}},
+		{"Perlin3", 3, func(p []float32) (float32, []float32) {
+			v, g := n.Perlin3(&vector3.Vector{p[0], p[1], p[2]})
+			return v, g[:]
+		}},
+		{"Perlin4", 4, func(p []float32) (float32, []float32) {
+			v, g := n.Perlin4(&vector4.Vector{p[0], p[1], p[2], p[3]})
+			return v, g[:]
+		}},
+		{"OpenSimplex2", 2, func(p []float32) (float32, []float32) {
+			v, g := n.OpenSimplex2(&vector2.Vector{p[0], p[1]})
+			return v, g[:]
+		}},
+		{"OpenSimplex3", 3, func(p []float32) (float32, []float32) {
+			v, g := n.OpenSimplex3(&vector3.Vector{p[0], p[1], p[2]})
+			return v, g[:]
+		}},
+		{"OpenSimplex4", 4, func(p []float32) (float32, []float32) {
+			v, g := n.OpenSimplex4(&vector4.Vector{p[0], p[1], p[2], p[3]})
+			return v, g[:]
+		}},
+	}
+}
+
+func randPoint(r *rand.Rand, dim int, span float32) []float32 {
+	p := make([]float32, dim)
+	for k := range p {
+		p[k] = (r.Float32()*2 - 1) * span
+	}
+	return p
+}
+
+func TestRange(t *testing.T) {
+	samples := map[int]int{2: 200000, 3: 100000, 4: 40000}
+	for seed := int64(0); seed < 3; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		for _, c := range noiseFuncs(New(seed)) {
+			var maxAbs float32
+			for i := 0; i < samples[c.dim]; i++ {
+				v, _ := c.f(randPoint(r, c.dim, 100))
+				if v < -1 || v > 1 || v != v {
+					t.Fatalf("seed %d %s: value %v out of [-1,1]", seed, c.name, v)
+				}
+				if v < 0 {
+					v = -v
+				}
+				if v > maxAbs {
+					maxAbs = v
+				}
+			}
+			// 缩放不能过于保守
+			if maxAbs < 0.4 {
+				t.Errorf("seed %d %s: max |value| only %v", seed, c.name, maxAbs)
+			}
+		}
+	}
+}
+
+func TestGradient(t *testing.T) {
+	const h = 1e-3
+	r := rand.New(rand.NewSource(5))
+	for _, c := range noiseFuncs(New(42)) {
+		for i := 0; i < 200; i++ {
+			p := randPoint(r, c.dim, 20)
+			_, g := c.f(p)
+			for k := 0; k < c.dim; k++ {
+				a := append([]float32(nil), p...)
+				b := append([]float32(nil), p...)
+				a[k] += h
+				b[k] -= h
+				va, _ := c.f(a)
+				vb, _ := c.f(b)
+				fd := (va - vb) / float32(a[k]-b[k])
+				if math.Abs(float64(fd-g[k])) > 0.02*(1+math.Abs(float64(g[k]))) {
+					t.Errorf("%s at %v: d/dx%d = %v, finite difference %v", c.name, p, k, g[k], fd)
+				}
+			}
+		}
+	}
+}
+
+func TestContinuity(t *testing.T) {
+	// 沿随机直线细分采样, 相邻两点的差不能超过 步长*梯度上限
+	const step = 1e-3
+	r := rand.New(rand.NewSource(9))
+	for _, c := range noiseFuncs(New(3)) {
+		for line := 0; line < 20; line++ {
+			p := randPoint(r, c.dim, 10)
+			dir := randPoint(r, c.dim, 1)
+			prev, _ := c.f(p)
+			for i := 1; i < 3000; i++ {
+				q := make([]float32, c.dim)
+				for k := range q {
+					q[k] = p[k] + dir[k]*step*float32(i)
+				}
+				v, g := c.f(q)
+				var gl float64
+				for k := range g {
+					gl += float64(g[k] * g[k])
+				}
+				if d := math.Abs(float64(v - prev)); d > step*(2*math.Sqrt(gl)*2+1) {
+					t.Fatalf("%s: jump %v at %v", c.name, d, q)
+				}
+				prev = v
+			}
+		}
+	}
+}
+
+func TestSeed(t *testing.T) {
+	p := vector3.Vector{1.3, -2.7, 0.4}
+	a, _ := New(1).OpenSimplex3(&p)
+	b, _ := New(1).OpenSimplex3(&p)
+	c, _ := New(2).OpenSimplex3(&p)
+	if a != b {
+		t.Errorf("same seed: %v != %v", a, b)
+	}
+	if a == c {
+		t.Errorf("different seeds give the same value %v", a)
+	}
+	if s := New(77).Seed(); s != 77 {
+		t.Errorf("Seed = %v", s)
+	}
+}
+
+func TestWorley(t *testing.T) {
+	n := New(4)
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 2000; i++ {
+		p2 := vector2.Vector{r.Float32() * 50, r.Float32() * 50}
+		f1, f2, g := n.Worley2(&p2)
+		// f1的梯度是指向远离最近特征点的单位向量
+		if f1 < 0 || f1 > f2 || (f1 > 0 && math.Abs(float64(g.Length())-1) > 1e-4) {
+			t.Fatalf("Worley2 at %v: f1=%v f2=%v grad=%v", p2, f1, f2, g)
+		}
+		p3 := vector3.Vector{r.Float32() * 50, r.Float32() * 50, r.Float32() * 50}
+		f1, f2, g3 := n.Worley3(&p3)
+		if f1 < 0 || f1 > f2 || (f1 > 0 && math.Abs(float64(g3.Length())-1) > 1e-4) {
+			t.Fatalf("Worley3 at %v: f1=%v f2=%v grad=%v", p3, f1, f2, g3)
+		}
+	}
+}
+
+// 暴力搜索周围7^n个格子作为参考
+func bruteWorley(n *Noise, p []float64) (f1, f2 float64) {
+	dim := len(p)
+	count := 1
+	for k := 0; k < dim; k++ {
+		count *= 7
+	}
+	f1, f2 = math.MaxFloat64, math.MaxFloat64
+	cell := make([]int64, dim)
+	for c := 0; c < count; c++ {
+		idx := c
+		for k := 0; k < dim; k++ {
+			cell[k] = int64(math.Floor(p[k])) + int64(idx%7) - 3
+			idx /= 7
+		}
+		var dd float64
+		for k := 0; k < dim; k++ {
+			d := p[k] - (float64(cell[k]) + n.jitter(cell, k))
+			dd += mul(d, d)
+		}
+		if dd < f1 {
+			f1, f2 = dd, f1
+		} else if dd < f2 {
+			f2 = dd
+		}
+	}
+	return math.Sqrt(f1), math.Sqrt(f2)
+}
+
+func TestWorleyBruteForce(t *testing.T) {
+	tests := []struct {
+		name    string
+		dim     int
+		samples int
+	}{
+		{"2D", 2, 50000},
+		{"3D", 3, 5000},
+	}
+	for _, tt := range tests {
+		n := New(9)
+		r := rand.New(rand.NewSource(9))
+		var g [3]float64
+		p := make([]float64, tt.dim)
+		for i := 0; i < tt.samples; i++ {
+			for k := range p {
+				p[k] = r.Float64()*100 - 50
+			}
+			f1, f2 := n.worley(p, g[:tt.dim])
+			w1, w2 := bruteWorley(n, p)
+			if f1 != w1 || f2 != w2 {
+				t.Fatalf("%s at %v: f1=%v f2=%v, want %v %v", tt.name, p, f1, f2, w1, w2)
+			}
+		}
+	}
+}
+
+func TestFractalGradient(t *testing.T) {
+	const h = 1e-3
+	n := New(11)
+	// Ridged在每层f=0处有折痕, 附近的点跳过
+	nearCrease2 := func(q *vector2.Vector) bool {
+		for i, freq := 0, float32(1); i < 4; i, freq = i+1, freq*2 {
+			v, _ := n.Perlin2(&vector2.Vector{q[0] * freq, q[1] * freq})
+			if v > -0.05 && v < 0.05 {
+				return true
+			}
+		}
+		return false
+	}
+	nearCrease3 := func(q *vector3.Vector) bool {
+		for i, freq := 0, float32(1); i < 3; i, freq = i+1, freq*2 {
+			v, _ := n.Perlin3(&vector3.Vector{q[0] * freq, q[1] * freq, q[2] * freq})
+			if v > -0.05 && v < 0.05 {
+				return true
+			}
+		}
+		return false
+	}
+	cases2 := []struct {
+		name string
+		f    Func2
+		skip func(q *vector2.Vector) bool
+	}{
+		{"FBm2", func(q *vector2.Vector) (float32, vector2.Vector) { return FBm2(n.OpenSimplex2, q, 5, 2, 0.5) }, nil},
+		{"Ridged2", func(q *vector2.Vector) (float32, vector2.Vector) { return Ridged2(n.Perlin2, q, 4, 2, 0.5) }, nearCrease2},
+		{"Warp2", func(q *vector2.Vector) (float32, vector2.Vector) { return Warp2(n.Perlin2, n.OpenSimplex2, q, 0.7) }, nil},
+	}
+	cases3 := []struct {
+		name string
+		f    Func3
+		skip func(q *vector3.Vector) bool
+	}{
+		{"FBm3", func(q *vector3.Vector) (float32, vector3.Vector) { return FBm3(n.OpenSimplex3, q, 4, 2, 0.5) }, nil},
+		{"Ridged3", func(q *vector3.Vector) (float32, vector3.Vector) { return Ridged3(n.Perlin3, q, 3, 2, 0.5) }, nearCrease3},
+		{"Warp3", func(q *vector3.Vector) (float32, vector3.Vector) { return Warp3(n.OpenSimplex3, n.Perlin3, q, 0.5) }, nil},
+	}
+	check := func(name string, g, fd float32) {
+		if math.Abs(float64(fd-g)) > 0.03*(1+math.Abs(float64(g))) {
+			t.Errorf("%s: gradient %v, finite difference %v", name, g, fd)
+		}
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		p := vector2.Vector{r.Float32() * 10, r.Float32() * 10}
+		for _, c := range cases2 {
+			if c.skip != nil && c.skip(&p) {
+				continue
+			}
+			_, g := c.f(&p)
+			for k := 0; k < 2; k++ {
+				a, b := p, p
+				a[k] += h
+				b[k] -= h
+				va, _ := c.f(&a)
+				vb, _ := c.f(&b)
+				check(c.name, g[k], (va-vb)/(a[k]-b[k]))
+			}
+		}
+		q := vector3.Vector{r.Float32() * 10, r.Float32() * 10, r.Float32() * 10}
+		for _, c := range cases3 {
+			if c.skip != nil && c.skip(&q) {
+				continue
+			}
+			_, g := c.f(&q)
+			for k := 0; k < 3; k++ {
+				a, b := q, q
+				a[k] += h
+				b[k] -= h
+				va, _ := c.f(&a)
+				vb, _ := c.f(&b)
+				check(c.name, g[k], (va-vb)/(a[k]-b[k]))
+			}
+		}
+	}
+}
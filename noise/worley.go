@@ -0,0 +1,99 @@
+/*
+ * Worley(cellular)噪声  每个格子一个特征点
+ */
+package noise
+
+import (
+	"math"
+
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 2D Worley, f1/f2为到最近/次近特征点的距离, grad为f1的梯度
+func (t *Noise) Worley2(p *vector2.Vector) (f1, f2 float32, grad vector2.Vector) {
+	var g [2]float64
+	d1, d2 := t.worley([]float64{float64(p[0]), float64(p[1])}, g[:])
+	return float32(d1), float32(d2), vector2.Vector{float32(g[0]), float32(g[1])}
+}
+
+// 3D Worley
+func (t *Noise) Worley3(p *vector3.Vector) (f1, f2 float32, grad vector3.Vector) {
+	var g [3]float64
+	d1, d2 := t.worley([]float64{float64(p[0]), float64(p[1]), float64(p[2])}, g[:])
+	return float32(d1), float32(d2), vector3.Vector{float32(g[0]), float32(g[1]), float32(g[2])}
+}
+
+// 格子内特征点第k维的偏移 [0,1)
+func (t *Noise) jitter(cell []int64, k int) float64 {
+	h := uint64(t.seed)
+	for _, c := range cell {
+		h = splitmix64(h ^ uint64(c))
+	}
+	h = splitmix64(h + uint64(k))
+	return float64(h>>11) / (1 << 53)
+}
+
+// 遍历周围5^n个格子的特征点
+// 特征点可在格子内任意位置, 次近点可能在两格以外, 只搜3^n会在格子边界出错
+// 先算内圈3^n个格子, 外圈格子到p的最小距离已超过f2时跳过
+func (t *Noise) worley(p []float64, grad []float64) (f1, f2 float64) {
+	n := len(p)
+	var baseBuf, featureBuf, nearestBuf [4]float64
+	var cellBuf [4]int64
+	base, feature, nearest := baseBuf[:n], featureBuf[:n], nearestBuf[:n]
+	cell := cellBuf[:n]
+	for k := range p {
+		base[k] = math.Floor(p[k])
+	}
+
+	f1, f2 = math.MaxFloat64, math.MaxFloat64
+	count := 1
+	for k := 0; k < n; k++ {
+		count *= 5
+	}
+	for pass := 0; pass < 2; pass++ {
+		for c := 0; c < count; c++ {
+			idx := c
+			outer := false
+			var lower float64 // p到格子的最小距离平方
+			for k := 0; k < n; k++ {
+				o := int64(idx%5) - 2
+				idx /= 5
+				cell[k] = int64(base[k]) + o
+				outer = outer || o == -2 || o == 2
+				if d := p[k] - float64(cell[k]); d < 0 {
+					lower += mul(d, d)
+				} else if d > 1 {
+					lower += mul(d-1, d-1)
+				}
+			}
+			if outer != (pass == 1) || (outer && lower > f2) {
+				continue
+			}
+			var dd float64
+			for k := 0; k < n; k++ {
+				feature[k] = float64(cell[k]) + t.jitter(cell, k)
+				d := p[k] - feature[k]
+				dd += mul(d, d)
+			}
+			if dd < f1 {
+				f2 = f1
+				f1 = dd
+				copy(nearest, feature)
+			} else if dd < f2 {
+				f2 = dd
+			}
+		}
+	}
+
+	f1 = math.Sqrt(f1)
+	f2 = math.Sqrt(f2)
+	// f1 = |p - nearest|, 梯度为单位方向
+	if f1 > 0 {
+		for k := 0; k < n; k++ {
+			grad[k] = (p[k] - nearest[k]) / f1
+		}
+	}
+	return f1, f2
+}
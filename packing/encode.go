@@ -0,0 +1,301 @@
+/*
+ * 按结构体字段自动计算偏移并编码
+ *   支持 float32/int32/uint32/bool(4字节), 长度2~4的float32数组视为向量,
+ *   其他数组(含矩阵, 即列向量数组)按数组规则, 嵌套结构体按结构体规则
+ *   切片只能作为顶层值或顶层结构体的最后一个字段 (SSBO末尾不定长数组)
+ *   tag: `packing:"-"` 忽略字段, `packing:"offset=64"` 指定偏移(不能小于自动偏移且需满足对齐)
+ *   未导出字段忽略
+ */
+package packing
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 字段偏移信息, 嵌套结构体的字段名用.连接
+type Field struct {
+	Name   string
+	Offset int
+	Size   int
+}
+
+type fieldLayout struct {
+	index  int
+	name   string
+	offset int
+	size   int // 切片字段为0
+	stride int // 切片字段的元素步长
+	slice  bool
+}
+
+type structLayout struct {
+	fields []fieldLayout
+	size   int // 不含末尾切片
+	align  int
+}
+
+type layoutKey struct {
+	layout Layout
+	typ    reflect.Type
+	top    bool
+}
+
+var layoutCache sync.Map // layoutKey -> *structLayout
+
+// 按布局编码v, v可以是值或指针
+func Marshal(layout Layout, v interface{}) ([]byte, error) {
+	buf, _, err := marshal(layout, v)
+	return buf, err
+}
+
+// v的类型的字段偏移及总大小 (末尾切片按v的实际长度计算)
+func Fields(layout Layout, v interface{}) (fields []Field, size int, err error) {
+	rv, err := indirect(v)
+	if err != nil {
+		return nil, 0, err
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, 0, fmt.Errorf("packing: Fields needs a struct, got %s", rv.Type())
+	}
+	size, _, err = layout.valueInfo(rv)
+	if err != nil {
+		return nil, 0, err
+	}
+	fields, err = layout.appendFields(fields, rv.Type(), "", 0, true)
+	return fields, size, err
+}
+
+func marshal(layout Layout, v interface{}) (buf []byte, align int, err error) {
+	rv, err := indirect(v)
+	if err != nil {
+		return nil, 0, err
+	}
+	size, align, err := layout.valueInfo(rv)
+	if err != nil {
+		return nil, 0, err
+	}
+	buf = make([]byte, size)
+	if err = layout.encode(buf, rv, true); err != nil {
+		return nil, 0, err
+	}
+	return buf, align, nil
+}
+
+func indirect(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return rv, errors.New("packing: nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return rv, errors.New("packing: nil value")
+	}
+	return rv, nil
+}
+
+// 顶层值的大小和对齐, 包含切片长度
+func (t Layout) valueInfo(v reflect.Value) (size, align int, err error) {
+	switch v.Kind() {
+	case reflect.Slice:
+		es, ea, err := t.typeInfo(v.Type().Elem())
+		if err != nil {
+			return 0, 0, err
+		}
+		return t.ArrayStride(es, ea) * v.Len(), t.ArrayAlign(ea), nil
+	case reflect.Struct:
+		sl, err := t.structLayout(v.Type(), true)
+		if err != nil {
+			return 0, 0, err
+		}
+		size = sl.size
+		if n := len(sl.fields); n > 0 && sl.fields[n-1].slice {
+			f := sl.fields[n-1]
+			size = roundUp(f.offset+f.stride*v.Field(f.index).Len(), sl.align)
+		}
+		return size, sl.align, nil
+	}
+	return t.typeInfo(v.Type())
+}
+
+func (t Layout) typeInfo(typ reflect.Type) (size, align int, err error) {
+	switch typ.Kind() {
+	case reflect.Float32, reflect.Int32, reflect.Uint32, reflect.Bool:
+		return 4, 4, nil
+	case reflect.Array:
+		if isVector(typ) {
+			return typ.Len() * 4, t.VecAlign(typ.Len()), nil
+		}
+		es, ea, err := t.typeInfo(typ.Elem())
+		if err != nil {
+			return 0, 0, err
+		}
+		return t.ArrayStride(es, ea) * typ.Len(), t.ArrayAlign(ea), nil
+	case reflect.Struct:
+		sl, err := t.structLayout(typ, false)
+		if err != nil {
+			return 0, 0, err
+		}
+		return sl.size, sl.align, nil
+	case reflect.Slice:
+		return 0, 0, fmt.Errorf("packing: slice %s only allowed at top level or as the last field of the top-level struct", typ)
+	}
+	return 0, 0, fmt.Errorf("packing: unsupported type %s", typ)
+}
+
+func isVector(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Array && typ.Elem().Kind() == reflect.Float32 && typ.Len() >= 2 && typ.Len() <= 4
+}
+
+func (t Layout) structLayout(typ reflect.Type, top bool) (*structLayout, error) {
+	key := layoutKey{t, typ, top}
+	if sl, ok := layoutCache.Load(key); ok {
+		return sl.(*structLayout), nil
+	}
+
+	type member struct {
+		field  reflect.StructField
+		offset int // -1为自动
+	}
+	var members []member
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := f.Tag.Get("packing")
+		if tag == "-" {
+			continue
+		}
+		offset := -1
+		if tag != "" {
+			if !strings.HasPrefix(tag, "offset=") {
+				return nil, fmt.Errorf("packing: %s.%s: bad tag %q", typ, f.Name, tag)
+			}
+			n, err := strconv.Atoi(strings.TrimPrefix(tag, "offset="))
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("packing: %s.%s: bad offset %q", typ, f.Name, tag)
+			}
+			offset = n
+		}
+		members = append(members, member{f, offset})
+	}
+
+	sl := &structLayout{}
+	offset, maxAlign := 0, 4
+	for i, m := range members {
+		fl := fieldLayout{index: m.field.Index[0], name: m.field.Name}
+		var align int
+		if m.field.Type.Kind() == reflect.Slice {
+			if !top || i != len(members)-1 {
+				return nil, fmt.Errorf("packing: %s.%s: slice must be the last field of the top-level struct", typ, m.field.Name)
+			}
+			es, ea, err := t.typeInfo(m.field.Type.Elem())
+			if err != nil {
+				return nil, err
+			}
+			fl.slice = true
+			fl.stride = t.ArrayStride(es, ea)
+			align = t.ArrayAlign(ea)
+		} else {
+			size, a, err := t.typeInfo(m.field.Type)
+			if err != nil {
+				return nil, err
+			}
+			fl.size = size
+			align = a
+		}
+
+		offset = roundUp(offset, align)
+		if m.offset >= 0 {
+			if m.offset < offset || m.offset%align != 0 {
+				return nil, fmt.Errorf("packing: %s.%s: offset %d overlaps or misaligned (min %d, align %d)",
+					typ, m.field.Name, m.offset, offset, align)
+			}
+			offset = m.offset
+		}
+		fl.offset = offset
+		offset += fl.size
+		if align > maxAlign {
+			maxAlign = align
+		}
+		sl.fields = append(sl.fields, fl)
+	}
+	sl.align = t.StructAlign(maxAlign)
+	sl.size = roundUp(offset, sl.align)
+
+	layoutCache.Store(key, sl)
+	return sl, nil
+}
+
+func (t Layout) appendFields(fields []Field, typ reflect.Type, prefix string, base int, top bool) ([]Field, error) {
+	sl, err := t.structLayout(typ, top)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range sl.fields {
+		name := prefix + f.name
+		fields = append(fields, Field{name, base + f.offset, f.size})
+		ft := typ.Field(f.index).Type
+		if ft.Kind() == reflect.Struct {
+			if fields, err = t.appendFields(fields, ft, name+".", base+f.offset, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fields, nil
+}
+
+// 将v写入buf起始处, buf长度已按布局计算好
+func (t Layout) encode(buf []byte, v reflect.Value, top bool) error {
+	switch v.Kind() {
+	case reflect.Float32:
+		binary.LittleEndian.PutUint32(buf, math.Float32bits(float32(v.Float())))
+	case reflect.Int32:
+		binary.LittleEndian.PutUint32(buf, uint32(v.Int()))
+	case reflect.Uint32:
+		binary.LittleEndian.PutUint32(buf, uint32(v.Uint()))
+	case reflect.Bool:
+		if v.Bool() {
+			binary.LittleEndian.PutUint32(buf, 1)
+		}
+	case reflect.Array, reflect.Slice:
+		if isVector(v.Type()) {
+			for i := 0; i < v.Len(); i++ {
+				binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(float32(v.Index(i).Float())))
+			}
+			return nil
+		}
+		es, ea, err := t.typeInfo(v.Type().Elem())
+		if err != nil {
+			return err
+		}
+		stride := t.ArrayStride(es, ea)
+		for i := 0; i < v.Len(); i++ {
+			if err := t.encode(buf[i*stride:], v.Index(i), false); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		sl, err := t.structLayout(v.Type(), top)
+		if err != nil {
+			return err
+		}
+		for _, f := range sl.fields {
+			if err := t.encode(buf[f.offset:], v.Field(f.index), false); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("packing: unsupported type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+ * GPU缓冲区布局规则  std140 / std430 / scalar
+ *   基本类型4字节; vec2对齐8, vec3/vec4对齐16 (scalar布局全部对齐4)
+ *   矩阵按列存储, 布局等同于其列向量组成的数组
+ *   std140中数组步长及结构体对齐向上取整到16
+ */
+package packing
+
+type Layout int
+
+const (
+	Std140 Layout = iota // uniform buffer
+	Std430               // storage buffer
+	Scalar               // VK_EXT_scalar_block_layout, 只按分量对齐
+)
+
+func (t Layout) String() string {
+	switch t {
+	case Std140:
+		return "std140"
+	case Std430:
+		return "std430"
+	case Scalar:
+		return "scalar"
+	}
+	return "unknown"
+}
+
+// n维向量的对齐
+func (t Layout) VecAlign(n int) int {
+	if t == Scalar || n == 1 {
+		return 4
+	}
+	if n == 2 {
+		return 8
+	}
+	return 16
+}
+
+// 数组元素步长
+func (t Layout) ArrayStride(elemSize, elemAlign int) int {
+	switch t {
+	case Std140:
+		return roundUp(roundUp(elemSize, elemAlign), 16)
+	case Std430:
+		return roundUp(elemSize, elemAlign)
+	}
+	return elemSize
+}
+
+// 数组对齐
+func (t Layout) ArrayAlign(elemAlign int) int {
+	if t == Std140 && elemAlign < 16 {
+		return 16
+	}
+	return elemAlign
+}
+
+// 结构体对齐, maxAlign为成员中最大的对齐
+func (t Layout) StructAlign(maxAlign int) int {
+	if t == Std140 {
+		return roundUp(maxAlign, 16)
+	}
+	return maxAlign
+}
+
+// cols列rows行矩阵的大小和对齐
+func (t Layout) MatInfo(cols, rows int) (size, align int) {
+	colAlign := t.VecAlign(rows)
+	stride := t.ArrayStride(rows*4, colAlign)
+	return stride * cols, t.ArrayAlign(colAlign)
+}
+
+func roundUp(n, align int) int {
+	return (n + align - 1) / align * align
+}
@@ -0,0 +1,271 @@
+package packing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/tinysss/smath/mat2"
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/mat4x3"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+	"github.com/tinysss/smath/vector4"
+)
+
+// OpenGL规范中std140布局的示例uniform块
+// 整型向量(bvec2, uvec3)换成同尺寸的float向量, 对齐规则相同
+// [2]float32会被当作vec2, float[2]用单成员结构体的数组表示, 三种布局下步长与float数组一致
+type specFloat struct {
+	V float32
+}
+
+type specInner struct {
+	D int32
+	E vector2.Vector
+}
+
+type specOuter struct {
+	J vector3.Vector
+	K vector2.Vector
+	L [2]specFloat
+	M vector2.Vector
+	N [2]mat3.Mat3
+}
+
+type specBlock struct {
+	A float32
+	B vector2.Vector
+	C vector3.Vector
+	F specInner
+	G float32
+	H [2]specFloat
+	I [2]vector3.Vector // mat2x3
+	O [2]specOuter
+}
+
+func TestSpecOffsets(t *testing.T) {
+	names := []string{"A", "B", "C", "F", "F.D", "F.E", "G", "H", "I", "O"}
+	cases := []struct {
+		layout  Layout
+		offsets []int
+		size    int
+	}{
+		// 与规范中注释的偏移一致
+		{Std140, []int{0, 8, 16, 32, 32, 40, 48, 64, 96, 128}, 480},
+		{Std430, []int{0, 8, 16, 32, 32, 40, 48, 52, 64, 96}, 384},
+		{Scalar, []int{0, 4, 12, 24, 24, 28, 36, 40, 48, 72}, 288},
+	}
+	for _, c := range cases {
+		fields, size, err := Fields(c.layout, specBlock{})
+		if err != nil {
+			t.Fatalf("%v: %v", c.layout, err)
+		}
+		if size != c.size {
+			t.Errorf("%v: size %d, want %d", c.layout, size, c.size)
+		}
+		if len(fields) != len(names) {
+			t.Fatalf("%v: fields %v", c.layout, fields)
+		}
+		for i, f := range fields {
+			if f.Name != names[i] || f.Offset != c.offsets[i] {
+				t.Errorf("%v: field %d = %s@%d, want %s@%d", c.layout, i, f.Name, f.Offset, names[i], c.offsets[i])
+			}
+		}
+	}
+}
+
+func floatAt(buf []byte, off int) float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(buf[off:]))
+}
+
+// 数组内结构体成员的偏移
+func TestSpecArrayOffsets(t *testing.T) {
+	var b specBlock
+	b.H[1].V = 1
+	b.I[1][2] = 2
+	b.O[1].J[0] = 3
+	b.O[1].L[1].V = 4
+	b.O[1].N[1][2][2] = 5
+	b.F.E[1] = 6
+	cases := []struct {
+		layout  Layout
+		offsets [6]int
+	}{
+		// h[1], i[1].z, o[1].j, o[1].l[1], o[1].n[1][2][2], f.e.y
+		{Std140, [6]int{80, 120, 304, 352, 304 + 80 + 48 + 32 + 8, 44}},
+		{Std430, [6]int{56, 88, 240, 240 + 28, 240 + 48 + 48 + 32 + 8, 44}},
+		{Scalar, [6]int{44, 68, 180, 180 + 24, 180 + 36 + 36 + 24 + 8, 32}},
+	}
+	for _, c := range cases {
+		buf, err := Marshal(c.layout, &b)
+		if err != nil {
+			t.Fatalf("%v: %v", c.layout, err)
+		}
+		for i, off := range c.offsets {
+			if got := floatAt(buf, off); got != float32(i+1) {
+				t.Errorf("%v: value %d at offset %d = %v", c.layout, i+1, off, got)
+			}
+		}
+		// 其余都是0
+		var sum int
+		for off := 0; off < len(buf); off += 4 {
+			if floatAt(buf, off) != 0 {
+				sum++
+			}
+		}
+		if sum != len(c.offsets) {
+			t.Errorf("%v: %d non-zero words, want %d", c.layout, sum, len(c.offsets))
+		}
+	}
+}
+
+func TestMatInfo(t *testing.T) {
+	cases := []struct {
+		layout      Layout
+		cols, rows  int
+		size, align int
+	}{
+		{Std140, 2, 2, 32, 16},
+		{Std430, 2, 2, 16, 8},
+		{Scalar, 2, 2, 16, 4},
+		{Std140, 3, 3, 48, 16},
+		{Std430, 3, 3, 48, 16},
+		{Scalar, 3, 3, 36, 4},
+		{Std140, 4, 4, 64, 16},
+		{Std140, 3, 2, 48, 16}, // mat3x2
+		{Std430, 3, 2, 24, 8},
+		{Std430, 3, 4, 48, 16}, // mat3x4
+	}
+	for _, c := range cases {
+		size, align := c.layout.MatInfo(c.cols, c.rows)
+		if size != c.size || align != c.align {
+			t.Errorf("%v mat%dx%d: size %d align %d, want %d %d", c.layout, c.cols, c.rows, size, align, c.size, c.align)
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+	m2 := mat2.Mat2{{1, 2}, {3, 4}}
+	m3 := mat3.Ident
+	m4 := mat4.Ident
+	v2 := vector2.Vector{1, 2}
+	v3 := vector3.Vector{1, 2, 3}
+	v4 := vector4.Vector{1, 2, 3, 4}
+	q := quat.Quaternion{0, 0, 0, 1}
+
+	type block struct {
+		F  float32
+		V3 vector3.Vector
+		I  int32
+		V2 vector2.Vector
+		M2 mat2.Mat2
+		B  bool
+		M3 mat3.Mat3
+		V4 vector4.Vector
+		Q  quat.Quaternion
+		U  uint32
+		M4 mat4.Mat4
+	}
+	blk := block{1.5, v3, -2, v2, m2, true, m3, v4, q, 7, m4}
+	for _, l := range []Layout{Std140, Std430, Scalar} {
+		w := NewWriter(l)
+		w.Float32(1.5).Vec3(&v3).Int32(-2).Vec2(&v2).Mat2(&m2).Bool(true).Mat3(&m3).Vec4(&v4).Quat(&q).Uint32(7).Mat4(&m4)
+		want, err := Marshal(l, &blk)
+		if err != nil {
+			t.Fatalf("%v: %v", l, err)
+		}
+		if got := w.End(); !bytes.Equal(got, want) {
+			t.Errorf("%v: Writer %d bytes, Marshal %d bytes", l, len(got), len(want))
+		}
+	}
+
+	// std140中mat2每列补齐到16字节
+	w := NewWriter(Std140)
+	w.Mat2(&m2)
+	if w.Len() != 32 || floatAt(w.Bytes(), 16) != 3 {
+		t.Errorf("std140 mat2 = %v", w.Bytes())
+	}
+	w.Reset()
+	w.Float32(1)
+	if got := len(w.End()); got != 16 {
+		t.Errorf("std140 End = %d bytes, want 16", got)
+	}
+}
+
+func TestSlice(t *testing.T) {
+	type ssbo struct {
+		Count uint32
+		Bones []mat4x3.Mat4x3
+	}
+	v := ssbo{2, []mat4x3.Mat4x3{mat4x3.Ident, mat4x3.Ident}}
+	for _, l := range []Layout{Std140, Std430} {
+		fields, size, err := Fields(l, &v)
+		if err != nil {
+			t.Fatalf("%v: %v", l, err)
+		}
+		if fields[1].Offset != 16 || size != 16+2*48 {
+			t.Errorf("%v: bones at %d, size %d", l, fields[1].Offset, size)
+		}
+	}
+	buf, err := Marshal(Std430, v.Bones)
+	if err != nil || len(buf) != 96 || floatAt(buf, 48) != 1 {
+		t.Errorf("top-level slice: %d bytes, %v", len(buf), err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	type notLast struct {
+		A []float32
+		B float32
+	}
+	type nested struct {
+		In struct{ A []float32 }
+	}
+	type badType struct {
+		A float64
+	}
+	type overlap struct {
+		A vector4.Vector
+		B float32 `packing:"offset=8"`
+	}
+	type misaligned struct {
+		A float32
+		B vector3.Vector `packing:"offset=20"`
+	}
+	type badTag struct {
+		A float32 `packing:"align=4"`
+	}
+	cases := []struct {
+		name string
+		v    interface{}
+	}{
+		{"slice not last", notLast{}},
+		{"nested slice", nested{}},
+		{"float64", badType{}},
+		{"overlap", overlap{}},
+		{"misaligned", misaligned{}},
+		{"bad tag", badTag{}},
+		{"nil pointer", (*overlap)(nil)},
+		{"nil", nil},
+	}
+	for _, c := range cases {
+		if _, err := Marshal(Std430, c.v); err == nil {
+			t.Errorf("%s: no error", c.name)
+		}
+	}
+
+	type offset struct {
+		A      float32
+		B      vector3.Vector `packing:"offset=32"`
+		hidden int
+		Skip   float64 `packing:"-"`
+	}
+	fields, size, err := Fields(Std430, offset{})
+	if err != nil || len(fields) != 2 || fields[1].Offset != 32 || size != 48 {
+		t.Errorf("offset tag: %v %d %v", fields, size, err)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * 顺序写入  每次写入前按布局规则补齐对齐, 小端序
+ *   适合逐个写uniform块成员; 整个结构体用Marshal
+ */
+package packing
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/tinysss/smath/mat2"
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+	"github.com/tinysss/smath/vector4"
+)
+
+type Writer struct {
+	layout Layout
+	buf    []byte
+}
+
+func NewWriter(layout Layout) *Writer {
+	return &Writer{layout: layout}
+}
+
+func (t *Writer) Layout() Layout {
+	return t.layout
+}
+
+// 已写入的数据, 末尾不补齐
+func (t *Writer) Bytes() []byte {
+	return t.buf
+}
+
+func (t *Writer) Len() int {
+	return len(t.buf)
+}
+
+func (t *Writer) Reset() {
+	t.buf = t.buf[:0]
+}
+
+// 补0到align的整数倍
+func (t *Writer) Align(align int) *Writer {
+	n := roundUp(len(t.buf), align)
+	for len(t.buf) < n {
+		t.buf = append(t.buf, 0)
+	}
+	return t
+}
+
+// 按std140规则结束一个块, 总大小补齐到16 (其他布局不变)
+func (t *Writer) End() []byte {
+	if t.layout == Std140 {
+		t.Align(16)
+	}
+	return t.buf
+}
+
+func (t *Writer) Float32(f float32) *Writer {
+	return t.Align(4).putFloats(f)
+}
+
+func (t *Writer) Int32(i int32) *Writer {
+	return t.Uint32(uint32(i))
+}
+
+func (t *Writer) Uint32(u uint32) *Writer {
+	t.Align(4)
+	t.putUint32(u)
+	return t
+}
+
+// GLSL bool占4字节
+func (t *Writer) Bool(b bool) *Writer {
+	if b {
+		return t.Uint32(1)
+	}
+	return t.Uint32(0)
+}
+
+func (t *Writer) Vec2(v *vector2.Vector) *Writer {
+	return t.vec(v[:])
+}
+
+func (t *Writer) Vec3(v *vector3.Vector) *Writer {
+	return t.vec(v[:])
+}
+
+func (t *Writer) Vec4(v *vector4.Vector) *Writer {
+	return t.vec(v[:])
+}
+
+// 同vec4, (x,y,z,w)
+func (t *Writer) Quat(q *quat.Quaternion) *Writer {
+	return t.vec(q[:])
+}
+
+func (t *Writer) Mat2(m *mat2.Mat2) *Writer {
+	return t.mat(m.Slice(), 2, 2)
+}
+
+func (t *Writer) Mat3(m *mat3.Mat3) *Writer {
+	return t.mat(m.Slice(), 3, 3)
+}
+
+func (t *Writer) Mat4(m *mat4.Mat4) *Writer {
+	return t.mat(m.Slice(), 4, 4)
+}
+
+// 任意值, 规则同Marshal
+func (t *Writer) Write(v interface{}) error {
+	buf, align, err := marshal(t.layout, v)
+	if err != nil {
+		return err
+	}
+	t.Align(align)
+	t.buf = append(t.buf, buf...)
+	return nil
+}
+
+func (t *Writer) vec(v []float32) *Writer {
+	return t.Align(t.layout.VecAlign(len(v))).putFloats(v...)
+}
+
+// data按列存储
+func (t *Writer) mat(data []float32, cols, rows int) *Writer {
+	colAlign := t.layout.VecAlign(rows)
+	stride := t.layout.ArrayStride(rows*4, colAlign)
+	t.Align(t.layout.ArrayAlign(colAlign))
+	for col := 0; col < cols; col++ {
+		start := len(t.buf)
+		t.putFloats(data[col*rows : (col+1)*rows]...)
+		for len(t.buf) < start+stride {
+			t.buf = append(t.buf, 0)
+		}
+	}
+	return t
+}
+
+func (t *Writer) putFloats(fs ...float32) *Writer {
+	for _, f := range fs {
+		t.putUint32(math.Float32bits(f))
+	}
+	return t
+}
+
+func (t *Writer) putUint32(u uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], u)
+	t.buf = append(t.buf, b[:]...)
+}
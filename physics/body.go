@@ -0,0 +1,144 @@
+/*
+ * 刚体  位置为质心, 角速度为世界空间
+ *   InvMass为0表示静态/运动学物体, 不受力和冲量影响; 运动学物体按设定的速度移动
+ */
+package physics
+
+import (
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 运动状态, 积分器只改这部分
+type State struct {
+	Position        vector3.Vector
+	Orientation     quat.Quaternion
+	LinearVelocity  vector3.Vector
+	AngularVelocity vector3.Vector // 世界空间, 弧度/秒
+}
+
+type Body struct {
+	State
+	InvMass         float32
+	InvInertiaLocal mat3.Mat3 // 局部空间逆惯性张量
+
+	// 本步累加的力和力矩, 积分后清零
+	Force  vector3.Vector
+	Torque vector3.Vector
+}
+
+// mass<=0时为静态物体; inertia为局部空间惯性张量, 见BoxInertia等
+func NewBody(mass float32, inertia *mat3.Mat3) *Body {
+	l_body := &Body{State: State{Orientation: quat.Ident}}
+	if mass > 0 {
+		l_body.InvMass = 1 / mass
+		l_body.InvInertiaLocal = InvInertia(inertia)
+	}
+	return l_body
+}
+
+func (t *Body) IsStatic() bool {
+	return t.InvMass == 0
+}
+
+func (t *Body) Mass() float32 {
+	if t.InvMass == 0 {
+		return 0
+	}
+	return 1 / t.InvMass
+}
+
+// 世界空间逆惯性张量 R I^-1 R^T
+func (t *Body) InvInertiaWorld() mat3.Mat3 {
+	return RotatedInertia(&t.InvInertiaLocal, &t.Orientation)
+}
+
+// 局部点 -> 世界
+func (t *Body) LocalToWorld(p *vector3.Vector) vector3.Vector {
+	l_p := t.Orientation.RotatedVec3(p)
+	return *l_p.Add(&t.Position)
+}
+
+// 世界点 -> 局部
+func (t *Body) WorldToLocal(p *vector3.Vector) vector3.Vector {
+	l_p := vector3.Sub(p, &t.Position)
+	l_inv := t.Orientation.Conjugated()
+	return l_inv.RotatedVec3(&l_p)
+}
+
+// 世界点p处的速度 v + w x r
+func (t *Body) VelocityAtPoint(p *vector3.Vector) vector3.Vector {
+	r := vector3.Sub(p, &t.Position)
+	l_wr := vector3.Cross(&t.AngularVelocity, &r)
+	return vector3.Add(&t.LinearVelocity, &l_wr)
+}
+
+// 作用于质心的力
+func (t *Body) ApplyForce(f *vector3.Vector) {
+	t.Force.Add(f)
+}
+
+// 作用于世界点p的力, 同时产生力矩 r x f
+func (t *Body) ApplyForceAtPoint(f, p *vector3.Vector) {
+	t.Force.Add(f)
+	r := vector3.Sub(p, &t.Position)
+	l_tau := vector3.Cross(&r, f)
+	t.Torque.Add(&l_tau)
+}
+
+func (t *Body) ApplyTorque(tau *vector3.Vector) {
+	t.Torque.Add(tau)
+}
+
+func (t *Body) ClearForces() {
+	t.Force = vector3.Zero
+	t.Torque = vector3.Zero
+}
+
+// 作用于质心的冲量, 立即改变线速度
+func (t *Body) ApplyImpulse(j *vector3.Vector) {
+	l_dv := j.Scaled(t.InvMass)
+	t.LinearVelocity.Add(&l_dv)
+}
+
+// 作用于世界点p的冲量  dv = j/m, dw = I^-1 (r x j)
+func (t *Body) ApplyImpulseAtPoint(j, p *vector3.Vector) {
+	t.ApplyImpulse(j)
+	r := vector3.Sub(p, &t.Position)
+	l_angular := vector3.Cross(&r, j)
+	t.ApplyAngularImpulse(&l_angular)
+}
+
+func (t *Body) ApplyAngularImpulse(j *vector3.Vector) {
+	if t.InvMass == 0 {
+		return
+	}
+	l_invI := t.InvInertiaWorld()
+	l_dw := l_invI.MulVec3(j)
+	t.AngularVelocity.Add(&l_dw)
+}
+
+// 世界点p处沿单位方向n施加单位冲量时的有效质量的倒数
+// n·(1/m + (I^-1 (r x n)) x r)·n, 用于求解接触和约束
+func (t *Body) InvEffectiveMass(p, n *vector3.Vector) float32 {
+	if t.InvMass == 0 {
+		return 0
+	}
+	r := vector3.Sub(p, &t.Position)
+	rn := vector3.Cross(&r, n)
+	l_invI := t.InvInertiaWorld()
+	l_irn := l_invI.MulVec3(&rn)
+	return t.InvMass + vector3.Dot(&rn, &l_irn)
+}
+
+// 动能 0.5 m v^2 + 0.5 w·I w
+func (t *Body) KineticEnergy() float32 {
+	if t.InvMass == 0 {
+		return 0
+	}
+	l_inertia := InvInertia(&t.InvInertiaLocal)
+	l_worldI := RotatedInertia(&l_inertia, &t.Orientation)
+	l_iw := l_worldI.MulVec3(&t.AngularVelocity)
+	return 0.5*t.Mass()*t.LinearVelocity.LengthSqr() + 0.5*vector3.Dot(&t.AngularVelocity, &l_iw)
+}
@@ -0,0 +1,140 @@
+package physics
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestNewBody(t *testing.T) {
+	i := BoxInertia(2, &vector3.Vector{1, 1, 1})
+	tests := []struct {
+		name   string
+		mass   float32
+		static bool
+	}{
+		{"dynamic", 2, false},
+		{"zero mass", 0, true},
+		{"negative mass", -1, true},
+	}
+	for _, tt := range tests {
+		b := NewBody(tt.mass, &i)
+		if b.IsStatic() != tt.static {
+			t.Errorf("%s: IsStatic = %v, want %v", tt.name, b.IsStatic(), tt.static)
+		}
+		want := tt.mass
+		if tt.static {
+			want = 0
+		}
+		if !sutil.AlmostEqual(b.Mass(), want, 1e-6, 1e-6) {
+			t.Errorf("%s: Mass = %v, want %v", tt.name, b.Mass(), want)
+		}
+		if b.Orientation != quat.Ident {
+			t.Errorf("%s: Orientation = %v, want Ident", tt.name, b.Orientation)
+		}
+	}
+}
+
+func TestBodyFrames(t *testing.T) {
+	i := SphereInertia(1, 1)
+	b := NewBody(1, &i)
+	b.Position = vector3.Vector{1, 2, 3}
+	b.Orientation = quat.FromZAxisAngle(1.5707964)
+	p := vector3.Vector{1, 0, 0}
+	w := b.LocalToWorld(&p)
+	want := vector3.Vector{1, 3, 3}
+	if !w.ApproxEqual(&want, 1e-5) {
+		t.Errorf("LocalToWorld = %v, want %v", w, want)
+	}
+	if l := b.WorldToLocal(&w); !l.ApproxEqual(&p, 1e-5) {
+		t.Errorf("WorldToLocal = %v, want %v", l, p)
+	}
+
+	b.LinearVelocity = vector3.Vector{1, 0, 0}
+	b.AngularVelocity = vector3.Vector{0, 0, 2}
+	// v + w x r, r=(0,1,0)
+	q := vector3.Vector{1, 3, 3}
+	v := b.VelocityAtPoint(&q)
+	if want := (vector3.Vector{-1, 0, 0}); !v.ApproxEqual(&want, 1e-5) {
+		t.Errorf("VelocityAtPoint = %v, want %v", v, want)
+	}
+}
+
+func TestApplyForceAtPoint(t *testing.T) {
+	i := SphereInertia(1, 1)
+	b := NewBody(1, &i)
+	f := vector3.Vector{0, 0, 2}
+	p := vector3.Vector{0, 1, 0}
+	b.ApplyForceAtPoint(&f, &p)
+	b.ApplyForce(&f)
+	if want := (vector3.Vector{0, 0, 4}); b.Force != want {
+		t.Errorf("Force = %v, want %v", b.Force, want)
+	}
+	// r x f = (0,1,0) x (0,0,2)
+	if want := (vector3.Vector{2, 0, 0}); b.Torque != want {
+		t.Errorf("Torque = %v, want %v", b.Torque, want)
+	}
+	b.ClearForces()
+	if b.Force != vector3.Zero || b.Torque != vector3.Zero {
+		t.Errorf("ClearForces left %v %v", b.Force, b.Torque)
+	}
+}
+
+func TestApplyImpulseAtPoint(t *testing.T) {
+	i := BoxInertia(2, &vector3.Vector{1, 2, 3})
+	rot := quat.FromAxisAngle(&vector3.Vector{1, 2, 3}, 0.6)
+	tests := []struct {
+		name string
+		p, n vector3.Vector
+	}{
+		{"center", vector3.Vector{}, vector3.Vector{0, 0, 1}},
+		{"offset", vector3.Vector{0.3, 0.5, -0.2}, vector3.Vector{0, 0, 1}},
+		{"oblique", vector3.Vector{-0.4, 0.1, 0.7}, vector3.Vector{0.6, 0, 0.8}},
+	}
+	for _, tt := range tests {
+		b := NewBody(2, &i)
+		b.Orientation = rot
+		// 沿n的单位冲量使该点法向速度变化InvEffectiveMass
+		k := b.InvEffectiveMass(&tt.p, &tt.n)
+		v0 := b.VelocityAtPoint(&tt.p)
+		j := tt.n.Scaled(1.5)
+		b.ApplyImpulseAtPoint(&j, &tt.p)
+		v1 := b.VelocityAtPoint(&tt.p)
+		dv := vector3.Sub(&v1, &v0)
+		if got := vector3.Dot(&dv, &tt.n); !sutil.AlmostEqual(got, 1.5*k, 1e-5, 1e-4) {
+			t.Errorf("%s: normal dv = %v, want %v", tt.name, got, 1.5*k)
+		}
+	}
+}
+
+func TestStaticBodyIgnoresImpulse(t *testing.T) {
+	b := NewBody(0, &mat3.Zero)
+	j := vector3.Vector{1, 2, 3}
+	p := vector3.Vector{1, 0, 0}
+	b.ApplyImpulseAtPoint(&j, &p)
+	if b.LinearVelocity != vector3.Zero || b.AngularVelocity != vector3.Zero {
+		t.Errorf("static body moved: %v %v", b.LinearVelocity, b.AngularVelocity)
+	}
+	if k := b.InvEffectiveMass(&p, &vector3.UnitX); k != 0 {
+		t.Errorf("InvEffectiveMass = %v, want 0", k)
+	}
+	if e := b.KineticEnergy(); e != 0 {
+		t.Errorf("KineticEnergy = %v, want 0", e)
+	}
+}
+
+func TestKineticEnergy(t *testing.T) {
+	i := DiagInertia(1, 2, 3)
+	b := NewBody(4, &i)
+	b.Orientation = quat.FromAxisAngle(&vector3.Vector{0, 1, 1}, 1.1)
+	b.LinearVelocity = vector3.Vector{1, 0, 1}
+	// 局部Y轴上的角速度, 转动能 0.5*2*w^2
+	b.AngularVelocity = b.Orientation.RotatedVec3(&vector3.Vector{0, 3, 0})
+	want := float32(0.5*4*2 + 0.5*2*9)
+	if got := b.KineticEnergy(); !sutil.AlmostEqual(got, want, 1e-4, 1e-4) {
+		t.Errorf("KineticEnergy = %v, want %v", got, want)
+	}
+}
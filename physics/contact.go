@@ -0,0 +1,94 @@
+/*
+ * 接触约束  单个接触点, 一个流形有多个点时传多个Contact
+ *   法向冲量累计值>=0, 两个切向冲量各自限制在 ±Friction*法向冲量 (盒形摩擦锥)
+ */
+package physics
+
+import (
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+)
+
+type Contact struct {
+	A, B        *Body          // 静态物体用InvMass为0的Body
+	Point       vector3.Vector // 世界接触点
+	Normal      vector3.Vector // 单位法线, 从A指向B
+	Depth       float32        // 穿透深度, >0为穿透
+	Friction    float32        // 摩擦系数
+	Restitution float32        // 恢复系数 [0,1]
+	Key         uint64         // 跨帧标识同一接触(如物体id+特征id)用于热启动, 0为不热启动
+
+	// 累计冲量, 求解前为热启动值, 求解后为本步结果
+	NormalImpulse  float32
+	TangentImpulse [2]float32
+
+	rA, rB       vector3.Vector
+	invIA, invIB mat3.Mat3
+	tangents     [2]vector3.Vector
+	normalMass   float32
+	tangentMass  [2]float32
+	bias         float32
+}
+
+func (t *Contact) PreSolve(dt float32, s *Solver) {
+	a, b := t.A, t.B
+	t.rA = vector3.Sub(&t.Point, &a.Position)
+	t.rB = vector3.Sub(&t.Point, &b.Position)
+	t.invIA = a.InvInertiaWorld()
+	t.invIB = b.InvInertiaWorld()
+
+	// 切线基只由法线决定, 保证跨帧一致
+	t.tangents[0] = t.Normal.Normal()
+	t.tangents[1] = vector3.Cross(&t.Normal, &t.tangents[0])
+
+	t.normalMass = safeInv(invMassAlong(a, b, &t.invIA, &t.invIB, &t.rA, &t.rB, &t.Normal))
+	for i := range t.tangents {
+		t.tangentMass[i] = safeInv(invMassAlong(a, b, &t.invIA, &t.invIB, &t.rA, &t.rB, &t.tangents[i]))
+	}
+
+	// 目标分离速度: 反弹和穿透修正取大者
+	l_rel := relativeVelocity(a, b, &t.rA, &t.rB)
+	vn := vector3.Dot(&l_rel, &t.Normal)
+	t.bias = 0
+	if vn < -s.RestitutionThreshold {
+		t.bias = -t.Restitution * vn
+	}
+	if pen := s.Baumgarte / dt * math.Max(t.Depth-s.Slop, 0); pen > t.bias {
+		t.bias = pen
+	}
+
+	if !s.WarmStart {
+		t.NormalImpulse, t.TangentImpulse = 0, [2]float32{}
+		return
+	}
+	l_p := t.Normal.Scaled(t.NormalImpulse)
+	for i := range t.tangents {
+		l_tp := t.tangents[i].Scaled(t.TangentImpulse[i])
+		l_p.Add(&l_tp)
+	}
+	applyImpulsePair(a, b, &t.invIA, &t.invIB, &t.rA, &t.rB, &l_p)
+}
+
+func (t *Contact) SolveVelocity() {
+	a, b := t.A, t.B
+
+	// 先摩擦后法向, 法向约束更重要, 放在最后保证不穿透
+	l_maxF := t.Friction * t.NormalImpulse
+	for i := range t.tangents {
+		l_rel := relativeVelocity(a, b, &t.rA, &t.rB)
+		vt := vector3.Dot(&l_rel, &t.tangents[i])
+		l_old := t.TangentImpulse[i]
+		t.TangentImpulse[i] = sutil.Clamp(l_old-vt*t.tangentMass[i], -l_maxF, l_maxF)
+		l_p := t.tangents[i].Scaled(t.TangentImpulse[i] - l_old)
+		applyImpulsePair(a, b, &t.invIA, &t.invIB, &t.rA, &t.rB, &l_p)
+	}
+
+	l_rel := relativeVelocity(a, b, &t.rA, &t.rB)
+	vn := vector3.Dot(&l_rel, &t.Normal)
+	l_old := t.NormalImpulse
+	t.NormalImpulse = math.Max(l_old+t.normalMass*(t.bias-vn), 0)
+	l_p := t.Normal.Scaled(t.NormalImpulse - l_old)
+	applyImpulsePair(a, b, &t.invIA, &t.invIB, &t.rA, &t.rB, &l_p)
+}
@@ -0,0 +1,197 @@
+package physics
+
+import (
+	"testing"
+
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 盒子在y=0地面以下的角点生成接触
+func boxContacts(ground, box *Body, half *vector3.Vector, mu, e float32) []Contact {
+	var l_cs []Contact
+	for i := 0; i < 8; i++ {
+		c := *half
+		for a := 0; a < 3; a++ {
+			if i&(1<<a) != 0 {
+				c[a] = -c[a]
+			}
+		}
+		w := box.LocalToWorld(&c)
+		if w[1] < 0 {
+			l_cs = append(l_cs, Contact{
+				A: ground, B: box,
+				Point:    vector3.Vector{w[0], 0, w[2]},
+				Normal:   vector3.UnitY,
+				Depth:    -w[1],
+				Friction: mu, Restitution: e,
+				Key: uint64(i + 1),
+			})
+		}
+	}
+	return l_cs
+}
+
+// 每步回调观察盒子状态
+func runBox(s *Solver, steps int, y float32, v0 vector3.Vector, mu float32, fn func(box *Body)) *Body {
+	ground := NewBody(0, nil)
+	half := vector3.Vector{0.5, 0.25, 0.5}
+	size := half.Scaled(2)
+	i := BoxInertia(2, &size)
+	box := NewBody(2, &i)
+	box.Position = vector3.Vector{0, y, 0}
+	box.LinearVelocity = v0
+	for n := 0; n < steps; n++ {
+		cs := boxContacts(ground, box, &half, mu, 0)
+		s.Step(1.0/60, []*Body{ground, box}, cs)
+		if fn != nil {
+			fn(box)
+		}
+	}
+	return box
+}
+
+// 静止接触: 不下沉, 不弹起, 不转动
+func TestRestingContact(t *testing.T) {
+	tests := []struct {
+		name      string
+		y         float32
+		warmStart bool
+		iters     int
+	}{
+		{"on ground", 0.25, true, 10},
+		{"dropped", 0.3, true, 10},
+		{"penetrating", 0.2, true, 10},
+		{"no warm start", 0.25, false, 10},
+		{"one iteration", 0.25, true, 1},
+	}
+	for _, tt := range tests {
+		s := NewSolver()
+		s.WarmStart = tt.warmStart
+		s.Iterations = tt.iters
+		minY, maxY := float32(1), float32(0)
+		box := runBox(s, 300, tt.y, vector3.Vector{}, 0.5, func(box *Body) {
+			minY = math.Min(minY, box.Position[1])
+			maxY = math.Max(maxY, box.Position[1])
+		})
+		// 穿透收敛到Slop以内
+		want := 0.25 - s.Slop
+		if y := box.Position[1]; y < want-1e-3 || y > 0.25 {
+			t.Errorf("%s: y = %v, want in [%v, 0.25]", tt.name, y, want)
+		}
+		if minY < want-0.01 && tt.y >= 0.25 {
+			t.Errorf("%s: sank to %v", tt.name, minY)
+		}
+		if maxY > math.Max(tt.y, 0.25)+1e-3 {
+			t.Errorf("%s: bounced to %v", tt.name, maxY)
+		}
+		if box.LinearVelocity.Length() > 1e-3 || box.AngularVelocity.Length() > 1e-3 {
+			t.Errorf("%s: still moving %v %v", tt.name, box.LinearVelocity, box.AngularVelocity)
+		}
+		if x := box.Position[0]; x*x+box.Position[2]*box.Position[2] > 1e-6 {
+			t.Errorf("%s: drifted to %v", tt.name, box.Position)
+		}
+	}
+}
+
+// 摩擦: 滑行距离 v^2/(2 mu g)
+func TestSlidingFriction(t *testing.T) {
+	tests := []struct {
+		name string
+		v0   float32
+		mu   float32
+	}{
+		{"mu 0.5", 3, 0.5},
+		{"mu 1", 3, 1},
+	}
+	for _, tt := range tests {
+		s := NewSolver()
+		box := runBox(s, 120, 0.245, vector3.Vector{tt.v0, 0, 0}, tt.mu, nil)
+		// 减速只有十几步, 允许离散误差
+		want := tt.v0 * tt.v0 / (2 * tt.mu * 9.81)
+		if !sutil.AlmostEqual(box.Position[0], want, 0, 0.1) {
+			t.Errorf("%s: slid %v, want %v", tt.name, box.Position[0], want)
+		}
+		if box.LinearVelocity.Length() > 1e-3 {
+			t.Errorf("%s: still moving %v", tt.name, box.LinearVelocity)
+		}
+	}
+	// 无摩擦时速度不变
+	box := runBox(NewSolver(), 60, 0.245, vector3.Vector{3, 0, 0}, 0, nil)
+	if !sutil.AlmostEqual(box.LinearVelocity[0], 3, 1e-4, 0) {
+		t.Errorf("frictionless: vx = %v, want 3", box.LinearVelocity[0])
+	}
+}
+
+// 恢复系数e: 反弹高度约为下落高度的e^2
+func TestRestitution(t *testing.T) {
+	tests := []struct {
+		name string
+		e    float32
+	}{
+		{"e 0.8", 0.8},
+		{"e 0.5", 0.5},
+		{"e 0", 0},
+	}
+	for _, tt := range tests {
+		s := NewSolver()
+		ground := NewBody(0, nil)
+		i := SphereInertia(1, 0.5)
+		ball := NewBody(1, &i)
+		ball.Position = vector3.Vector{0, 3, 0}
+		var peak float32
+		touched := false
+		for n := 0; n < 240; n++ {
+			var cs []Contact
+			if ball.Position[1] < 0.5 {
+				cs = append(cs, Contact{
+					A: ground, B: ball,
+					Point:       vector3.Vector{ball.Position[0], 0, ball.Position[2]},
+					Normal:      vector3.UnitY,
+					Depth:       0.5 - ball.Position[1],
+					Restitution: tt.e,
+					Key:         1,
+				})
+				touched = true
+			}
+			s.Step(1.0/120, []*Body{ground, ball}, cs)
+			if touched {
+				peak = math.Max(peak, ball.Position[1])
+			}
+		}
+		want := 0.5 + 2.5*tt.e*tt.e
+		if !sutil.AlmostEqual(peak, want, 0.1, 0.05) {
+			t.Errorf("%s: peak = %v, want %v", tt.name, peak, want)
+		}
+	}
+}
+
+// 求解后Contact中的冲量为本步结果, 静止时法向冲量约等于 m g dt / 接触数
+func TestContactImpulse(t *testing.T) {
+	s := NewSolver()
+	ground := NewBody(0, nil)
+	half := vector3.Vector{0.5, 0.25, 0.5}
+	size := half.Scaled(2)
+	i := BoxInertia(2, &size)
+	box := NewBody(2, &i)
+	box.Position = vector3.Vector{0, 0.245, 0}
+	var cs []Contact
+	for n := 0; n < 60; n++ {
+		cs = boxContacts(ground, box, &half, 0.5, 0)
+		s.Step(1.0/60, []*Body{ground, box}, cs)
+	}
+	if len(cs) != 4 {
+		t.Fatalf("contacts = %d, want 4", len(cs))
+	}
+	var sum float32
+	for i := range cs {
+		if cs[i].NormalImpulse < 0 {
+			t.Errorf("contact %d: negative impulse %v", i, cs[i].NormalImpulse)
+		}
+		sum += cs[i].NormalImpulse
+	}
+	if want := float32(2 * 9.81 / 60); !sutil.AlmostEqual(sum, want, 1e-3, 1e-2) {
+		t.Errorf("total normal impulse = %v, want %v", sum, want)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * 惯性张量  均匀实心体, 相对质心, 局部坐标系
+ *   胶囊/圆柱的轴为局部Y轴
+ */
+package physics
+
+import (
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath"
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 对角惯性张量
+func DiagInertia(ix, iy, iz float32) mat3.Mat3 {
+	return mat3.Mat3{
+		{ix, 0, 0},
+		{0, iy, 0},
+		{0, 0, iz},
+	}
+}
+
+// 长方体, size为边长
+func BoxInertia(mass float32, size *vector3.Vector) mat3.Mat3 {
+	x2, y2, z2 := size[0]*size[0], size[1]*size[1], size[2]*size[2]
+	k := mass / 12
+	return DiagInertia(k*(y2+z2), k*(x2+z2), k*(x2+y2))
+}
+
+func SphereInertia(mass, radius float32) mat3.Mat3 {
+	i := 0.4 * mass * radius * radius
+	return DiagInertia(i, i, i)
+}
+
+// 圆柱, height为总高
+func CylinderInertia(mass, radius, height float32) mat3.Mat3 {
+	r2, h2 := radius*radius, height*height
+	iy := 0.5 * mass * r2
+	ixz := mass * (3*r2 + h2) / 12
+	return DiagInertia(ixz, iy, ixz)
+}
+
+// 胶囊, height为中间圆柱部分的高(不含两端半球)
+// 质量按体积分给圆柱和两个半球, 半球用平行轴定理移到质心
+func CapsuleInertia(mass, radius, height float32) mat3.Mat3 {
+	r2, h2 := radius*radius, height*height
+	vCyl := math.Pi * r2 * height
+	vSph := 4.0 / 3.0 * math.Pi * r2 * radius
+	mCyl := mass * vCyl / (vCyl + vSph)
+	mSph := mass - mCyl
+
+	iy := mCyl*r2*0.5 + mSph*r2*0.4
+	ixz := mCyl*(h2/12+r2/4) + mSph*(r2*0.4+h2/4+3*height*radius/8)
+	return DiagInertia(ixz, iy, ixz)
+}
+
+// 平行轴定理: 相对质心的惯性张量移到偏移offset的点
+// I' = I + m (|d|^2 E - d d^T)
+func ParallelAxis(inertia *mat3.Mat3, mass float32, offset *vector3.Vector) mat3.Mat3 {
+	d2 := vector3.Dot(offset, offset)
+	l_res := *inertia
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			v := -offset[col] * offset[row]
+			if col == row {
+				v += d2
+			}
+			l_res[col][row] += mass * v
+		}
+	}
+	return l_res
+}
+
+// 旋转到世界空间 R I R^T, 对逆惯性张量同样适用
+func RotatedInertia(inertia *mat3.Mat3, rot *quat.Quaternion) mat3.Mat3 {
+	r := smath.QuatToMat3(rot)
+	rt := r
+	rt.Transpose()
+	var l_tmp, l_res mat3.Mat3
+	l_tmp.AssignMul(&r, inertia)
+	l_res.AssignMul(&l_tmp, &rt)
+	return l_res
+}
+
+// 逆惯性张量, 奇异时(如质量为0)返回零矩阵
+// 不用mat3.Inv: 小物体的行列式很小, 会被当成0
+func InvInertia(inertia *mat3.Mat3) mat3.Mat3 {
+	return inv3(inertia)
+}
+
+// 3x3求逆, 只在行列式严格为0时返回零矩阵
+func inv3(m *mat3.Mat3) mat3.Mat3 {
+	det := m.Det()
+	if det == 0 {
+		return mat3.Zero
+	}
+	oo := 1 / det
+	return mat3.Mat3{
+		{
+			(m[1][1]*m[2][2] - m[2][1]*m[1][2]) * oo,
+			(m[2][1]*m[0][2] - m[0][1]*m[2][2]) * oo,
+			(m[0][1]*m[1][2] - m[1][1]*m[0][2]) * oo,
+		},
+		{
+			(m[2][0]*m[1][2] - m[1][0]*m[2][2]) * oo,
+			(m[0][0]*m[2][2] - m[2][0]*m[0][2]) * oo,
+			(m[1][0]*m[0][2] - m[0][0]*m[1][2]) * oo,
+		},
+		{
+			(m[1][0]*m[2][1] - m[2][0]*m[1][1]) * oo,
+			(m[2][0]*m[0][1] - m[0][0]*m[2][1]) * oo,
+			(m[0][0]*m[1][1] - m[1][0]*m[0][1]) * oo,
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package physics
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestInertia(t *testing.T) {
+	tests := []struct {
+		name string
+		got  mat3.Mat3
+		want mat3.Mat3
+	}{
+		{"box", BoxInertia(12, &vector3.Vector{1, 2, 3}), DiagInertia(13, 10, 5)},
+		{"sphere", SphereInertia(2, 0.5), DiagInertia(0.2, 0.2, 0.2)},
+		{"cylinder", CylinderInertia(2, 0.5, 1), DiagInertia(0.2916667, 0.25, 0.2916667)},
+		// 高为0的胶囊就是球
+		{"capsule sphere", CapsuleInertia(2, 0.5, 0), SphereInertia(2, 0.5)},
+		// 很细的胶囊接近细杆 m h^2/12
+		{"capsule rod", CapsuleInertia(2, 1e-3, 3), DiagInertia(1.5, 0, 1.5)},
+		{"parallel axis", func() mat3.Mat3 {
+			s := SphereInertia(1, 1)
+			return ParallelAxis(&s, 1, &vector3.Vector{0, 2, 0})
+		}(), DiagInertia(4.4, 0.4, 4.4)},
+	}
+	for _, tt := range tests {
+		if !tt.got.ApproxEqual(&tt.want, 1e-3) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestParallelAxisOffDiagonal(t *testing.T) {
+	var zero mat3.Mat3
+	got := ParallelAxis(&zero, 2, &vector3.Vector{1, 2, 0})
+	// m (|d|^2 E - d d^T)
+	want := mat3.Mat3{
+		{8, -4, 0},
+		{-4, 2, 0},
+		{0, 0, 10},
+	}
+	if !got.ApproxEqual(&want, 1e-5) {
+		t.Errorf("ParallelAxis = %v, want %v", got, want)
+	}
+}
+
+func TestRotatedInertia(t *testing.T) {
+	i := DiagInertia(1, 2, 3)
+	// 绕Z转90度, X和Y的主轴互换
+	rot := quat.FromZAxisAngle(1.5707964)
+	got := RotatedInertia(&i, &rot)
+	want := DiagInertia(2, 1, 3)
+	if !got.ApproxEqual(&want, 1e-5) {
+		t.Errorf("RotatedInertia = %v, want %v", got, want)
+	}
+	// 各向同性张量不受旋转影响
+	s := SphereInertia(1, 1)
+	rot = quat.FromAxisAngle(&vector3.Vector{1, 1, 1}, 0.7)
+	if got := RotatedInertia(&s, &rot); !got.ApproxEqual(&s, 1e-5) {
+		t.Errorf("RotatedInertia(sphere) = %v, want %v", got, s)
+	}
+}
+
+func TestInvInertia(t *testing.T) {
+	tests := []struct {
+		name string
+		in   mat3.Mat3
+	}{
+		{"box", BoxInertia(3, &vector3.Vector{1, 2, 3})},
+		// 行列式约1e-15, mat3.Inv会当成奇异
+		{"small sphere", SphereInertia(1, 0.01)},
+		{"off diagonal", mat3.Mat3{{2, 0.5, 0}, {0.5, 3, 0.1}, {0, 0.1, 1}}},
+	}
+	for _, tt := range tests {
+		inv := InvInertia(&tt.in)
+		var prod mat3.Mat3
+		prod.AssignMul(&tt.in, &inv)
+		if !prod.ApproxEqual(&mat3.Ident, 1e-4) {
+			t.Errorf("%s: I * InvInertia(I) = %v", tt.name, prod)
+		}
+	}
+	if got := InvInertia(&mat3.Zero); got != mat3.Zero {
+		t.Errorf("InvInertia(Zero) = %v, want Zero", got)
+	}
+}
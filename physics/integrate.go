@@ -0,0 +1,142 @@
+/*
+ * 积分器
+ *   半隐式欧拉: 先更新速度再用新速度更新位置, 稳定且便宜; 忽略陀螺力矩项
+ *   RK4: 四阶龙格库塔, 包含陀螺力矩 w x Iw, 力可随状态变化
+ *   朝向 dq/dt = 0.5 * (w,0) * q, 每步后归一化
+ */
+package physics
+
+import (
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 按状态求力和力矩(世界空间), 用于RK4在中间状态上重新求力, 如弹簧/阻尼
+type ForceFunc func(s *State) (force, torque vector3.Vector)
+
+// 半隐式欧拉, 使用本步累加的力, 积分后清零
+func (t *Body) IntegrateEuler(dt float32) {
+	t.integrateVelocity(dt, &vector3.Zero)
+	t.integratePosition(dt)
+}
+
+// 用累加的力和额外的加速度(如重力)更新速度, 然后清零累加的力
+func (t *Body) integrateVelocity(dt float32, acc *vector3.Vector) {
+	if t.InvMass == 0 {
+		t.ClearForces()
+		return
+	}
+	l_acc := t.Force.Scaled(t.InvMass)
+	l_acc.Add(acc)
+	l_acc.Scale(dt)
+	t.LinearVelocity.Add(&l_acc)
+	l_invI := t.InvInertiaWorld()
+	l_alpha := l_invI.MulVec3(&t.Torque)
+	l_alpha.Scale(dt)
+	t.AngularVelocity.Add(&l_alpha)
+	t.ClearForces()
+}
+
+// 用当前速度更新位置和朝向, 运动学物体也按设定的速度移动
+func (t *Body) integratePosition(dt float32) {
+	l_dx := t.LinearVelocity.Scaled(dt)
+	t.Position.Add(&l_dx)
+	t.Orientation = integrateOrientation(&t.Orientation, &t.AngularVelocity, dt)
+}
+
+// 四阶龙格库塔
+// fn为nil时使用本步累加的力(整步内视为常量); 积分后清零累加的力
+// 运动学物体速度不变, 只按当前速度更新位置和朝向
+func (t *Body) IntegrateRK4(dt float32, fn ForceFunc) {
+	if t.InvMass == 0 {
+		t.ClearForces()
+		t.integratePosition(dt)
+		return
+	}
+	if fn == nil {
+		f, tau := t.Force, t.Torque
+		fn = func(*State) (vector3.Vector, vector3.Vector) { return f, tau }
+	}
+	s0 := t.State
+	k1 := t.derivative(&s0, fn)
+	s1 := advance(&s0, &k1, dt/2)
+	k2 := t.derivative(&s1, fn)
+	s2 := advance(&s0, &k2, dt/2)
+	k3 := t.derivative(&s2, fn)
+	s3 := advance(&s0, &k3, dt)
+	k4 := t.derivative(&s3, fn)
+
+	// (k1 + 2k2 + 2k3 + k4) / 6
+	var l_sum derivative
+	for i, k := range [...]*derivative{&k1, &k2, &k3, &k4} {
+		w := float32(1)
+		if i == 1 || i == 2 {
+			w = 2
+		}
+		l_sum.add(k, w/6)
+	}
+	t.State = advance(&s0, &l_sum, dt)
+	t.ClearForces()
+}
+
+// 状态的时间导数
+type derivative struct {
+	dx vector3.Vector  // 速度
+	dq quat.Quaternion // 朝向导数
+	dv vector3.Vector  // 线加速度
+	dw vector3.Vector  // 角加速度
+}
+
+func (t *derivative) add(o *derivative, w float32) {
+	l_dx := o.dx.Scaled(w)
+	t.dx.Add(&l_dx)
+	t.dq.Add(o.dq.Scaled(w))
+	l_dv := o.dv.Scaled(w)
+	t.dv.Add(&l_dv)
+	l_dw := o.dw.Scaled(w)
+	t.dw.Add(&l_dw)
+}
+
+// 刚体在状态s下的导数, 角加速度按欧拉方程 I^-1 (tau - w x Iw)
+func (t *Body) derivative(s *State, fn ForceFunc) derivative {
+	force, torque := fn(s)
+	l_invI := RotatedInertia(&t.InvInertiaLocal, &s.Orientation)
+	l_inertia := InvInertia(&l_invI)
+	l_iw := l_inertia.MulVec3(&s.AngularVelocity)
+	l_gyro := vector3.Cross(&s.AngularVelocity, &l_iw)
+	torque.Sub(&l_gyro)
+
+	return derivative{
+		dx: s.LinearVelocity,
+		dq: orientationRate(&s.Orientation, &s.AngularVelocity),
+		dv: force.Scaled(t.InvMass),
+		dw: l_invI.MulVec3(&torque),
+	}
+}
+
+// s + d*dt, 朝向归一化
+func advance(s *State, d *derivative, dt float32) State {
+	l_res := *s
+	l_dx := d.dx.Scaled(dt)
+	l_res.Position.Add(&l_dx)
+	l_res.Orientation.Add(d.dq.Scaled(dt))
+	l_res.Orientation.Normalize()
+	l_dv := d.dv.Scaled(dt)
+	l_res.LinearVelocity.Add(&l_dv)
+	l_dw := d.dw.Scaled(dt)
+	l_res.AngularVelocity.Add(&l_dw)
+	return l_res
+}
+
+// dq/dt = 0.5 * (w,0) * q
+func orientationRate(q *quat.Quaternion, w *vector3.Vector) quat.Quaternion {
+	l_w := quat.Quaternion{w[0], w[1], w[2], 0}
+	l_dq := quat.Mul(&l_w, q)
+	return *l_dq.Scale(0.5)
+}
+
+func integrateOrientation(q *quat.Quaternion, w *vector3.Vector, dt float32) quat.Quaternion {
+	l_dq := orientationRate(q, w)
+	l_res := q.Added(l_dq.Scaled(dt))
+	return l_res.Normalized()
+}
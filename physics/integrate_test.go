@@ -0,0 +1,149 @@
+package physics
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 恒力下的位移, 两种积分器
+func TestIntegrateConstantForce(t *testing.T) {
+	i := BoxInertia(3, &vector3.Vector{1, 2, 3})
+	f := vector3.Vector{0, -30, 0}
+	tests := []struct {
+		name  string
+		step  func(b *Body)
+		wantY float32
+		tol   float32
+	}{
+		// x = 0.5 a t^2 = -5
+		{"rk4", func(b *Body) { b.IntegrateRK4(0.01, nil) }, -5, 1e-3},
+		// 半隐式欧拉 sum(k*dt)*a*dt = -5.05
+		{"euler", func(b *Body) { b.IntegrateEuler(0.01) }, -5.05, 1e-3},
+	}
+	for _, tt := range tests {
+		b := NewBody(3, &i)
+		for n := 0; n < 100; n++ {
+			b.ApplyForce(&f)
+			tt.step(b)
+		}
+		if !sutil.AlmostEqual(b.Position[1], tt.wantY, tt.tol, 0) {
+			t.Errorf("%s: y = %v, want %v", tt.name, b.Position[1], tt.wantY)
+		}
+		if !sutil.AlmostEqual(b.LinearVelocity[1], -10, 1e-3, 0) {
+			t.Errorf("%s: vy = %v, want -10", tt.name, b.LinearVelocity[1])
+		}
+		if b.Force != vector3.Zero {
+			t.Errorf("%s: forces not cleared: %v", tt.name, b.Force)
+		}
+	}
+}
+
+// 弹簧 F=-x 一个周期后回到起点
+func TestIntegrateRK4Spring(t *testing.T) {
+	i := SphereInertia(1, 1)
+	b := NewBody(1, &i)
+	b.Position = vector3.Vector{1, 0, 0}
+	for n := 0; n < 628; n++ {
+		b.IntegrateRK4(0.01, func(s *State) (vector3.Vector, vector3.Vector) {
+			return s.Position.Scaled(-1), vector3.Zero
+		})
+	}
+	want := vector3.Vector{1, 0, 0}
+	if !b.Position.ApproxEqual(&want, 1e-3) {
+		t.Errorf("Position = %v, want %v", b.Position, want)
+	}
+}
+
+// 无力矩时RK4保持角动量和动能
+func TestIntegrateRK4TorqueFree(t *testing.T) {
+	i := BoxInertia(3, &vector3.Vector{1, 2, 3})
+	b := NewBody(3, &i)
+	b.AngularVelocity = vector3.Vector{0.1, 3, 0.2}
+	momentum := func() vector3.Vector {
+		wi := RotatedInertia(&i, &b.Orientation)
+		return wi.MulVec3(&b.AngularVelocity)
+	}
+	l0, e0 := momentum(), b.KineticEnergy()
+	for n := 0; n < 1000; n++ {
+		b.IntegrateRK4(0.01, nil)
+	}
+	if l := momentum(); !l.ApproxEqual(&l0, 1e-2) {
+		t.Errorf("angular momentum %v, want %v", l, l0)
+	}
+	if e := b.KineticEnergy(); !sutil.AlmostEqual(e, e0, 0, 1e-3) {
+		t.Errorf("kinetic energy %v, want %v", e, e0)
+	}
+	if !b.Orientation.IsNormalQuat() {
+		t.Errorf("orientation not normalized: %v", b.Orientation)
+	}
+}
+
+// 匀速转动: 朝向与解析解一致
+func TestIntegrateOrientation(t *testing.T) {
+	i := SphereInertia(1, 1)
+	tests := []struct {
+		name string
+		step func(b *Body)
+	}{
+		{"euler", func(b *Body) { b.IntegrateEuler(0.001) }},
+		{"rk4", func(b *Body) { b.IntegrateRK4(0.001, nil) }},
+	}
+	axis := vector3.Vector{0, 1, 0}
+	want := quat.FromAxisAngle(&axis, 1)
+	for _, tt := range tests {
+		b := NewBody(1, &i)
+		b.AngularVelocity = axis
+		for n := 0; n < 1000; n++ {
+			tt.step(b)
+		}
+		if !b.Orientation.ApproxEqual(&want, 1e-3) {
+			t.Errorf("%s: Orientation = %v, want %v", tt.name, b.Orientation, want)
+		}
+	}
+}
+
+// 运动学物体不受力, 但按设定的速度移动
+func TestIntegrateKinematic(t *testing.T) {
+	tests := []struct {
+		name string
+		step func(b *Body)
+	}{
+		{"euler", func(b *Body) { b.IntegrateEuler(0.01) }},
+		{"rk4", func(b *Body) { b.IntegrateRK4(0.01, nil) }},
+		{"solver", func(b *Body) {
+			s := NewSolver()
+			s.Step(0.01, []*Body{b}, nil)
+		}},
+	}
+	v := vector3.Vector{1, 2, 0}
+	w := vector3.Vector{0, 0, 1}
+	wantPos := vector3.Vector{1, 2, 0}
+	wantRot := quat.FromAxisAngle(&w, 1)
+	for _, tt := range tests {
+		b := NewBody(0, &mat3.Zero)
+		b.LinearVelocity = v
+		b.AngularVelocity = w
+		f := vector3.Vector{0, -100, 0}
+		for n := 0; n < 100; n++ {
+			b.ApplyForce(&f)
+			b.ApplyTorque(&f)
+			tt.step(b)
+		}
+		if b.LinearVelocity != v || b.AngularVelocity != w {
+			t.Errorf("%s: velocity changed to %v %v", tt.name, b.LinearVelocity, b.AngularVelocity)
+		}
+		if !b.Position.ApproxEqual(&wantPos, 1e-4) {
+			t.Errorf("%s: Position = %v, want %v", tt.name, b.Position, wantPos)
+		}
+		if !b.Orientation.ApproxEqual(&wantRot, 1e-3) {
+			t.Errorf("%s: Orientation = %v, want %v", tt.name, b.Orientation, wantRot)
+		}
+		if b.Force != vector3.Zero || b.Torque != vector3.Zero {
+			t.Errorf("%s: forces not cleared", tt.name)
+		}
+	}
+}
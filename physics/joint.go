@@ -0,0 +1,281 @@
+/*
+ * 关节约束  锚点和轴存在各自物体的局部空间
+ *   球窝: 3行点约束, 有效质量为3x3矩阵 K = (1/mA+1/mB)E - [rA]IA^-1[rA] - [rB]IB^-1[rB]
+ *   铰链: 球窝 + 2行角约束(轴对齐) + 可选角度限制
+ *   距离: 两锚点距离保持不变
+ */
+package physics
+
+import (
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath/lie"
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/vector3"
+)
+
+//-------------------------------------------- 球窝 ------------------------------------------------
+
+type BallSocketJoint struct {
+	A, B         *Body
+	LocalAnchorA vector3.Vector
+	LocalAnchorB vector3.Vector
+
+	impulse      vector3.Vector // 累计冲量
+	rA, rB       vector3.Vector
+	invIA, invIB mat3.Mat3
+	mass         mat3.Mat3 // K^-1
+	bias         vector3.Vector
+}
+
+// anchor为世界空间锚点
+func NewBallSocketJoint(a, b *Body, anchor *vector3.Vector) *BallSocketJoint {
+	return &BallSocketJoint{
+		A:            a,
+		B:            b,
+		LocalAnchorA: a.WorldToLocal(anchor),
+		LocalAnchorB: b.WorldToLocal(anchor),
+	}
+}
+
+func (t *BallSocketJoint) PreSolve(dt float32, s *Solver) {
+	a, b := t.A, t.B
+	t.rA = a.Orientation.RotatedVec3(&t.LocalAnchorA)
+	t.rB = b.Orientation.RotatedVec3(&t.LocalAnchorB)
+	t.invIA = a.InvInertiaWorld()
+	t.invIB = b.InvInertiaWorld()
+
+	hA, hB := lie.Hat(&t.rA), lie.Hat(&t.rB)
+	var l_k, l_tmp mat3.Mat3
+	l_tmp.AssignMul(&hA, &t.invIA)
+	l_ka := mat3.Mul(&l_tmp, &hA)
+	l_tmp.AssignMul(&hB, &t.invIB)
+	l_kb := mat3.Mul(&l_tmp, &hB)
+	m := a.InvMass + b.InvMass
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			l_k[col][row] = -l_ka[col][row] - l_kb[col][row]
+		}
+		l_k[col][col] += m
+	}
+	t.mass = inv3(&l_k)
+
+	// C = pB - pA
+	l_pa := vector3.Add(&a.Position, &t.rA)
+	l_pb := vector3.Add(&b.Position, &t.rB)
+	t.bias = vector3.Sub(&l_pb, &l_pa)
+	t.bias.Scale(s.Baumgarte / dt)
+
+	if !s.WarmStart {
+		t.impulse = vector3.Zero
+		return
+	}
+	applyImpulsePair(a, b, &t.invIA, &t.invIB, &t.rA, &t.rB, &t.impulse)
+}
+
+func (t *BallSocketJoint) SolveVelocity() {
+	l_cdot := relativeVelocity(t.A, t.B, &t.rA, &t.rB)
+	l_cdot.Add(&t.bias)
+	l_lambda := t.mass.MulVec3(&l_cdot)
+	l_lambda.Scale(-1)
+	t.impulse.Add(&l_lambda)
+	applyImpulsePair(t.A, t.B, &t.invIA, &t.invIB, &t.rA, &t.rB, &l_lambda)
+}
+
+//-------------------------------------------- 铰链 ------------------------------------------------
+
+type HingeJoint struct {
+	A, B        *Body
+	LocalAxisA  vector3.Vector // 铰链轴
+	LocalAxisB  vector3.Vector
+	EnableLimit bool
+	Lower       float32 // 角度范围(弧度), B相对A绕轴转过的角度, 创建时为0
+	Upper       float32
+
+	point      BallSocketJoint
+	localRefA  vector3.Vector // 垂直于轴的参考方向, 求角度和角约束的基
+	localRefB  vector3.Vector
+	angImpulse [2]float32
+	limImpulse float32
+
+	axis       vector3.Vector
+	rows       [2]vector3.Vector
+	rowMass    [2]float32
+	rowBias    [2]float32
+	limitState int // 0无 1下限 2上限 3上下限相等
+	limitMass  float32
+	limitBias  float32
+}
+
+// anchor axis为世界空间, axis不要求归一化
+func NewHingeJoint(a, b *Body, anchor, axis *vector3.Vector) *HingeJoint {
+	l_axis := axis.Normalized()
+	l_ref := l_axis.Normal()
+	l_invA, l_invB := a.Orientation.Conjugated(), b.Orientation.Conjugated()
+	return &HingeJoint{
+		A:          a,
+		B:          b,
+		LocalAxisA: l_invA.RotatedVec3(&l_axis),
+		LocalAxisB: l_invB.RotatedVec3(&l_axis),
+		point:      *NewBallSocketJoint(a, b, anchor),
+		localRefA:  l_invA.RotatedVec3(&l_ref),
+		localRefB:  l_invB.RotatedVec3(&l_ref),
+	}
+}
+
+// B相对A绕轴的角度 (-pi,pi]
+func (t *HingeJoint) Angle() float32 {
+	a1 := t.A.Orientation.RotatedVec3(&t.LocalAxisA)
+	b1 := t.A.Orientation.RotatedVec3(&t.localRefA)
+	b2 := t.B.Orientation.RotatedVec3(&t.localRefB)
+	l_cross := vector3.Cross(&b1, &b2)
+	return math.Atan2(vector3.Dot(&l_cross, &a1), vector3.Dot(&b1, &b2))
+}
+
+func (t *HingeJoint) PreSolve(dt float32, s *Solver) {
+	t.point.A, t.point.B = t.A, t.B
+	t.point.PreSolve(dt, s)
+	invIA, invIB := &t.point.invIA, &t.point.invIB
+
+	// 约束 b1·a2 = 0, c1·a2 = 0, 其中b1 c1垂直于A的轴
+	a1 := t.A.Orientation.RotatedVec3(&t.LocalAxisA)
+	a2 := t.B.Orientation.RotatedVec3(&t.LocalAxisB)
+	b1 := t.A.Orientation.RotatedVec3(&t.localRefA)
+	c1 := vector3.Cross(&a1, &b1)
+	t.axis = a1
+	k := s.Baumgarte / dt
+	for i, p := range [...]*vector3.Vector{&b1, &c1} {
+		// d(p·a2)/dt = (wB - wA)·(a2 x p)
+		t.rows[i] = vector3.Cross(&a2, p)
+		t.rowMass[i] = safeInv(invMassAngular(invIA, invIB, &t.rows[i]))
+		t.rowBias[i] = k * vector3.Dot(p, &a2)
+	}
+
+	t.limitState = 0
+	if t.EnableLimit {
+		angle := t.Angle()
+		t.limitMass = safeInv(invMassAngular(invIA, invIB, &a1))
+		if t.Lower == t.Upper {
+			t.limitState = 3
+			t.limitBias = k * (angle - t.Lower)
+		} else if angle <= t.Lower {
+			t.limitState = 1
+			t.limitBias = k * (angle - t.Lower)
+		} else if angle >= t.Upper {
+			t.limitState = 2
+			t.limitBias = k * (angle - t.Upper)
+		}
+	}
+	// 限制状态变化时上一步的冲量方向可能已无效
+	switch t.limitState {
+	case 0:
+		t.limImpulse = 0
+	case 1:
+		t.limImpulse = math.Max(t.limImpulse, 0)
+	case 2:
+		t.limImpulse = math.Min(t.limImpulse, 0)
+	}
+
+	if !s.WarmStart {
+		t.angImpulse, t.limImpulse = [2]float32{}, 0
+		return
+	}
+	l_l := t.rows[0].Scaled(t.angImpulse[0])
+	l_r1 := t.rows[1].Scaled(t.angImpulse[1])
+	l_l.Add(&l_r1)
+	l_lim := a1.Scaled(t.limImpulse)
+	l_l.Add(&l_lim)
+	applyAngularPair(t.A, t.B, invIA, invIB, &l_l)
+}
+
+func (t *HingeJoint) SolveVelocity() {
+	invIA, invIB := &t.point.invIA, &t.point.invIB
+	if t.limitState != 0 {
+		l_dw := vector3.Sub(&t.B.AngularVelocity, &t.A.AngularVelocity)
+		l_cdot := vector3.Dot(&l_dw, &t.axis)
+		l_lambda := -t.limitMass * (l_cdot + t.limitBias)
+		l_old := t.limImpulse
+		t.limImpulse += l_lambda
+		if t.limitState == 1 {
+			t.limImpulse = math.Max(t.limImpulse, 0)
+		} else if t.limitState == 2 {
+			t.limImpulse = math.Min(t.limImpulse, 0)
+		}
+		l_l := t.axis.Scaled(t.limImpulse - l_old)
+		applyAngularPair(t.A, t.B, invIA, invIB, &l_l)
+	}
+
+	for i := range t.rows {
+		l_dw := vector3.Sub(&t.B.AngularVelocity, &t.A.AngularVelocity)
+		l_cdot := vector3.Dot(&l_dw, &t.rows[i])
+		l_lambda := -t.rowMass[i] * (l_cdot + t.rowBias[i])
+		t.angImpulse[i] += l_lambda
+		l_l := t.rows[i].Scaled(l_lambda)
+		applyAngularPair(t.A, t.B, invIA, invIB, &l_l)
+	}
+
+	t.point.SolveVelocity()
+}
+
+//-------------------------------------------- 距离 ------------------------------------------------
+
+type DistanceJoint struct {
+	A, B         *Body
+	LocalAnchorA vector3.Vector
+	LocalAnchorB vector3.Vector
+	Length       float32
+
+	impulse      float32
+	rA, rB       vector3.Vector
+	invIA, invIB mat3.Mat3
+	n            vector3.Vector
+	mass         float32
+	bias         float32
+}
+
+// 锚点为世界空间, Length取当前距离
+func NewDistanceJoint(a, b *Body, anchorA, anchorB *vector3.Vector) *DistanceJoint {
+	return &DistanceJoint{
+		A:            a,
+		B:            b,
+		LocalAnchorA: a.WorldToLocal(anchorA),
+		LocalAnchorB: b.WorldToLocal(anchorB),
+		Length:       vector3.Distance(anchorA, anchorB),
+	}
+}
+
+func (t *DistanceJoint) PreSolve(dt float32, s *Solver) {
+	a, b := t.A, t.B
+	t.rA = a.Orientation.RotatedVec3(&t.LocalAnchorA)
+	t.rB = b.Orientation.RotatedVec3(&t.LocalAnchorB)
+	t.invIA = a.InvInertiaWorld()
+	t.invIB = b.InvInertiaWorld()
+
+	l_pa := vector3.Add(&a.Position, &t.rA)
+	l_pb := vector3.Add(&b.Position, &t.rB)
+	t.n = vector3.Sub(&l_pb, &l_pa)
+	l := t.n.Length()
+	if l > 1e-6 {
+		t.n.Scale(1 / l)
+	} else {
+		// 锚点重合时方向任意
+		t.n = vector3.UnitY
+	}
+	t.mass = safeInv(invMassAlong(a, b, &t.invIA, &t.invIB, &t.rA, &t.rB, &t.n))
+	t.bias = s.Baumgarte / dt * (l - t.Length)
+
+	if !s.WarmStart {
+		t.impulse = 0
+		return
+	}
+	l_p := t.n.Scaled(t.impulse)
+	applyImpulsePair(a, b, &t.invIA, &t.invIB, &t.rA, &t.rB, &l_p)
+}
+
+func (t *DistanceJoint) SolveVelocity() {
+	l_rel := relativeVelocity(t.A, t.B, &t.rA, &t.rB)
+	l_cdot := vector3.Dot(&l_rel, &t.n)
+	l_lambda := -t.mass * (l_cdot + t.bias)
+	t.impulse += l_lambda
+	l_p := t.n.Scaled(l_lambda)
+	applyImpulsePair(t.A, t.B, &t.invIA, &t.invIB, &t.rA, &t.rB, &l_p)
+}
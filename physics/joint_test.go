@@ -0,0 +1,127 @@
+package physics
+
+import (
+	"testing"
+
+	math "github.com/barnex/fmath"
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 长时间运行后关节误差不累积
+func TestBallSocketDrift(t *testing.T) {
+	tests := []struct {
+		name  string
+		pos   vector3.Vector
+		vel   vector3.Vector
+		steps int
+	}{
+		{"pendulum", vector3.Vector{0.2, -2, 0}, vector3.Vector{}, 600},
+		{"horizontal", vector3.Vector{2, 0, 0}, vector3.Vector{}, 600},
+		{"conical", vector3.Vector{1, -1, 0}, vector3.Vector{0, 0, 3}, 1200},
+	}
+	for _, tt := range tests {
+		s := NewSolver()
+		anchor := NewBody(0, nil)
+		i := SphereInertia(1, 0.5)
+		bob := NewBody(1, &i)
+		bob.Position = tt.pos
+		bob.LinearVelocity = tt.vel
+		j := NewBallSocketJoint(anchor, bob, &vector3.Zero)
+		s.AddJoint(j)
+		var maxErr, err float32
+		for n := 0; n < tt.steps; n++ {
+			s.Step(1.0/120, []*Body{anchor, bob}, nil)
+			p := bob.LocalToWorld(&j.LocalAnchorB)
+			err = p.Length()
+			maxErr = math.Max(maxErr, err)
+		}
+		// 单步误差由Baumgarte修正, 不随步数累积 (匀速圆周时有约1e-3的稳态误差)
+		if maxErr > 5e-3 || err > 2e-3 {
+			t.Errorf("%s: anchor error max %v, final %v", tt.name, maxErr, err)
+		}
+		// 摆锤不飞出
+		if l := bob.Position.Length(); l > tt.pos.Length()+1e-2 {
+			t.Errorf("%s: bob at distance %v, want %v", tt.name, l, tt.pos.Length())
+		}
+	}
+}
+
+func TestDistanceJointDrift(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  vector3.Vector
+		vel  vector3.Vector
+	}{
+		{"hanging", vector3.Vector{0, -1.5, 0}, vector3.Vector{}},
+		{"orbit", vector3.Vector{1.5, 0, 0}, vector3.Vector{0, 0, 2}},
+		{"swing", vector3.Vector{1, -1, 0}, vector3.Vector{0, 0, 4}},
+	}
+	for _, tt := range tests {
+		s := NewSolver()
+		a := NewBody(0, nil)
+		i := SphereInertia(1, 0.5)
+		b := NewBody(1, &i)
+		b.Position = tt.pos
+		b.LinearVelocity = tt.vel
+		j := NewDistanceJoint(a, b, &vector3.Zero, &b.Position)
+		s.AddJoint(j)
+		var maxErr float32
+		for n := 0; n < 1200; n++ {
+			s.Step(1.0/120, []*Body{a, b}, nil)
+			p := b.LocalToWorld(&j.LocalAnchorB)
+			maxErr = math.Max(maxErr, math.Abs(p.Length()-j.Length))
+		}
+		if maxErr > 1e-2 {
+			t.Errorf("%s: length error %v, Length %v", tt.name, maxErr, j.Length)
+		}
+	}
+}
+
+// 门绕Y轴转动, 受角度限制; 有偏离轴的力矩时轴仍保持对齐
+func TestHingeJoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		torque       vector3.Vector
+		limit        bool
+		lower, upper float32
+		wantAngle    float32
+	}{
+		{"upper limit", vector3.Vector{0.5, 20, 0.3}, true, -0.5, 0.5, 0.5},
+		{"lower limit", vector3.Vector{-0.5, -20, 0.3}, true, -0.5, 0.5, -0.5},
+		{"locked", vector3.Vector{0, 20, 0}, true, 0.2, 0.2, 0.2},
+		{"free", vector3.Vector{0, 0, 5}, false, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		s := NewSolver()
+		s.Gravity = vector3.Zero
+		frame := NewBody(0, nil)
+		size := vector3.Vector{1, 2, 0.1}
+		i := BoxInertia(5, &size)
+		door := NewBody(5, &i)
+		door.Position = vector3.Vector{0.5, 0, 0}
+		h := NewHingeJoint(frame, door, &vector3.Zero, &vector3.UnitY)
+		h.EnableLimit, h.Lower, h.Upper = tt.limit, tt.lower, tt.upper
+		s.AddJoint(h)
+		var maxAxisErr, maxAnchorErr, anchorErr float32
+		for n := 0; n < 300; n++ {
+			door.ApplyTorque(&tt.torque)
+			s.Step(1.0/60, []*Body{frame, door}, nil)
+			ax := door.Orientation.RotatedVec3(&vector3.UnitY)
+			maxAxisErr = math.Max(maxAxisErr, 1-ax[1])
+			p := door.LocalToWorld(&h.point.LocalAnchorB)
+			anchorErr = p.Length()
+			maxAnchorErr = math.Max(maxAnchorErr, anchorErr)
+		}
+		if !sutil.AlmostEqual(h.Angle(), tt.wantAngle, 1e-2, 0) {
+			t.Errorf("%s: Angle = %v, want %v", tt.name, h.Angle(), tt.wantAngle)
+		}
+		if maxAxisErr > 1e-3 {
+			t.Errorf("%s: axis drifted %v", tt.name, maxAxisErr)
+		}
+		// 撞到限位时锚点有短暂误差, 之后收敛
+		if maxAnchorErr > 5e-3 || anchorErr > 1e-3 {
+			t.Errorf("%s: anchor error max %v, final %v", tt.name, maxAnchorErr, anchorErr)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+/*
+ * 顺序冲量约束求解器
+ *   每步: 积分速度(力+重力) -> 约束预处理(热启动) -> 迭代求解速度 -> 积分位置
+ *   位置误差用Baumgarte稳定: 在速度约束里加 beta/dt * C 的偏置
+ *   确定性: 先关节(按添加顺序)后接触(按传入顺序), 固定迭代次数, 不依赖map遍历顺序;
+ *   相同输入在同一平台上结果逐位相同 (不同架构的浮点融合乘加可能造成差异)
+ */
+package physics
+
+import (
+	"github.com/tinysss/smath/mat3"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 速度约束
+type Constraint interface {
+	// 每步调用一次: 计算有效质量和偏置; s.WarmStart为true时施加上一步的累计冲量, 否则清零
+	PreSolve(dt float32, s *Solver)
+	// 每次迭代调用一次
+	SolveVelocity()
+}
+
+type Solver struct {
+	Iterations           int            // 速度迭代次数
+	Baumgarte            float32        // 位置误差修正系数 [0,1], 常用0.1~0.3
+	Slop                 float32        // 允许的穿透深度, 避免接触抖动
+	RestitutionThreshold float32        // 相对法向速度低于此值时不反弹
+	WarmStart            bool           // 用上一步的冲量作为初值
+	Gravity              vector3.Vector // 重力加速度, 只作用于动态物体
+
+	joints []Constraint
+	cache  map[uint64]contactImpulse // 上一步接触冲量, 按Contact.Key
+}
+
+func NewSolver() *Solver {
+	return &Solver{
+		Iterations:           10,
+		Baumgarte:            0.2,
+		Slop:                 0.005,
+		RestitutionThreshold: 1,
+		WarmStart:            true,
+		Gravity:              vector3.Vector{0, -9.81, 0},
+		cache:                make(map[uint64]contactImpulse),
+	}
+}
+
+// 关节等常驻约束, 按添加顺序求解
+func (t *Solver) AddJoint(c Constraint) {
+	t.joints = append(t.joints, c)
+}
+
+func (t *Solver) RemoveJoint(c Constraint) {
+	for i, j := range t.joints {
+		if j == c {
+			t.joints = append(t.joints[:i], t.joints[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *Solver) Joints() []Constraint {
+	return t.joints
+}
+
+// 推进一步
+// contacts为本步碰撞检测的结果, 求解后其中的冲量字段为本步结果
+func (t *Solver) Step(dt float32, bodies []*Body, contacts []Contact) {
+	if dt <= 0 {
+		return
+	}
+	for _, b := range bodies {
+		b.integrateVelocity(dt, &t.Gravity)
+	}
+
+	for _, j := range t.joints {
+		j.PreSolve(dt, t)
+	}
+	for i := range contacts {
+		c := &contacts[i]
+		if imp, ok := t.cache[c.Key]; ok && c.Key != 0 {
+			c.NormalImpulse, c.TangentImpulse = imp.normal, imp.tangent
+		} else {
+			c.NormalImpulse, c.TangentImpulse = 0, [2]float32{}
+		}
+		c.PreSolve(dt, t)
+	}
+
+	for it := 0; it < t.Iterations; it++ {
+		for _, j := range t.joints {
+			j.SolveVelocity()
+		}
+		for i := range contacts {
+			contacts[i].SolveVelocity()
+		}
+	}
+
+	// 只保留本步出现的接触
+	l_cache := make(map[uint64]contactImpulse, len(contacts))
+	for i := range contacts {
+		if c := &contacts[i]; c.Key != 0 {
+			l_cache[c.Key] = contactImpulse{c.NormalImpulse, c.TangentImpulse}
+		}
+	}
+	t.cache = l_cache
+
+	for _, b := range bodies {
+		b.integratePosition(dt)
+	}
+}
+
+type contactImpulse struct {
+	normal  float32
+	tangent [2]float32
+}
+
+//-------------------------------------------- 公共 ------------------------------------------------
+
+// 两物体在各自力臂处的相对速度 (vB + wB x rB) - (vA + wA x rA)
+func relativeVelocity(a, b *Body, rA, rB *vector3.Vector) vector3.Vector {
+	l_wa := vector3.Cross(&a.AngularVelocity, rA)
+	l_wb := vector3.Cross(&b.AngularVelocity, rB)
+	l_va := vector3.Add(&a.LinearVelocity, &l_wa)
+	l_vb := vector3.Add(&b.LinearVelocity, &l_wb)
+	return vector3.Sub(&l_vb, &l_va)
+}
+
+// 对A施加-p, 对B施加+p, 作用点分别在rA rB
+func applyImpulsePair(a, b *Body, invIA, invIB *mat3.Mat3, rA, rB, p *vector3.Vector) {
+	l_pa := p.Scaled(a.InvMass)
+	a.LinearVelocity.Sub(&l_pa)
+	l_ta := vector3.Cross(rA, p)
+	l_wa := invIA.MulVec3(&l_ta)
+	a.AngularVelocity.Sub(&l_wa)
+
+	l_pb := p.Scaled(b.InvMass)
+	b.LinearVelocity.Add(&l_pb)
+	l_tb := vector3.Cross(rB, p)
+	l_wb := invIB.MulVec3(&l_tb)
+	b.AngularVelocity.Add(&l_wb)
+}
+
+// 对A施加角冲量-l, 对B施加+l
+func applyAngularPair(a, b *Body, invIA, invIB *mat3.Mat3, l *vector3.Vector) {
+	l_wa := invIA.MulVec3(l)
+	a.AngularVelocity.Sub(&l_wa)
+	l_wb := invIB.MulVec3(l)
+	b.AngularVelocity.Add(&l_wb)
+}
+
+// 沿方向n(作用点rA rB)的有效质量倒数 1/mA + 1/mB + (rA x n)·IA^-1(rA x n) + (rB x n)·IB^-1(rB x n)
+func invMassAlong(a, b *Body, invIA, invIB *mat3.Mat3, rA, rB, n *vector3.Vector) float32 {
+	l_ran := vector3.Cross(rA, n)
+	l_rbn := vector3.Cross(rB, n)
+	l_ia := invIA.MulVec3(&l_ran)
+	l_ib := invIB.MulVec3(&l_rbn)
+	return a.InvMass + b.InvMass + vector3.Dot(&l_ran, &l_ia) + vector3.Dot(&l_rbn, &l_ib)
+}
+
+// 只有角速度的行 (wB - wA)·u 的有效质量倒数
+func invMassAngular(invIA, invIB *mat3.Mat3, u *vector3.Vector) float32 {
+	l_ia := invIA.MulVec3(u)
+	l_ib := invIB.MulVec3(u)
+	return vector3.Dot(u, &l_ia) + vector3.Dot(u, &l_ib)
+}
+
+func safeInv(k float32) float32 {
+	if k == 0 {
+		return 0
+	}
+	return 1 / k
+}
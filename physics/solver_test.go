@@ -0,0 +1,97 @@
+package physics
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestSolverJoints(t *testing.T) {
+	a, b := NewBody(0, nil), NewBody(0, nil)
+	s := NewSolver()
+	j1 := NewBallSocketJoint(a, b, &vector3.Zero)
+	j2 := NewDistanceJoint(a, b, &vector3.Zero, &vector3.UnitX)
+	j3 := NewHingeJoint(a, b, &vector3.Zero, &vector3.UnitY)
+	s.AddJoint(j1)
+	s.AddJoint(j2)
+	s.AddJoint(j3)
+	tests := []struct {
+		remove Constraint
+		want   []Constraint
+	}{
+		{j2, []Constraint{j1, j3}},
+		// 不存在的约束忽略
+		{j2, []Constraint{j1, j3}},
+		{j1, []Constraint{j3}},
+		{j3, []Constraint{}},
+	}
+	for _, tt := range tests {
+		s.RemoveJoint(tt.remove)
+		got := s.Joints()
+		if len(got) != len(tt.want) {
+			t.Fatalf("Joints = %v, want %v", got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Joints[%d] = %v, want %v", i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestSolverStep(t *testing.T) {
+	tests := []struct {
+		name  string
+		dt    float32
+		wantY float32
+		wantV float32
+	}{
+		{"zero dt", 0, 0, 0},
+		{"negative dt", -1, 0, 0},
+		// 半隐式欧拉: 先加速再移动
+		{"one step", 0.1, -0.0981, -0.981},
+	}
+	for _, tt := range tests {
+		s := NewSolver()
+		i := SphereInertia(1, 1)
+		b := NewBody(1, &i)
+		ground := NewBody(0, nil)
+		s.Step(tt.dt, []*Body{ground, b}, nil)
+		if !b.Position.ApproxEqual(&vector3.Vector{0, tt.wantY, 0}, 1e-6) {
+			t.Errorf("%s: y = %v, want %v", tt.name, b.Position[1], tt.wantY)
+		}
+		if !b.LinearVelocity.ApproxEqual(&vector3.Vector{0, tt.wantV, 0}, 1e-6) {
+			t.Errorf("%s: v = %v, want %v", tt.name, b.LinearVelocity[1], tt.wantV)
+		}
+		// 重力不作用于静态物体
+		if ground.LinearVelocity != vector3.Zero || ground.Position != vector3.Zero {
+			t.Errorf("%s: static body moved", tt.name)
+		}
+	}
+}
+
+// 同样的输入得到逐位相同的结果
+func TestSolverDeterminism(t *testing.T) {
+	run := func() (*Body, *Body) {
+		s := NewSolver()
+		box := runBox(s, 200, 0.4, vector3.Vector{1, 0, 0.5}, 0.5, nil)
+		anchor := NewBody(0, nil)
+		i := SphereInertia(1, 0.5)
+		bob := NewBody(1, &i)
+		bob.Position = vector3.Vector{1, -1, 0}
+		bob.LinearVelocity = vector3.Vector{0, 0, 3}
+		s.AddJoint(NewBallSocketJoint(anchor, bob, &vector3.Zero))
+		for n := 0; n < 200; n++ {
+			s.Step(1.0/120, []*Body{anchor, bob}, nil)
+		}
+		return box, bob
+	}
+	box1, bob1 := run()
+	box2, bob2 := run()
+	if box1.State != box2.State {
+		t.Errorf("box: %v != %v", box1.State, box2.State)
+	}
+	if bob1.State != bob2.State {
+		t.Errorf("bob: %v != %v", bob1.State, bob2.State)
+	}
+}
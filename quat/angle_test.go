@@ -0,0 +1,49 @@
+package quat
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestAxisAngleOf(t *testing.T) {
+	axis := vector3.Vector{0, 0, 1}
+	cases := []struct {
+		name      string
+		got, want Quaternion
+	}{
+		{"deg", FromAxisAngleOf(&axis, sutil.Degrees(90)), FromAxisAngle(&axis, sutil.KPiOver2)},
+		{"rad", FromAxisAngleOf(&axis, sutil.Radians(1)), FromAxisAngle(&axis, 1)},
+		{"x", FromXAxisAngleOf(sutil.Degrees(30)), FromXAxisAngle(sutil.KPi / 6)},
+		{"y", FromYAxisAngleOf(sutil.Degrees(-60)), FromYAxisAngle(-sutil.KPi / 3)},
+		{"z", FromZAxisAngleOf(sutil.Degrees(45)), FromZAxisAngle(sutil.KPi / 4)},
+	}
+	for _, c := range cases {
+		if !c.got.ApproxEqual(&c.want, 1e-6) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	q := FromAxisAngleOf(&axis, sutil.Degrees(90))
+	v := q.RotatedVec3(&vector3.Vector{1, 0, 0})
+	if want := (vector3.Vector{0, 1, 0}); !v.ApproxEqual(&want, 1e-6) {
+		t.Errorf("rotate x by 90deg around z = %v", v)
+	}
+	if _, a := q.AxisRadians(); !sutil.AlmostEqual(float32(a.Deg()), 90, 1e-3, 0) {
+		t.Errorf("AxisRadians = %v deg, want 90", a.Deg())
+	}
+}
+
+func TestEulerOf(t *testing.T) {
+	q := FromEulerAnglesOf(sutil.Degrees(30), sutil.Degrees(20), sutil.Degrees(-10))
+	h, p, b := q.ToEulerRadians()
+	got := [3]float32{float32(h.Deg()), float32(p.Deg()), float32(b.Deg())}
+	want := [3]float32{30, 20, -10}
+	for i := range got {
+		if !sutil.AlmostEqual(got[i], want[i], 1e-3, 0) {
+			t.Errorf("euler round trip = %v, want %v", got, want)
+			break
+		}
+	}
+}
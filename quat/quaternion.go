@@ -71,6 +71,17 @@ func (t *Quaternion) IsNormalQuat() bool {
 	return math.Abs(t.Norm()-1) <= 0.0001
 }
 
+// 各分量在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual)
+// 注意q与-q表示同一旋转, 这里不认为相等
+func (t *Quaternion) ApproxEqual(o *Quaternion, tol float32) bool {
+	for i := range t {
+		if !sutil.AlmostEqual(t[i], o[i], tol, tol) {
+			return false
+		}
+	}
+	return true
+}
+
 // 返回 绕axis旋转angle的四元数
 func FromAxisAngle(axis *vector3.Vector, angle float32) Quaternion {
 	axisnor := axis.Normalized()
@@ -92,6 +103,11 @@ func NewFromAxisAngle(axis *vector3.Vector, angle float32) *Quaternion {
 	return &l_quat
 }
 
+// 带单位的版本, 如 FromAxisAngleOf(&axis, sutil.Degrees(90))
+func FromAxisAngleOf(axis *vector3.Vector, angle sutil.Angle) Quaternion {
+	return FromAxisAngle(axis, float32(angle.Rad()))
+}
+
 // 返回 绕x轴选装angle的四元数
 func FromXAxisAngle(angle float32) Quaternion {
 	angle *= 0.5
@@ -121,6 +137,18 @@ func FromZAxisAngle(angle float32) Quaternion {
 	// return l_quat.Normalized()
 }
 
+func FromXAxisAngleOf(angle sutil.Angle) Quaternion {
+	return FromXAxisAngle(float32(angle.Rad()))
+}
+
+func FromYAxisAngleOf(angle sutil.Angle) Quaternion {
+	return FromYAxisAngle(float32(angle.Rad()))
+}
+
+func FromZAxisAngleOf(angle sutil.Angle) Quaternion {
+	return FromZAxisAngle(float32(angle.Rad()))
+}
+
 // 返回 欧拉角构造的四元数  (使用限制角)
 func FromEulerAngles(yHead, xPitch, zBank float32) Quaternion {
 	xPitch, yHead, zBank = sutil.CanonizeEuler(xPitch, yHead, zBank)
@@ -153,6 +181,10 @@ func NewFromEulerAngles(yHead, xPitch, zBank float32) *Quaternion {
 	return &l_quat
 }
 
+func FromEulerAnglesOf(yHead, xPitch, zBank sutil.Angle) Quaternion {
+	return FromEulerAngles(float32(yHead.Rad()), float32(xPitch.Rad()), float32(zBank.Rad()))
+}
+
 func FromVec4(v *vector4.Vector) Quaternion {
 	return Quaternion(*v)
 }
@@ -184,6 +216,12 @@ func (t *Quaternion) ToEulerAngles() (yHead, xPitch, zBank float32) {
 	return
 }
 
+// 提取欧拉角, 返回弧度类型
+func (t *Quaternion) ToEulerRadians() (yHead, xPitch, zBank sutil.Radians) {
+	h, p, b := t.ToEulerAngles()
+	return sutil.Radians(h), sutil.Radians(p), sutil.Radians(b)
+}
+
 // 提取轴角
 func (t *Quaternion) AxisAngle() (axis vector3.Vector, angle float32) {
 	angle = math.Acos(t[3]) * 2
@@ -200,6 +238,12 @@ func (t *Quaternion) AxisAngle() (axis vector3.Vector, angle float32) {
 	return
 }
 
+// 提取轴角, 返回弧度类型
+func (t *Quaternion) AxisRadians() (axis vector3.Vector, angle sutil.Radians) {
+	axis, a := t.AxisAngle()
+	return axis, sutil.Radians(a)
+}
+
 // 共轭
 func (t *Quaternion) Conjugate() *Quaternion {
 	t[0] = -t[0]
@@ -379,11 +423,34 @@ func DiffQuat(a, b *Quaternion) Quaternion {
 	return d.Normalized()
 }
 
+// 摆动-扭转分解 t = swing * twist
+// twist为绕axis的旋转, swing的旋转轴与axis垂直; t需为单位四元数
+func (t *Quaternion) SwingTwist(axis *vector3.Vector) (swing, twist Quaternion) {
+	n := axis.Normalized()
+	p := t[0]*n[0] + t[1]*n[1] + t[2]*n[2]
+	twist = Quaternion{n[0] * p, n[1] * p, n[2] * p, t[3]}
+	if twist.Norm() < 1e-12 {
+		// 绕axis正交方向转了180度, 扭转部分为0
+		return *t, Ident
+	}
+	twist.Normalize()
+	l_inv := twist.Conjugated()
+	swing = Mul(t, &l_inv)
+	return swing, twist
+}
+
+// from旋转到to的最短旋转
+// 两者反向时旋转轴不唯一, 取与from正交的任意轴转180度
 func FromToQuat(from, to vector3.Vector) Quaternion {
 	from.Normalize()
 	to.Normalize()
+	d := vector3.Dot(&from, &to)
+	if d < -1+1e-6 {
+		axis := from.Normal()
+		return Quaternion{axis[0], axis[1], axis[2], 0}
+	}
 	cr := vector3.Cross(&from, &to)
-	sr := math.Sqrt(2 * (1 + vector3.Dot(&from, &to)))
+	sr := math.Sqrt(2 * (1 + d))
 	oosr := 1 / sr
 
 	q := Quaternion{cr[0] * oosr, cr[1] * oosr, cr[2] * oosr, sr * 0.5}
@@ -400,8 +467,9 @@ func Clamp(a, low, high float32) float32 {
 	return a
 }
 
+// a + (b-a)*t
 func Lerp(a, b *Quaternion, t float32) *Quaternion {
-	l_res := a.Added(b.Subed((*a).Scaled(t)))
+	l_res := a.Added(b.Subed(*a).Scaled(t))
 	return &l_res
 }
 
@@ -0,0 +1,101 @@
+package quat
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestFromToQuat(t *testing.T) {
+	cases := []struct {
+		name     string
+		from, to vector3.Vector
+	}{
+		{"same", vector3.Vector{1, 0, 0}, vector3.Vector{2, 0, 0}},
+		{"right angle", vector3.Vector{1, 0, 0}, vector3.Vector{0, 3, 0}},
+		{"general", vector3.Vector{1, 2, -1}, vector3.Vector{-0.5, 0.2, 4}},
+		// 反向时取任意正交轴转180度
+		{"opposite x", vector3.Vector{1, 0, 0}, vector3.Vector{-1, 0, 0}},
+		{"opposite", vector3.Vector{1, 2, -1}, vector3.Vector{-2, -4, 2}},
+	}
+	for _, c := range cases {
+		q := FromToQuat(c.from, c.to)
+		if !q.IsNormalQuat() {
+			t.Errorf("%s: FromToQuat = %v, not unit", c.name, q)
+		}
+		got := q.RotatedVec3(&c.from)
+		got.Normalize()
+		want := c.to.Normalized()
+		if !got.ApproxEqual(&want, 1e-5) {
+			t.Errorf("%s: FromToQuat rotates %v to %v, want %v", c.name, c.from, got, want)
+		}
+	}
+}
+
+func TestSwingTwist(t *testing.T) {
+	y := vector3.Vector{0, 1, 0}
+	tilt := vector3.Vector{1, 0, 1}
+	cases := []struct {
+		name       string
+		q          Quaternion
+		twistAngle float32
+		swingAngle float32
+	}{
+		{"pure twist", FromYAxisAngle(0.7), 0.7, 0},
+		{"pure swing", FromAxisAngle(&tilt, 0.5), 0, 0.5},
+		{"both", func() Quaternion {
+			s, tw := FromAxisAngle(&tilt, 0.5), FromYAxisAngle(-1.2)
+			return Mul(&s, &tw)
+		}(), -1.2, 0.5},
+		// 绕正交轴180度, 扭转为0
+		{"half turn", FromXAxisAngle(3.14159265), 0, 3.14159265},
+	}
+	for _, c := range cases {
+		swing, twist := c.q.SwingTwist(&y)
+		back := Mul(&swing, &twist)
+		if !back.ApproxEqual(&c.q, 1e-5) {
+			t.Errorf("%s: swing*twist = %v, want %v", c.name, back, c.q)
+		}
+		want := FromYAxisAngle(c.twistAngle)
+		neg := twist.Scaled(-1)
+		if !twist.ApproxEqual(&want, 1e-5) && !neg.ApproxEqual(&want, 1e-5) {
+			t.Errorf("%s: twist = %v, want %v", c.name, twist, want)
+		}
+		// swing轴与y垂直
+		if d := swing[1]; d > 1e-5 || d < -1e-5 {
+			t.Errorf("%s: swing %v has a y component", c.name, swing)
+		}
+		// acos在1附近不精确, 放宽误差
+		if _, a := swing.AxisAngle(); a-c.swingAngle > 2e-3 || a-c.swingAngle < -2e-3 {
+			t.Errorf("%s: swing angle = %v, want %v", c.name, a, c.swingAngle)
+		}
+	}
+}
+
+func TestLerp(t *testing.T) {
+	a, b := FromYAxisAngle(0.2), FromYAxisAngle(1.4)
+	for _, f := range []float32{0, 0.25, 0.5, 1} {
+		got := *Lerp(&a, &b, f)
+		var want Quaternion
+		for i := range want {
+			want[i] = a[i] + (b[i]-a[i])*f
+		}
+		if !got.ApproxEqual(&want, 1e-6) {
+			t.Errorf("Lerp(%v) = %v, want %v", f, got, want)
+		}
+	}
+
+	// 夹角很小时Slerp走NLerp
+	c := FromYAxisAngle(0.21)
+	for _, f := range []float32{0.25, 0.5, 0.75} {
+		got := Slerp(&a, &c, f)
+		want := FromYAxisAngle(0.2 + 0.01*f)
+		if !got.ApproxEqual(&want, 1e-5) {
+			t.Errorf("Slerp(%v) = %v, want %v", f, got, want)
+		}
+	}
+	mid := *NLerp(&a, &b, 0.5)
+	if want := FromYAxisAngle(0.8); !mid.ApproxEqual(&want, 1e-5) {
+		t.Errorf("NLerp(0.5) = %v, want %v", mid, want)
+	}
+}
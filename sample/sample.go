@@ -0,0 +1,189 @@
+/*
+ * 随机采样  由调用方传入*rand.Rand, 相同种子结果可复现
+ */
+package sample
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+)
+
+// 单位圆上均匀分布的点
+func UnitCircle(r *rand.Rand) vector2.Vector {
+	s, c := math.Sincos(r.Float64() * 2 * math.Pi)
+	return vector2.Vector{float32(c), float32(s)}
+}
+
+// 单位球面上均匀分布的点  z在[-1,1]均匀分布时球面上均匀(阿基米德)
+func UnitSphere(r *rand.Rand) vector3.Vector {
+	z := r.Float64()*2 - 1
+	s, c := math.Sincos(r.Float64() * 2 * math.Pi)
+	rxy := math.Sqrt(1 - z*z)
+	return vector3.Vector{float32(rxy * c), float32(rxy * s), float32(z)}
+}
+
+// 单位圆盘内均匀分布的点
+func InUnitDisk(r *rand.Rand) vector2.Vector {
+	v := UnitCircle(r)
+	return *v.Scale(float32(math.Sqrt(r.Float64())))
+}
+
+// 圆内均匀分布的点
+func InCircle(r *rand.Rand, c *vector2.Circle) vector2.Vector {
+	v := InUnitDisk(r)
+	v.Scale(c.Radius)
+	return *v.Add(&c.Center)
+}
+
+// rect内均匀分布的点
+func InRect(r *rand.Rand, rect *vector2.Rect) vector2.Vector {
+	return vector2.Vector{
+		rect.Min[0] + r.Float32()*(rect.Max[0]-rect.Min[0]),
+		rect.Min[1] + r.Float32()*(rect.Max[1]-rect.Min[1]),
+	}
+}
+
+// box内均匀分布的点
+func InBox(r *rand.Rand, b *vector3.Box) vector3.Vector {
+	return vector3.Vector{
+		b.Min[0] + r.Float32()*(b.Max[0]-b.Min[0]),
+		b.Min[1] + r.Float32()*(b.Max[1]-b.Min[1]),
+		b.Min[2] + r.Float32()*(b.Max[2]-b.Min[2]),
+	}
+}
+
+// 球内均匀分布的点
+func InSphere(r *rand.Rand, s *vector3.Sphere) vector3.Vector {
+	v := UnitSphere(r)
+	v.Scale(s.Radius * float32(math.Cbrt(r.Float64())))
+	return *v.Add(&s.Center)
+}
+
+// 三角形内均匀分布的点  落在另一半平行四边形时翻折回来
+func InTriangle(r *rand.Rand, tri *vector3.Triangle) vector3.Vector {
+	u := r.Float32()
+	v := r.Float32()
+	if u+v > 1 {
+		u = 1 - u
+		v = 1 - v
+	}
+	ab := vector3.Sub(&tri.B, &tri.A)
+	ac := vector3.Sub(&tri.C, &tri.A)
+	res := vector3.Add(&tri.A, ab.Scale(u))
+	return *res.Add(ac.Scale(v))
+}
+
+// 均匀分布的随机旋转 (Shoemake, Uniform random rotations, Graphics Gems III)
+func Rotation(r *rand.Rand) quat.Quaternion {
+	u1 := r.Float64()
+	s2, c2 := math.Sincos(r.Float64() * 2 * math.Pi)
+	s3, c3 := math.Sincos(r.Float64() * 2 * math.Pi)
+	r1 := math.Sqrt(1 - u1)
+	r2 := math.Sqrt(u1)
+	return quat.Quaternion{float32(r1 * s2), float32(r1 * c2), float32(r2 * s3), float32(r2 * c3)}
+}
+
+// 以dir为轴, 半角halfAngle(弧度)的圆锥内均匀分布的单位方向
+func InCone(r *rand.Rand, dir *vector3.Vector, halfAngle float32) vector3.Vector {
+	// 球冠上z在[cos,1]均匀分布
+	cosMax := math.Cos(float64(halfAngle))
+	z := 1 - r.Float64()*(1-cosMax)
+	s, c := math.Sincos(r.Float64() * 2 * math.Pi)
+	rxy := math.Sqrt(math.Max(0, 1-z*z))
+
+	w := dir.Normalized()
+	u := w.Normal()
+	v := vector3.Cross(&w, &u)
+
+	res := w.Scaled(float32(z))
+	u.Scale(float32(rxy * c))
+	v.Scale(float32(rxy * s))
+	res.Add(&u)
+	return *res.Add(&v)
+}
+
+// rect内的泊松圆盘采样, 任意两点距离不小于minDist
+// k为每个活跃点的尝试次数, <=0时取30  (Bridson, Fast Poisson Disk Sampling in Arbitrary Dimensions)
+func PoissonDisk(r *rand.Rand, rect *vector2.Rect, minDist float32, k int) []vector2.Vector {
+	if k <= 0 {
+		k = 30
+	}
+	size := vector2.Sub(&rect.Max, &rect.Min)
+	if minDist <= 0 || size[0] <= 0 || size[1] <= 0 {
+		return nil
+	}
+
+	// 网格边长 r/sqrt(2), 每格最多一个点
+	cell := minDist / float32(math.Sqrt2)
+	cols := int(math.Ceil(float64(size[0] / cell)))
+	rows := int(math.Ceil(float64(size[1] / cell)))
+	grid := make([]int, cols*rows)
+	for i := range grid {
+		grid[i] = -1
+	}
+	cellOf := func(p *vector2.Vector) (int, int) {
+		cx := int((p[0] - rect.Min[0]) / cell)
+		cy := int((p[1] - rect.Min[1]) / cell)
+		if cx >= cols {
+			cx = cols - 1
+		}
+		if cy >= rows {
+			cy = rows - 1
+		}
+		return cx, cy
+	}
+
+	var points []vector2.Vector
+	var active []int
+	add := func(p vector2.Vector) {
+		cx, cy := cellOf(&p)
+		grid[cy*cols+cx] = len(points)
+		active = append(active, len(points))
+		points = append(points, p)
+	}
+	farEnough := func(p *vector2.Vector) bool {
+		cx, cy := cellOf(p)
+		for y := cy - 2; y <= cy+2; y++ {
+			for x := cx - 2; x <= cx+2; x++ {
+				if x < 0 || y < 0 || x >= cols || y >= rows {
+					continue
+				}
+				if i := grid[y*cols+x]; i >= 0 {
+					d := vector2.Sub(p, &points[i])
+					if d.LengthSqr() < minDist*minDist {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}
+
+	add(InRect(r, rect))
+	for len(active) > 0 {
+		ai := r.Intn(len(active))
+		center := points[active[ai]]
+		found := false
+		for i := 0; i < k; i++ {
+			// [r,2r]圆环内随机点
+			dir := UnitCircle(r)
+			dist := minDist * (1 + r.Float32())
+			p := vector2.Add(&center, dir.Scale(dist))
+			if !rect.ContainsPoint(&p) || !farEnough(&p) {
+				continue
+			}
+			add(p)
+			found = true
+			break
+		}
+		if !found {
+			active[ai] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+	return points
+}
@@ -0,0 +1,150 @@
+package sample
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tinysss/smath/vector2"
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestReproducible(t *testing.T) {
+	rect := vector2.Rect{Max: vector2.Vector{5, 5}}
+	a := PoissonDisk(rand.New(rand.NewSource(7)), &rect, 0.5, 0)
+	b := PoissonDisk(rand.New(rand.NewSource(7)), &rect, 0.5, 0)
+	if len(a) != len(b) {
+		t.Fatalf("len %d != %d", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("point %d: %v != %v", i, a[i], b[i])
+		}
+	}
+	qa := Rotation(rand.New(rand.NewSource(3)))
+	qb := Rotation(rand.New(rand.NewSource(3)))
+	if qa != qb {
+		t.Errorf("Rotation %v != %v", qa, qb)
+	}
+}
+
+func TestDomains(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tri := vector3.Triangle{A: vector3.Vector{0, 0, 0}, B: vector3.Vector{2, 0, 0}, C: vector3.Vector{0, 2, 0}}
+	sphere := vector3.Sphere{Center: vector3.Vector{1, 2, 3}, Radius: 2}
+	circle := vector2.Circle{Center: vector2.Vector{-1, 1}, Radius: 0.5}
+	box := vector3.Box{Min: vector3.Vector{-1, 0, 2}, Max: vector3.Vector{1, 1, 3}}
+	coneDir := vector3.Vector{0, 0, 2}
+	cases := []struct {
+		name string
+		ok   func() bool
+	}{
+		{"UnitCircle", func() bool { v := UnitCircle(r); return approx(v.Length(), 1) }},
+		{"UnitSphere", func() bool { v := UnitSphere(r); return approx(v.Length(), 1) }},
+		{"InUnitDisk", func() bool { v := InUnitDisk(r); return v.Length() <= 1 }},
+		{"InCircle", func() bool { v := InCircle(r, &circle); return circle.ContainsPoint(&v) }},
+		{"InBox", func() bool { v := InBox(r, &box); return box.ContainsPoint(&v) }},
+		{"InSphere", func() bool { v := InSphere(r, &sphere); return vector3.Distance(&v, &sphere.Center) <= 2+1e-5 }},
+		{"InTriangle", func() bool {
+			v := InTriangle(r, &tri)
+			return v[0] >= 0 && v[1] >= 0 && v[0]+v[1] <= 2+1e-6 && v[2] == 0
+		}},
+		{"Rotation", func() bool { q := Rotation(r); return q.IsNormalQuat() }},
+		{"InCone", func() bool {
+			v := InCone(r, &coneDir, 0.3)
+			return approx(v.Length(), 1) && float64(v[2]) >= math.Cos(0.3)-1e-6
+		}},
+	}
+	for _, c := range cases {
+		for i := 0; i < 1000; i++ {
+			if !c.ok() {
+				t.Errorf("%s: sample %d out of domain", c.name, i)
+				break
+			}
+		}
+	}
+}
+
+func TestUniform(t *testing.T) {
+	// 均值应接近区域中心
+	const n = 20000
+	r := rand.New(rand.NewSource(2))
+	var disk vector2.Vector
+	var sphere, tri vector3.Vector
+	triangle := vector3.Triangle{A: vector3.Vector{0, 0, 0}, B: vector3.Vector{3, 0, 0}, C: vector3.Vector{0, 3, 0}}
+	var diskR float64
+	for i := 0; i < n; i++ {
+		d := InUnitDisk(r)
+		disk.Add(&d)
+		diskR += float64(d.Length())
+		s := UnitSphere(r)
+		sphere.Add(&s)
+		p := InTriangle(r, &triangle)
+		tri.Add(&p)
+	}
+	disk.Scale(1.0 / n)
+	sphere.Scale(1.0 / n)
+	tri.Scale(1.0 / n)
+	if disk.Length() > 0.02 || sphere.Length() > 0.02 {
+		t.Errorf("mean disk %v sphere %v, want ~0", disk, sphere)
+	}
+	// 圆盘内均匀分布时 E|v| = 2/3
+	if m := diskR / n; math.Abs(m-2.0/3) > 0.01 {
+		t.Errorf("disk mean radius %v, want 2/3", m)
+	}
+	if !tri.ApproxEqual(&vector3.Vector{1, 1, 0}, 0.03) {
+		t.Errorf("triangle mean %v, want (1,1,0)", tri)
+	}
+}
+
+func TestPoissonDisk(t *testing.T) {
+	cases := []struct {
+		rect    vector2.Rect
+		minDist float32
+	}{
+		{vector2.Rect{Max: vector2.Vector{10, 10}}, 1},
+		{vector2.Rect{Min: vector2.Vector{-3, 2}, Max: vector2.Vector{4, 3}}, 0.25},
+	}
+	for ci, c := range cases {
+		pts := PoissonDisk(rand.New(rand.NewSource(int64(ci))), &c.rect, c.minDist, 0)
+		for i := range pts {
+			if !c.rect.ContainsPoint(&pts[i]) {
+				t.Errorf("case %d: %v outside rect", ci, pts[i])
+			}
+			for j := i + 1; j < len(pts); j++ {
+				if d := dist2(&pts[i], &pts[j]); d < c.minDist {
+					t.Fatalf("case %d: points %d,%d only %v apart", ci, i, j, d)
+				}
+			}
+		}
+		// 采样是饱和的: rect内任意一点到最近采样点不超过2*minDist
+		for y := c.rect.Min[1]; y <= c.rect.Max[1]; y += c.minDist / 2 {
+			for x := c.rect.Min[0]; x <= c.rect.Max[0]; x += c.minDist / 2 {
+				p := vector2.Vector{x, y}
+				best := float32(math.MaxFloat32)
+				for i := range pts {
+					if d := dist2(&p, &pts[i]); d < best {
+						best = d
+					}
+				}
+				if best > 2*c.minDist {
+					t.Errorf("case %d: gap at %v (nearest %v)", ci, p, best)
+				}
+			}
+		}
+	}
+
+	if pts := PoissonDisk(rand.New(rand.NewSource(0)), &vector2.Rect{}, 1, 0); pts != nil {
+		t.Errorf("empty rect: got %d points", len(pts))
+	}
+}
+
+func approx(a, b float32) bool {
+	d := a - b
+	return d <= 1e-5 && d >= -1e-5
+}
+
+func dist2(a, b *vector2.Vector) float32 {
+	d := vector2.Sub(a, b)
+	return d.Length()
+}
@@ -0,0 +1,280 @@
+/*
+ * 场景节点  局部TRS + 懒计算的世界矩阵
+ *   改局部变换或父节点时标脏, 脏标记沿子树向下传播, 取世界矩阵时才重算
+ *   不是并发安全的: 读取世界矩阵也可能写缓存
+ */
+package scene
+
+import (
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/transform"
+	"github.com/tinysss/smath/vector3"
+)
+
+type Node struct {
+	Name string
+
+	local    transform.Transform
+	parent   *Node
+	children []*Node
+
+	localMat   mat4.Mat4
+	worldMat   mat4.Mat4
+	localDirty bool
+	worldDirty bool
+}
+
+func NewNode(name string) *Node {
+	return &Node{
+		Name:     name,
+		local:    transform.Ident,
+		localMat: mat4.Ident,
+		worldMat: mat4.Ident,
+	}
+}
+
+//-------------------------------------------- 局部变换 ------------------------------------------------
+
+func (t *Node) Local() transform.Transform {
+	return t.local
+}
+
+func (t *Node) SetLocal(tr *transform.Transform) *Node {
+	t.local = *tr
+	t.markLocalDirty()
+	return t
+}
+
+func (t *Node) Position() vector3.Vector {
+	return t.local.Translation
+}
+
+func (t *Node) SetPosition(p *vector3.Vector) *Node {
+	t.local.Translation = *p
+	t.markLocalDirty()
+	return t
+}
+
+func (t *Node) Rotation() quat.Quaternion {
+	return t.local.Rotation
+}
+
+func (t *Node) SetRotation(q *quat.Quaternion) *Node {
+	t.local.Rotation = *q
+	t.markLocalDirty()
+	return t
+}
+
+func (t *Node) Scale() vector3.Vector {
+	return t.local.Scale
+}
+
+func (t *Node) SetScale(s *vector3.Vector) *Node {
+	t.local.Scale = *s
+	t.markLocalDirty()
+	return t
+}
+
+// 在父空间中平移
+func (t *Node) Translate(d *vector3.Vector) *Node {
+	t.local.Translation.Add(d)
+	t.markLocalDirty()
+	return t
+}
+
+// 在父空间中旋转 (左乘)
+func (t *Node) Rotate(q *quat.Quaternion) *Node {
+	t.local.Rotation = quat.Mul(q, &t.local.Rotation)
+	t.local.Rotation.Normalize()
+	t.markLocalDirty()
+	return t
+}
+
+// 局部 -> 父空间
+func (t *Node) LocalMatrix() mat4.Mat4 {
+	if t.localDirty {
+		t.localMat = t.local.Mat4()
+		t.localDirty = false
+	}
+	return t.localMat
+}
+
+//-------------------------------------------- 世界变换 ------------------------------------------------
+
+// 局部 -> 世界
+func (t *Node) WorldMatrix() mat4.Mat4 {
+	if t.worldDirty {
+		l_local := t.LocalMatrix()
+		if t.parent == nil {
+			t.worldMat = l_local
+		} else {
+			l_parent := t.parent.WorldMatrix()
+			t.worldMat.AssignMul(&l_parent, &l_local)
+		}
+		t.worldDirty = false
+	}
+	return t.worldMat
+}
+
+func (t *Node) WorldPosition() vector3.Vector {
+	l_world := t.WorldMatrix()
+	return vector3.Vector{l_world[3][0], l_world[3][1], l_world[3][2]}
+}
+
+// 沿父链累乘旋转, 父节点有非均匀缩放时只是近似
+func (t *Node) WorldRotation() quat.Quaternion {
+	if t.parent == nil {
+		return t.local.Rotation
+	}
+	l_parent := t.parent.WorldRotation()
+	l_rot := quat.Mul(&l_parent, &t.local.Rotation)
+	return l_rot.Normalized()
+}
+
+// 设置世界位置, 换算到父空间
+func (t *Node) SetWorldPosition(p *vector3.Vector) *Node {
+	l_local := *p
+	if t.parent != nil {
+		l_local = t.parent.InverseTransformPoint(p)
+	}
+	return t.SetPosition(&l_local)
+}
+
+// 设置世界旋转, 换算到父空间
+func (t *Node) SetWorldRotation(q *quat.Quaternion) *Node {
+	l_local := *q
+	if t.parent != nil {
+		l_parentInv := t.parent.WorldRotation()
+		l_parentInv.Conjugate()
+		l_local = quat.Mul(&l_parentInv, q)
+	}
+	return t.SetRotation(&l_local)
+}
+
+// 局部点 -> 世界
+func (t *Node) TransformPoint(p *vector3.Vector) vector3.Vector {
+	l_world := t.WorldMatrix()
+	return l_world.MulVec3W(p, 1)
+}
+
+// 局部方向 -> 世界, 受旋转和缩放影响, 不受平移影响
+func (t *Node) TransformDir(d *vector3.Vector) vector3.Vector {
+	l_world := t.WorldMatrix()
+	return l_world.MulVec3W(d, 0)
+}
+
+// 世界点 -> 局部
+func (t *Node) InverseTransformPoint(p *vector3.Vector) vector3.Vector {
+	l_inv := t.WorldMatrix()
+	l_inv.Inv()
+	return l_inv.MulVec3W(p, 1)
+}
+
+// 世界方向 -> 局部
+func (t *Node) InverseTransformDir(d *vector3.Vector) vector3.Vector {
+	l_inv := t.WorldMatrix()
+	l_inv.Inv()
+	return l_inv.MulVec3W(d, 0)
+}
+
+//-------------------------------------------- 层级 ------------------------------------------------
+
+func (t *Node) Parent() *Node {
+	return t.parent
+}
+
+// 子节点列表, 调用方不要修改
+func (t *Node) Children() []*Node {
+	return t.children
+}
+
+// 根节点
+func (t *Node) Root() *Node {
+	r := t
+	for r.parent != nil {
+		r = r.parent
+	}
+	return r
+}
+
+// t是否为n的祖先
+func (t *Node) IsAncestorOf(n *Node) bool {
+	for p := n.parent; p != nil; p = p.parent {
+		if p == t {
+			return true
+		}
+	}
+	return false
+}
+
+// 挂到parent下 (nil为脱离父节点)
+// keepWorld为true时重算局部变换使世界变换不变; 有非均匀缩放加旋转时切变部分会丢失
+// parent为自身或后代时panic
+func (t *Node) SetParent(parent *Node, keepWorld bool) *Node {
+	if parent == t.parent {
+		return t
+	}
+	if parent == t || (parent != nil && t.IsAncestorOf(parent)) {
+		panic("scene: cannot parent a node to itself or its descendant")
+	}
+	var l_world mat4.Mat4
+	if keepWorld {
+		l_world = t.WorldMatrix()
+	}
+
+	if t.parent != nil {
+		t.parent.removeChild(t)
+	}
+	t.parent = parent
+	if parent != nil {
+		parent.children = append(parent.children, t)
+	}
+
+	if keepWorld {
+		l_local := l_world
+		if parent != nil {
+			l_parentInv := parent.WorldMatrix()
+			l_parentInv.Inv()
+			l_local.AssignMul(&l_parentInv, &l_world)
+		}
+		t.local = transform.FromMat4(&l_local)
+		t.markLocalDirty()
+	} else {
+		t.markWorldDirty()
+	}
+	return t
+}
+
+// 等同 child.SetParent(t, false)
+func (t *Node) AddChild(child *Node) *Node {
+	child.SetParent(t, false)
+	return t
+}
+
+func (t *Node) removeChild(child *Node) {
+	for i, c := range t.children {
+		if c == child {
+			copy(t.children[i:], t.children[i+1:])
+			t.children[len(t.children)-1] = nil
+			t.children = t.children[:len(t.children)-1]
+			return
+		}
+	}
+}
+
+func (t *Node) markLocalDirty() {
+	t.localDirty = true
+	t.markWorldDirty()
+}
+
+// 已脏的子树不用再往下走: 节点脏时其后代一定都脏
+func (t *Node) markWorldDirty() {
+	if t.worldDirty {
+		return
+	}
+	t.worldDirty = true
+	for _, c := range t.children {
+		c.markWorldDirty()
+	}
+}
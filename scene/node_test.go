@@ -0,0 +1,145 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/transform"
+	"github.com/tinysss/smath/vector3"
+)
+
+// root(1,0,0) -> a(0,1,0 转y0.5 缩放2) -> b(0,0,1)
+//             -> c
+func testTree() (root, a, b, c *Node) {
+	root, a, b, c = NewNode("root"), NewNode("a"), NewNode("b"), NewNode("c")
+	root.AddChild(a).AddChild(c)
+	a.AddChild(b)
+	root.SetPosition(&vector3.Vector{1, 0, 0})
+	q := quat.FromYAxisAngle(0.5)
+	a.SetRotation(&q).SetScale(&vector3.Vector{2, 2, 2}).SetPosition(&vector3.Vector{0, 1, 0})
+	b.SetPosition(&vector3.Vector{0, 0, 1})
+	return
+}
+
+func TestWorldMatrix(t *testing.T) {
+	root, a, b, _ := testTree()
+	// b的世界位置 = root + a + R(0.5) * 2 * (0,0,1)
+	q := quat.FromYAxisAngle(0.5)
+	off := q.RotatedVec3(&vector3.Vector{0, 0, 2})
+	want := vector3.Vector{1 + off[0], 1 + off[1], off[2]}
+	if got := b.WorldPosition(); !got.ApproxEqual(&want, 1e-5) {
+		t.Errorf("WorldPosition = %v, want %v", got, want)
+	}
+
+	// 改祖先后子树标脏
+	root.SetPosition(&vector3.Vector{5, 0, 0})
+	want[0] += 4
+	if got := b.WorldPosition(); !got.ApproxEqual(&want, 1e-5) {
+		t.Errorf("after moving root: WorldPosition = %v, want %v", got, want)
+	}
+	root.Translate(&vector3.Vector{0, 0, 1})
+	want[2]++
+	if got := b.WorldPosition(); !got.ApproxEqual(&want, 1e-5) {
+		t.Errorf("after Translate: WorldPosition = %v, want %v", got, want)
+	}
+
+	r := quat.FromZAxisAngle(0.3)
+	a.Rotate(&r)
+	wantRot := quat.Mul(&r, &q)
+	if got := b.WorldRotation(); !got.ApproxEqual(&wantRot, 1e-5) {
+		t.Errorf("WorldRotation = %v, want %v", got, wantRot)
+	}
+	local := a.Local()
+	if m, lm := local.Mat4(), a.LocalMatrix(); !m.ApproxEqual(&lm, 1e-6) {
+		t.Errorf("LocalMatrix = %v, want %v", lm, m)
+	}
+}
+
+func TestTransformPoint(t *testing.T) {
+	_, _, b, _ := testTree()
+	cases := []vector3.Vector{{0, 0, 0}, {3, 4, 5}, {-1, 0.5, 2}}
+	for _, p := range cases {
+		l := b.InverseTransformPoint(&p)
+		if got := b.TransformPoint(&l); !got.ApproxEqual(&p, 1e-4) {
+			t.Errorf("point %v round trip = %v", p, got)
+		}
+		l = b.InverseTransformDir(&p)
+		if got := b.TransformDir(&l); !got.ApproxEqual(&p, 1e-4) {
+			t.Errorf("dir %v round trip = %v", p, got)
+		}
+	}
+	// 方向不受平移影响, 受缩放影响
+	if got := b.TransformDir(&vector3.UnitY); !got.ApproxEqual(&vector3.Vector{0, 2, 0}, 1e-5) {
+		t.Errorf("TransformDir(UnitY) = %v", got)
+	}
+}
+
+func TestSetWorld(t *testing.T) {
+	_, _, b, _ := testTree()
+	wp := vector3.Vector{3, 4, 5}
+	b.SetWorldPosition(&wp)
+	if got := b.WorldPosition(); !got.ApproxEqual(&wp, 1e-4) {
+		t.Errorf("SetWorldPosition: %v, want %v", got, wp)
+	}
+	wr := quat.FromZAxisAngle(1)
+	b.SetWorldRotation(&wr)
+	if got := b.WorldRotation(); !got.ApproxEqual(&wr, 1e-5) {
+		t.Errorf("SetWorldRotation: %v, want %v", got, wr)
+	}
+	// 位置不受影响
+	if got := b.WorldPosition(); !got.ApproxEqual(&wp, 1e-4) {
+		t.Errorf("SetWorldRotation moved the node to %v", got)
+	}
+}
+
+func TestSetParent(t *testing.T) {
+	root, a, b, c := testTree()
+	q := quat.FromXAxisAngle(0.3)
+	c.SetRotation(&q).SetPosition(&vector3.Vector{0, 3, 0})
+
+	before := b.WorldMatrix()
+	b.SetParent(c, true)
+	if after := b.WorldMatrix(); !after.ApproxEqual(&before, 1e-5) {
+		t.Errorf("keepWorld: %v, want %v", after, before)
+	}
+	if b.Parent() != c || len(a.Children()) != 0 || len(c.Children()) != 1 || b.Root() != root {
+		t.Errorf("hierarchy after SetParent wrong")
+	}
+	if !root.IsAncestorOf(b) || a.IsAncestorOf(b) || b.IsAncestorOf(b) {
+		t.Errorf("IsAncestorOf wrong")
+	}
+
+	// 不保持世界变换时局部变换不变
+	local := b.Local()
+	b.SetParent(a, false)
+	if got := b.Local(); got != local {
+		t.Errorf("SetParent(false) changed local to %v", got)
+	}
+
+	// 镜像节点换父后世界矩阵不变
+	m := NewNode("m")
+	m.SetLocal(&transform.Transform{Rotation: quat.FromYAxisAngle(0.5), Scale: vector3.Vector{-1, 2, 1}})
+	before = m.WorldMatrix()
+	m.SetParent(c, true)
+	if after := m.WorldMatrix(); !after.ApproxEqual(&before, 1e-5) {
+		t.Errorf("mirror keepWorld: %v, want %v", after, before)
+	}
+
+	// 脱离父节点
+	before = m.WorldMatrix()
+	m.SetParent(nil, true)
+	if after := m.WorldMatrix(); m.Parent() != nil || !after.ApproxEqual(&before, 1e-5) {
+		t.Errorf("detach: parent %v, world %v, want %v", m.Parent(), after, before)
+	}
+
+	for _, p := range []*Node{a, b} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("parenting a to %s did not panic", p.Name)
+				}
+			}()
+			a.SetParent(p, false)
+		}()
+	}
+}
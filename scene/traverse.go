@@ -0,0 +1,89 @@
+/*
+ * 节点遍历  回调式Walk和迭代器式Iter, 均为深度优先先序
+ *   遍历过程中不要改动层级
+ */
+package scene
+
+// 深度优先先序遍历t及其后代, depth为相对t的深度(t为0)
+// fn返回false时跳过该节点的子树
+func (t *Node) Walk(fn func(n *Node, depth int) bool) {
+	t.walk(fn, 0)
+}
+
+func (t *Node) walk(fn func(n *Node, depth int) bool, depth int) {
+	if !fn(t, depth) {
+		return
+	}
+	for _, c := range t.children {
+		c.walk(fn, depth+1)
+	}
+}
+
+// 从父节点到根依次回调, fn返回false时停止
+func (t *Node) Ancestors(fn func(n *Node) bool) {
+	for p := t.parent; p != nil; p = p.parent {
+		if !fn(p) {
+			return
+		}
+	}
+}
+
+// 先序查找第一个名字为name的节点(含自身), 找不到返回nil
+func (t *Node) Find(name string) *Node {
+	var l_found *Node
+	t.Walk(func(n *Node, _ int) bool {
+		if l_found != nil {
+			return false
+		}
+		if n.Name == name {
+			l_found = n
+			return false
+		}
+		return true
+	})
+	return l_found
+}
+
+// 先序迭代器
+//
+//	for it := root.Iter(); it.Next(); {
+//	    n := it.Node()
+//	}
+type Iterator struct {
+	stack   []*Node
+	cur     *Node
+	skipped bool // 当前节点的子树已跳过
+}
+
+func (t *Node) Iter() *Iterator {
+	return &Iterator{stack: []*Node{t}}
+}
+
+// 前进到下一个节点, 遍历完返回false
+func (t *Iterator) Next() bool {
+	n := len(t.stack)
+	if n == 0 {
+		t.cur = nil
+		return false
+	}
+	t.cur = t.stack[n-1]
+	t.stack = t.stack[:n-1]
+	t.skipped = false
+	// 逆序压栈, 保证按children顺序出栈
+	for i := len(t.cur.children) - 1; i >= 0; i-- {
+		t.stack = append(t.stack, t.cur.children[i])
+	}
+	return true
+}
+
+func (t *Iterator) Node() *Node {
+	return t.cur
+}
+
+// 跳过当前节点的子树, 在Next之后调用; 重复调用无效
+func (t *Iterator) SkipChildren() {
+	if t.cur != nil && !t.skipped {
+		t.stack = t.stack[:len(t.stack)-len(t.cur.children)]
+		t.skipped = true
+	}
+}
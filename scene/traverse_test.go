@@ -0,0 +1,88 @@
+package scene
+
+import (
+	"reflect"
+	"testing"
+)
+
+// r -> a -> a1
+//        -> a2 -> a21
+//   -> b
+//   -> c -> c1
+func namedTree() *Node {
+	r := NewNode("r")
+	a, b, c := NewNode("a"), NewNode("b"), NewNode("c")
+	r.AddChild(a).AddChild(b).AddChild(c)
+	a2 := NewNode("a2")
+	a.AddChild(NewNode("a1")).AddChild(a2)
+	a2.AddChild(NewNode("a21"))
+	c.AddChild(NewNode("c1"))
+	return r
+}
+
+func TestWalk(t *testing.T) {
+	r := namedTree()
+	var names []string
+	var depths []int
+	r.Walk(func(n *Node, depth int) bool {
+		names = append(names, n.Name)
+		depths = append(depths, depth)
+		return n.Name != "a2"
+	})
+	if want := []string{"r", "a", "a1", "a2", "b", "c", "c1"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Walk = %v, want %v", names, want)
+	}
+	if want := []int{0, 1, 2, 2, 1, 1, 2}; !reflect.DeepEqual(depths, want) {
+		t.Errorf("depths = %v, want %v", depths, want)
+	}
+
+	a21 := r.Find("a21")
+	if a21 == nil || a21.Name != "a21" || r.Find("x") != nil {
+		t.Errorf("Find wrong")
+	}
+	var up []string
+	a21.Ancestors(func(n *Node) bool {
+		up = append(up, n.Name)
+		return n.Name != "a"
+	})
+	if want := []string{"a2", "a"}; !reflect.DeepEqual(up, want) {
+		t.Errorf("Ancestors = %v, want %v", up, want)
+	}
+}
+
+func TestIter(t *testing.T) {
+	cases := []struct {
+		name  string
+		skip  map[string]int // 节点名 -> SkipChildren调用次数
+		names []string
+	}{
+		{"all", nil, []string{"r", "a", "a1", "a2", "a21", "b", "c", "c1"}},
+		{"skip a", map[string]int{"a": 1}, []string{"r", "a", "b", "c", "c1"}},
+		// 重复调用不能多弹出兄弟节点
+		{"skip a twice", map[string]int{"a": 2}, []string{"r", "a", "b", "c", "c1"}},
+		{"skip leaf", map[string]int{"b": 3, "c": 1}, []string{"r", "a", "a1", "a2", "a21", "b", "c"}},
+		{"skip root", map[string]int{"r": 2}, []string{"r"}},
+	}
+	for _, c := range cases {
+		var names []string
+		for it := namedTree().Iter(); it.Next(); {
+			n := it.Node()
+			names = append(names, n.Name)
+			for i := 0; i < c.skip[n.Name]; i++ {
+				it.SkipChildren()
+			}
+		}
+		if !reflect.DeepEqual(names, c.names) {
+			t.Errorf("%s: Iter = %v, want %v", c.name, names, c.names)
+		}
+	}
+
+	// 遍历结束后Node为nil, SkipChildren无效
+	it := NewNode("x").Iter()
+	for it.Next() {
+	}
+	it.SkipChildren()
+	if it.Node() != nil || it.Next() {
+		t.Errorf("finished iterator not empty")
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * 带单位的角度类型  弧度/角度混用在编译期报错
+ *   同类型之间可直接 + - 及与无类型常量相乘, 不同类型需显式转换
+ */
+package sutil
+
+import (
+	math "github.com/barnex/fmath"
+)
+
+// 弧度
+type Radians float32
+
+// 角度
+type Degrees float32
+
+// 接受任意单位角度的参数类型, Radians和Degrees均实现
+type Angle interface {
+	Rad() Radians
+	Deg() Degrees
+}
+
+func (t Radians) Rad() Radians {
+	return t
+}
+
+func (t Radians) Deg() Degrees {
+	return Degrees(t * Rad2Deg)
+}
+
+func (t Degrees) Rad() Radians {
+	return Radians(t * Deg2Rad)
+}
+
+func (t Degrees) Deg() Degrees {
+	return t
+}
+
+// [-pi,pi]
+func (t Radians) Wrapped() Radians {
+	return Radians(WrapPi(float32(t)))
+}
+
+// [0,2pi)
+func (t Radians) Wrapped2Pi() Radians {
+	return Radians(Mod(float32(t), K2Pi))
+}
+
+// [-180,180]
+func (t Degrees) Wrapped() Degrees {
+	return Degrees(WrapAngle(float32(t)))
+}
+
+// [0,360]
+func (t Degrees) Wrapped360() Degrees {
+	return Degrees(WrapAngle360(float32(t)))
+}
+
+// 到target的最短差值 [-pi,pi]
+func (t Radians) Delta(target Radians) Radians {
+	return Radians(DeltaAngle(float32(t), float32(target)))
+}
+
+// 到target的最短差值 [-180,180]
+func (t Degrees) Delta(target Degrees) Degrees {
+	return Degrees(DeltaAngleDeg(float32(t), float32(target)))
+}
+
+// 沿最短路径插值, f限制在[0,1]
+func (t Radians) Lerp(target Radians, f float32) Radians {
+	return Radians(LerpAngle(float32(t), float32(target), f))
+}
+
+// 沿最短路径插值, f限制在[0,1]
+func (t Degrees) Lerp(target Degrees, f float32) Degrees {
+	return Degrees(LerpAngleDeg(float32(t), float32(target), f))
+}
+
+func (t Radians) Sin() float32 {
+	return math.Sin(float32(t))
+}
+
+func (t Radians) Cos() float32 {
+	return math.Cos(float32(t))
+}
+
+func (t Radians) Sincos() (sin, cos float32) {
+	return math.Sincos(float32(t))
+}
+
+// Asin/Acos/Atan2的带单位版本
+func AsinRad(x float32) Radians {
+	return Radians(math.Asin(x))
+}
+
+func AcosRad(x float32) Radians {
+	return Radians(math.Acos(x))
+}
+
+func Atan2Rad(y, x float32) Radians {
+	return Radians(math.Atan2(y, x))
+}
+
+// CanonizeEuler的带单位版本
+func CanonizeEulerOf(pitch, heading, bank Angle) (rp, rh, rb Radians) {
+	p, h, b := CanonizeEuler(float32(pitch.Rad()), float32(heading.Rad()), float32(bank.Rad()))
+	return Radians(p), Radians(h), Radians(b)
+}
@@ -0,0 +1,124 @@
+package sutil
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAngleConvert(t *testing.T) {
+	cases := []struct {
+		a   Angle
+		rad Radians
+		deg Degrees
+	}{
+		{Radians(0), 0, 0},
+		{Radians(KPi), KPi, 180},
+		{Degrees(90), KPiOver2, 90},
+		{Degrees(-45), -KPi / 4, -45},
+		{Degrees(720), 4 * KPi, 720},
+	}
+	for _, c := range cases {
+		if r := c.a.Rad(); !near(float32(r), float32(c.rad), 1e-5) {
+			t.Errorf("%v.Rad() = %v, want %v", c.a, r, c.rad)
+		}
+		if d := c.a.Deg(); !near(float32(d), float32(c.deg), 1e-3) {
+			t.Errorf("%v.Deg() = %v, want %v", c.a, d, c.deg)
+		}
+	}
+}
+
+func TestAngleWrap(t *testing.T) {
+	rad := []struct {
+		name      string
+		got, want Radians
+	}{
+		{"Wrapped", Radians(3 * KPi / 2).Wrapped(), -KPiOver2},
+		{"Wrapped negative", Radians(-5 * KPi / 2).Wrapped(), -KPiOver2},
+		{"Wrapped2Pi", Radians(-KPiOver2).Wrapped2Pi(), 3 * KPi / 2},
+		{"Delta", Radians(KPi - 0.1).Delta(-KPi + 0.1), 0.2},
+		{"Lerp", Radians(0).Lerp(1, 0.25), 0.25},
+		{"arithmetic", Radians(1) + 2*Radians(0.5), 2},
+	}
+	for _, c := range rad {
+		if !near(float32(c.got), float32(c.want), 1e-5) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	deg := []struct {
+		name      string
+		got, want Degrees
+	}{
+		{"Wrapped", Degrees(270).Wrapped(), -90},
+		{"Wrapped360", Degrees(-90).Wrapped360(), 270},
+		{"Delta", Degrees(350).Delta(10), 20},
+		{"Lerp", Degrees(10).Lerp(350, 0.5), 0},
+	}
+	for _, c := range deg {
+		if !near(float32(c.got), float32(c.want), 1e-3) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestAngleTrig(t *testing.T) {
+	a := Degrees(30).Rad()
+	if s := a.Sin(); !near(s, 0.5, 1e-6) {
+		t.Errorf("sin 30deg = %v", s)
+	}
+	s, c := Degrees(60).Rad().Sincos()
+	if !near(s, 0.8660254, 1e-6) || !near(c, 0.5, 1e-6) || !near(Degrees(60).Rad().Cos(), c, 0) {
+		t.Errorf("sincos 60deg = %v, %v", s, c)
+	}
+	if d := AsinRad(0.5).Deg(); !near(float32(d), 30, 1e-3) {
+		t.Errorf("AsinRad(0.5) = %v deg", d)
+	}
+	if d := AcosRad(0.5).Deg(); !near(float32(d), 60, 1e-3) {
+		t.Errorf("AcosRad(0.5) = %v deg", d)
+	}
+	if d := Atan2Rad(-1, -1).Deg(); !near(float32(d), -135, 1e-3) {
+		t.Errorf("Atan2Rad(-1, -1) = %v deg", d)
+	}
+}
+
+func TestCanonizeEulerOf(t *testing.T) {
+	p, h, b := CanonizeEulerOf(Degrees(30), Radians(0.5), Degrees(-400))
+	wp, wh, wb := CanonizeEuler(float32(Degrees(30).Rad()), 0.5, float32(Degrees(-400).Rad()))
+	if !near(float32(p), wp, 1e-6) || !near(float32(h), wh, 1e-6) || !near(float32(b), wb, 1e-6) {
+		t.Errorf("CanonizeEulerOf = %v %v %v, want %v %v %v", p, h, b, wp, wh, wb)
+	}
+	if !near(float32(b.Deg()), -40, 1e-3) {
+		t.Errorf("bank = %v deg, want -40", b.Deg())
+	}
+}
+
+// 超大值和Inf不能死循环, 结果落在区间内或为NaN
+func TestDegreesWrapHuge(t *testing.T) {
+	inf := Degrees(math.Inf(1))
+	cases := []struct {
+		name   string
+		got    Degrees
+		lo, hi Degrees
+	}{
+		{"Wrapped 1e12", Degrees(1e12).Wrapped(), -180, 180},
+		{"Wrapped -1e12", Degrees(-1e12).Wrapped(), -180, 180},
+		{"Wrapped max", Degrees(math.MaxFloat32).Wrapped(), -180, 180},
+		{"Wrapped360 1e12", Degrees(1e12).Wrapped360(), 0, 360},
+		{"Wrapped360 -3e38", Degrees(-3e38).Wrapped360(), 0, 360},
+		{"Delta 1e12", Degrees(-1e12).Delta(1e12), -180, 180},
+		{"Lerp 1e12", Degrees(0).Lerp(1e12, 0.5), -90, 90},
+	}
+	for _, c := range cases {
+		if !(c.got >= c.lo && c.got <= c.hi) {
+			t.Errorf("%s = %v, want in [%v, %v]", c.name, c.got, c.lo, c.hi)
+		}
+	}
+	for _, got := range []Degrees{inf.Wrapped(), (-inf).Wrapped(), inf.Wrapped360(), Degrees(0).Delta(inf), Degrees(0).Lerp(-inf, 0.5)} {
+		if !IsNaN(float32(got)) {
+			t.Errorf("wrap of Inf = %v, want NaN", got)
+		}
+	}
+	// 精确可表示的大数按周期等价
+	if got := Degrees(360*16384 + 90).Wrapped(); got != 90 {
+		t.Errorf("Degrees(360*16384+90).Wrapped() = %v, want 90", got)
+	}
+}
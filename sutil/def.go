@@ -11,10 +11,11 @@ import (
 	math "github.com/barnex/fmath"
 )
 
-var Epsilon float32 = 1e-4
-var MinNormal = float32(1.1754943508222875e-38)
-var MinValue = float32(math.SmallestNonzeroFloat32)
-var MaxValue = float32(math.MaxFloat32)
+// FloatEqual使用的默认绝对误差, 需要其他精度时显式传入误差
+const Epsilon float32 = 1e-4
+const MinNormal = float32(1.1754943508222875e-38)
+const MinValue = float32(math.SmallestNonzeroFloat32)
+const MaxValue = float32(math.MaxFloat32)
 
 const KPi = math.Pi
 const K2Pi = KPi * 2.0
@@ -0,0 +1,44 @@
+/*
+ * 快速近似函数  精度换速度, 误差界为在float32输入上抽样实测所得
+ */
+package sutil
+
+import (
+	"math"
+)
+
+const (
+	kFastSinB = 4 / KPi
+	kFastSinC = -4 / (KPi * KPi)
+	kFastSinP = 0.225
+)
+
+// 近似 1/sqrt(x), 位运算初值 + 一次牛顿迭代
+// 相对误差 < 1.8e-3; x <= 0 或非有限值时结果无意义
+func FastInvSqrt(x float32) float32 {
+	i := math.Float32bits(x)
+	i = 0x5f375a86 - i>>1
+	y := math.Float32frombits(i)
+	return y * (1.5 - 0.5*x*y*y)
+}
+
+// 近似 sqrt(x) = x * FastInvSqrt(x), 相对误差 < 1.8e-3, x <= 0 时返回0
+func FastSqrt(x float32) float32 {
+	if x <= 0 {
+		return 0
+	}
+	return x * FastInvSqrt(x)
+}
+
+// 近似 sin(x), 先规范到[-pi,pi]再用修正抛物线拟合
+// 绝对误差 < 1.1e-3 (|x|很大时规范化本身的误差另计)
+func FastSin(x float32) float32 {
+	x = WrapPi(x)
+	y := kFastSinB*x + kFastSinC*x*Abs(x)
+	return kFastSinP*(y*Abs(y)-y) + y
+}
+
+// 近似 cos(x) = FastSin(x + pi/2), 误差同FastSin
+func FastCos(x float32) float32 {
+	return FastSin(x + KPiOver2)
+}
@@ -0,0 +1,40 @@
+package sutil
+
+import (
+	"math"
+	"testing"
+)
+
+// 抽样验证文档中的误差界
+func TestFastApprox(t *testing.T) {
+	var maxInvSqrt, maxSqrt, maxSin, maxCos float64
+	for i := 0; i <= 100000; i++ {
+		// 1e-6 ~ 1e6 按对数均匀
+		x := float32(math.Pow(10, -6+12*float64(i)/100000))
+		want := 1 / math.Sqrt(float64(x))
+		maxInvSqrt = math.Max(maxInvSqrt, math.Abs(float64(FastInvSqrt(x))-want)/want)
+		want = math.Sqrt(float64(x))
+		maxSqrt = math.Max(maxSqrt, math.Abs(float64(FastSqrt(x))-want)/want)
+
+		a := float32(-10 + 20*float64(i)/100000)
+		maxSin = math.Max(maxSin, math.Abs(float64(FastSin(a))-math.Sin(float64(a))))
+		maxCos = math.Max(maxCos, math.Abs(float64(FastCos(a))-math.Cos(float64(a))))
+	}
+	cases := []struct {
+		name     string
+		err, max float64
+	}{
+		{"FastInvSqrt", maxInvSqrt, 1.8e-3},
+		{"FastSqrt", maxSqrt, 1.8e-3},
+		{"FastSin", maxSin, 1.1e-3},
+		{"FastCos", maxCos, 1.1e-3},
+	}
+	for _, c := range cases {
+		if c.err >= c.max {
+			t.Errorf("%s: max error %v, documented < %v", c.name, c.err, c.max)
+		}
+	}
+	if FastSqrt(0) != 0 || FastSqrt(-1) != 0 {
+		t.Errorf("FastSqrt of non-positive should be 0")
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * 浮点数比较及分类
+ */
+package sutil
+
+import "math"
+
+func IsNaN(f float32) bool {
+	return f != f
+}
+
+// sign>0 只判断+Inf, sign<0 只判断-Inf, sign==0 都判断
+func IsInf(f float32, sign int) bool {
+	return sign >= 0 && f > math.MaxFloat32 || sign <= 0 && f < -math.MaxFloat32
+}
+
+// 既不是NaN也不是Inf
+func IsFinite(f float32) bool {
+	return f-f == 0
+}
+
+// 绝对误差或相对误差满足其一即认为相等
+// |a-b| <= absTol 适用于接近0的值, |a-b| <= relTol*max(|a|,|b|) 适用于大数
+func AlmostEqual(a, b, absTol, relTol float32) bool {
+	if a == b { // 含相同符号的Inf
+		return true
+	}
+	if !IsFinite(a) || !IsFinite(b) {
+		return false
+	}
+	diff := Abs(a - b)
+	if diff <= absTol {
+		return true
+	}
+	largest := Abs(a)
+	if l := Abs(b); l > largest {
+		largest = l
+	}
+	return diff <= relTol*largest
+}
+
+// 两个数之间相差的可表示浮点数个数(ULP)不超过maxULPs, +0与-0相等
+// 跨越0附近时ULP的意义不大, 应配合AlmostEqual的绝对误差使用
+func AlmostEqualULPs(a, b float32, maxULPs uint32) bool {
+	if IsNaN(a) || IsNaN(b) {
+		return false
+	}
+	diff := orderedBits(a) - orderedBits(b)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= int64(maxULPs)
+}
+
+// 把float32的位表示映射为单调递增的整数, 负数按补码顺序翻转
+func orderedBits(f float32) int64 {
+	i := int64(int32(math.Float32bits(f)))
+	if i < 0 {
+		i = math.MinInt32 - i
+	}
+	return i
+}
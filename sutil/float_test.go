@@ -0,0 +1,108 @@
+package sutil
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	kNaN  = float32(math.NaN())
+	kPInf = float32(math.Inf(1))
+	kNInf = float32(math.Inf(-1))
+)
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		f                         float32
+		nan, inf, pinf, ninf, fin bool
+	}{
+		{0, false, false, false, false, true},
+		{-1.5, false, false, false, false, true},
+		{MaxValue, false, false, false, false, true},
+		{-MaxValue, false, false, false, false, true},
+		{MinValue, false, false, false, false, true},
+		{kPInf, false, true, true, false, false},
+		{kNInf, false, true, false, true, false},
+		{kNaN, true, false, false, false, false},
+	}
+	for _, c := range cases {
+		if got := IsNaN(c.f); got != c.nan {
+			t.Errorf("IsNaN(%v) = %v", c.f, got)
+		}
+		if got := IsInf(c.f, 0); got != c.inf {
+			t.Errorf("IsInf(%v, 0) = %v", c.f, got)
+		}
+		if got := IsInf(c.f, 1); got != c.pinf {
+			t.Errorf("IsInf(%v, 1) = %v", c.f, got)
+		}
+		if got := IsInf(c.f, -1); got != c.ninf {
+			t.Errorf("IsInf(%v, -1) = %v", c.f, got)
+		}
+		if got := IsFinite(c.f); got != c.fin {
+			t.Errorf("IsFinite(%v) = %v", c.f, got)
+		}
+	}
+}
+
+func TestAlmostEqual(t *testing.T) {
+	cases := []struct {
+		a, b, abs, rel float32
+		want           bool
+	}{
+		{1, 1, 0, 0, true},
+		{0, 1e-6, 1e-5, 0, true},
+		{0, 1e-4, 1e-5, 0.1, false}, // 接近0时相对误差不起作用
+		{1e6, 1e6 + 64, 1e-5, 1e-4, true},
+		{1e6, 1e6 + 256, 1e-5, 1e-4, false},
+		{-1, 1, 1e-3, 1e-3, false},
+		{kPInf, kPInf, 0, 0, true},
+		{kPInf, kNInf, 1, 1, false},
+		{kPInf, MaxValue, 1, 1, false},
+		{kNaN, kNaN, 1, 1, false},
+		{kNaN, 0, 1, 1, false},
+	}
+	for _, c := range cases {
+		if got := AlmostEqual(c.a, c.b, c.abs, c.rel); got != c.want {
+			t.Errorf("AlmostEqual(%v, %v, %v, %v) = %v, want %v", c.a, c.b, c.abs, c.rel, got, c.want)
+		}
+		if got := AlmostEqual(c.b, c.a, c.abs, c.rel); got != c.want {
+			t.Errorf("AlmostEqual(%v, %v, %v, %v) = %v, want %v", c.b, c.a, c.abs, c.rel, got, c.want)
+		}
+	}
+}
+
+func TestAlmostEqualULPs(t *testing.T) {
+	next := func(f float32, n int) float32 {
+		for i := 0; i < n; i++ {
+			f = math.Nextafter32(f, kPInf)
+		}
+		return f
+	}
+	negZero := float32(math.Copysign(0, -1))
+	cases := []struct {
+		a, b float32
+		ulps uint32
+		want bool
+	}{
+		{1, 1, 0, true},
+		{1, next(1, 1), 0, false},
+		{1, next(1, 1), 1, true},
+		{1, next(1, 4), 3, false},
+		{1, next(1, 4), 4, true},
+		{-1, next(-1, 2), 2, true},
+		{0, negZero, 0, true},
+		{-MinValue, MinValue, 2, true}, // 跨越0
+		{-MinValue, MinValue, 1, false},
+		{MaxValue, kPInf, 1, true},
+		{-1, 1, math.MaxUint32, true},
+		{kNaN, kNaN, math.MaxUint32, false},
+	}
+	for _, c := range cases {
+		if got := AlmostEqualULPs(c.a, c.b, c.ulps); got != c.want {
+			t.Errorf("AlmostEqualULPs(%v, %v, %d) = %v, want %v", c.a, c.b, c.ulps, got, c.want)
+		}
+		if got := AlmostEqualULPs(c.b, c.a, c.ulps); got != c.want {
+			t.Errorf("AlmostEqualULPs(%v, %v, %d) = %v, want %v", c.b, c.a, c.ulps, got, c.want)
+		}
+	}
+}
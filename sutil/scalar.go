@@ -0,0 +1,136 @@
+/*
+ * 标量插值, 取整, 角度等常用函数
+ */
+package sutil
+
+import (
+	math "github.com/barnex/fmath"
+)
+
+// a + (b-a)*t, t不做限制
+func Lerp(a, b, t float32) float32 {
+	return a + (b-a)*t
+}
+
+// Lerp的逆: 返回v在[a,b]中的比例, a==b时返回0
+func InverseLerp(a, b, v float32) float32 {
+	if a == b {
+		return 0
+	}
+	return (v - a) / (b - a)
+}
+
+// 将v从[inMin,inMax]线性映射到[outMin,outMax]
+func Remap(v, inMin, inMax, outMin, outMax float32) float32 {
+	return Lerp(outMin, outMax, InverseLerp(inMin, inMax, v))
+}
+
+// -1, 0, 1
+func Sign(a float32) float32 {
+	if a > 0 {
+		return 1
+	} else if a < 0 {
+		return -1
+	}
+	return 0
+}
+
+// 小数部分 [0,1), 负数同样向下取整: Fract(-0.25) = 0.75
+func Fract(a float32) float32 {
+	return a - math.Floor(a)
+}
+
+// 取模, 结果与b同号: Mod(-1, 3) = 2
+// 同math.Mod, b为0或a为Inf时返回NaN
+func Mod(a, b float32) float32 {
+	return a - b*math.Floor(a/b)
+}
+
+// 将t循环限制在[0,length], length<=0时返回0
+func Repeat(t, length float32) float32 {
+	if !(length > 0) {
+		return 0
+	}
+	return Clamp(t-math.Floor(t/length)*length, 0, length)
+}
+
+// t在[0,length]之间往返, length<=0时返回0
+func PingPong(t, length float32) float32 {
+	if !(length > 0) {
+		return 0
+	}
+	t = Repeat(t, length*2)
+	return length - Abs(t-length)
+}
+
+// x < edge返回0, 否则返回1
+func Step(edge, x float32) float32 {
+	if x < edge {
+		return 0
+	}
+	return 1
+}
+
+// current向target移动, 每次不超过maxDelta, 不会越过target
+func MoveTowards(current, target, maxDelta float32) float32 {
+	if Abs(target-current) <= maxDelta {
+		return target
+	}
+	return current + Sign(target-current)*maxDelta
+}
+
+// 弧度: current到target的最短差值 [-pi,pi]
+func DeltaAngle(current, target float32) float32 {
+	return WrapPi(target - current)
+}
+
+// 弧度: 沿最短路径插值, t限制在[0,1]
+func LerpAngle(a, b, t float32) float32 {
+	return a + DeltaAngle(a, b)*Clamp(t, 0, 1)
+}
+
+// 弧度: 沿最短路径向target移动, 每次不超过maxDelta
+func MoveTowardsAngle(current, target, maxDelta float32) float32 {
+	delta := DeltaAngle(current, target)
+	if -maxDelta < delta && delta < maxDelta {
+		return target
+	}
+	return MoveTowards(current, current+delta, maxDelta)
+}
+
+// 角度: current到target的最短差值 [-180,180]
+func DeltaAngleDeg(current, target float32) float32 {
+	return WrapAngle(target - current)
+}
+
+// 角度: 沿最短路径插值, t限制在[0,1]
+func LerpAngleDeg(a, b, t float32) float32 {
+	return a + DeltaAngleDeg(a, b)*Clamp(t, 0, 1)
+}
+
+// 角度: 沿最短路径向target移动, 每次不超过maxDelta
+func MoveTowardsAngleDeg(current, target, maxDelta float32) float32 {
+	delta := DeltaAngleDeg(current, target)
+	if -maxDelta < delta && delta < maxDelta {
+		return target
+	}
+	return MoveTowards(current, current+delta, maxDelta)
+}
+
+// 不小于v的最小2的幂, v为0时返回1, 超过2^31时返回0
+func NextPowerOfTwo(v uint32) uint32 {
+	if v == 0 {
+		return 1
+	}
+	v--
+	v |= v >> 1
+	v |= v >> 2
+	v |= v >> 4
+	v |= v >> 8
+	v |= v >> 16
+	return v + 1
+}
+
+func IsPowerOfTwo(v uint32) bool {
+	return v != 0 && v&(v-1) == 0
+}
@@ -0,0 +1,194 @@
+package sutil
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b, tol float32) bool {
+	return Abs(a-b) <= tol
+}
+
+func TestInterp(t *testing.T) {
+	cases := []struct {
+		name      string
+		got, want float32
+	}{
+		{"Lerp 0", Lerp(2, 6, 0), 2},
+		{"Lerp 1", Lerp(2, 6, 1), 6},
+		{"Lerp mid", Lerp(2, 6, 0.25), 3},
+		{"Lerp extrapolate", Lerp(2, 6, 1.5), 8},
+		{"InverseLerp", InverseLerp(2, 6, 3), 0.25},
+		{"InverseLerp outside", InverseLerp(2, 6, 0), -0.5},
+		{"InverseLerp a==b", InverseLerp(2, 2, 5), 0},
+		{"Remap", Remap(5, 0, 10, 100, 200), 150},
+		{"Remap reversed", Remap(2, 0, 10, 1, -1), 0.6},
+	}
+	for _, c := range cases {
+		if !near(c.got, c.want, 1e-6) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestScalar(t *testing.T) {
+	cases := []struct {
+		name      string
+		got, want float32
+	}{
+		{"Sign +", Sign(3), 1},
+		{"Sign -", Sign(-0.5), -1},
+		{"Sign 0", Sign(0), 0},
+		{"Fract", Fract(2.25), 0.25},
+		{"Fract negative", Fract(-0.25), 0.75},
+		{"Mod", Mod(7, 3), 1},
+		{"Mod negative a", Mod(-1, 3), 2},
+		{"Mod negative b", Mod(1, -3), -2},
+		{"Repeat", Repeat(7, 3), 1},
+		{"Repeat negative", Repeat(-1, 3), 2},
+		{"PingPong up", PingPong(1, 3), 1},
+		{"PingPong down", PingPong(4, 3), 2},
+		{"PingPong wrap", PingPong(7, 3), 1},
+		{"Step below", Step(1, 0.5), 0},
+		{"Step edge", Step(1, 1), 1},
+		{"MoveTowards", MoveTowards(0, 10, 3), 3},
+		{"MoveTowards back", MoveTowards(0, -10, 3), -3},
+		{"MoveTowards arrive", MoveTowards(9, 10, 3), 10},
+	}
+	for _, c := range cases {
+		if !near(c.got, c.want, 1e-5) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+// 退化参数有确定的结果
+func TestScalarDegenerate(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		got  float32
+	}{
+		{"Mod b=0", Mod(1, 0)},
+		{"Mod a=Inf", Mod(float32(math.Inf(1)), 3)},
+	} {
+		if !IsNaN(c.got) {
+			t.Errorf("%s = %v, want NaN", c.name, c.got)
+		}
+	}
+	cases := []struct {
+		name      string
+		got, want float32
+	}{
+		{"Repeat length=0", Repeat(5, 0), 0},
+		{"Repeat length<0", Repeat(5, -3), 0},
+		{"Repeat length=NaN", Repeat(5, float32(math.NaN())), 0},
+		{"PingPong length=0", PingPong(5, 0), 0},
+		{"PingPong length<0", PingPong(-5, -3), 0},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestAngle(t *testing.T) {
+	// 结果按周期比较, -pi与pi等价
+	rad := []struct {
+		name      string
+		got, want float32
+	}{
+		{"DeltaAngle", DeltaAngle(0.1, 0.4), 0.3},
+		{"DeltaAngle wrap", DeltaAngle(KPi-0.1, -KPi+0.1), 0.2},
+		{"DeltaAngle wrap back", DeltaAngle(-KPi+0.1, KPi-0.1), -0.2},
+		{"LerpAngle wrap", LerpAngle(KPi-0.1, -KPi+0.1, 0.5), KPi},
+		{"LerpAngle clamp", LerpAngle(0, 1, 2), 1},
+		{"MoveTowardsAngle", MoveTowardsAngle(KPi-0.1, -KPi+0.1, 0.05), KPi - 0.05},
+		{"MoveTowardsAngle arrive", MoveTowardsAngle(KPi-0.1, -KPi+0.1, 1), -KPi + 0.1},
+	}
+	for _, c := range rad {
+		if !near(WrapPi(c.got-c.want), 0, 1e-4) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	deg := []struct {
+		name      string
+		got, want float32
+	}{
+		{"DeltaAngleDeg", DeltaAngleDeg(350, 10), 20},
+		{"DeltaAngleDeg back", DeltaAngleDeg(10, 350), -20},
+		{"LerpAngleDeg", LerpAngleDeg(350, 10, 0.5), 0},
+		{"MoveTowardsAngleDeg", MoveTowardsAngleDeg(350, 10, 5), 355},
+		{"MoveTowardsAngleDeg arrive", MoveTowardsAngleDeg(350, 10, 30), 10},
+	}
+	for _, c := range deg {
+		if !near(WrapAngle(c.got-c.want), 0, 1e-3) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	// 差值本身不做周期等价
+	if d := DeltaAngleDeg(350, 10); !near(d, 20, 1e-3) {
+		t.Errorf("DeltaAngleDeg(350, 10) = %v, want 20", d)
+	}
+	if d := DeltaAngle(KPi-0.1, -KPi+0.1); !near(d, 0.2, 1e-4) {
+		t.Errorf("DeltaAngle across pi = %v, want 0.2", d)
+	}
+}
+
+// 角度差很大或为Inf时不能死循环
+func TestAngleDegHuge(t *testing.T) {
+	inf := float32(math.Inf(1))
+	cases := []struct {
+		name   string
+		got    float32
+		lo, hi float32
+	}{
+		{"DeltaAngleDeg", DeltaAngleDeg(-1e12, 1e12), -180, 180},
+		{"DeltaAngleDeg max", DeltaAngleDeg(-math.MaxFloat32/2, math.MaxFloat32/2), -180, 180},
+		{"LerpAngleDeg", LerpAngleDeg(0, 1e12, 0.5), -90, 90},
+		{"MoveTowardsAngleDeg", MoveTowardsAngleDeg(0, 3e38, 1), -1, 1},
+		{"DeltaAngle", DeltaAngle(-1e12, 1e12), -KPi, KPi},
+	}
+	for _, c := range cases {
+		if !(c.got >= c.lo && c.got <= c.hi) {
+			t.Errorf("%s = %v, want in [%v, %v]", c.name, c.got, c.lo, c.hi)
+		}
+	}
+	if d := DeltaAngleDeg(0, inf); !IsNaN(d) {
+		t.Errorf("DeltaAngleDeg(0, Inf) = %v, want NaN", d)
+	}
+	if d := LerpAngleDeg(10, -inf, 0.5); !IsNaN(d) {
+		t.Errorf("LerpAngleDeg(10, -Inf) = %v, want NaN", d)
+	}
+	// 目标无效时不移动
+	if d := MoveTowardsAngleDeg(10, inf, 5); d != 10 {
+		t.Errorf("MoveTowardsAngleDeg(10, Inf) = %v, want 10", d)
+	}
+}
+
+func TestNextPowerOfTwo(t *testing.T) {
+	cases := []struct {
+		v, want uint32
+	}{
+		{0, 1},
+		{1, 1},
+		{2, 2},
+		{3, 4},
+		{5, 8},
+		{1024, 1024},
+		{1025, 2048},
+		{1 << 31, 1 << 31},
+		{1<<31 + 1, 0},
+	}
+	for _, c := range cases {
+		if got := NextPowerOfTwo(c.v); got != c.want {
+			t.Errorf("NextPowerOfTwo(%d) = %d, want %d", c.v, got, c.want)
+		}
+		if c.want != 0 && !IsPowerOfTwo(c.want) {
+			t.Errorf("IsPowerOfTwo(%d) = false", c.want)
+		}
+	}
+	if IsPowerOfTwo(0) || IsPowerOfTwo(6) {
+		t.Errorf("IsPowerOfTwo accepts non powers")
+	}
+}
@@ -25,18 +25,12 @@ func FloatEqual(a, b float32) bool {
 	return FloatEqualThreshold(a, b, Epsilon)
 }
 
+// 只比较绝对误差, 相对误差见AlmostEqual
 func FloatEqualThreshold(a, b, epsilon float32) bool {
 	if a == b {
 		return true
 	}
 
-	// diff := math.Abs(a - b)
-	// if a*b == 0 || diff < MinNormal {
-	// 	return diff < epsilon*epsilon
-	// }
-
-	// return diff/(Abs(a)+Abs(b)) < epsilon
-
 	if a > b {
 		return a-b < epsilon
 	} else {
@@ -101,19 +95,17 @@ func WrapPi(theta float32) float32 {
 	theta -= math.Floor(theta*K1Over2Pi) * K2Pi
 	theta -= math.Pi
 
-	return theta
+	// |theta|很大时舍入误差可能超出区间, 同WrapAngle
+	return Clamp(theta, -math.Pi, math.Pi)
 
 }
 
 // [-180,180]
+// 与WrapPi一样用floor, 不用循环: 超大值和Inf也能返回(Inf得NaN)
+// |angle|很大时float32已没有角度精度, 只保证结果落在区间内
 func WrapAngle(angle float32) float32 {
-	for angle > 180 {
-		angle -= 360
-	}
-	for angle < -180 {
-		angle += 360
-	}
-	return angle
+	angle -= math.Floor((angle+180)/360) * 360
+	return Clamp(angle, -180, 180)
 }
 
 // [0,360]
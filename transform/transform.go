@@ -0,0 +1,55 @@
+/*
+ * 平移-旋转-缩放变换  骨骼动画和场景节点共用的局部变换
+ *   矩阵为 T * R * S, 先缩放再旋转最后平移
+ */
+package transform
+
+import (
+	"github.com/tinysss/smath"
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+type Transform struct {
+	Translation vector3.Vector
+	Rotation    quat.Quaternion
+	Scale       vector3.Vector
+}
+
+var Ident = Transform{Rotation: quat.Ident, Scale: vector3.UnitXYZ}
+
+// T * R * S
+func (t *Transform) Mat4() mat4.Mat4 {
+	l_m := smath.QuatToMat4(&t.Rotation)
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			l_m[col][row] *= t.Scale[col]
+		}
+	}
+	l_m.SetTranslation(&t.Translation)
+	return l_m
+}
+
+// 仿射矩阵拆成TRS, 行列式为负时把镜像放到x缩放上
+// 线性部分含切变时切变部分丢失
+func FromMat4(m *mat4.Mat4) Transform {
+	var tr Transform
+	tr.Translation = vector3.Vector{m[3][0], m[3][1], m[3][2]}
+	l_rot := mat4.Ident
+	for col := 0; col < 3; col++ {
+		c := vector3.Vector{m[col][0], m[col][1], m[col][2]}
+		tr.Scale[col] = c.Length()
+		if tr.Scale[col] != 0 {
+			c.Scale(1 / tr.Scale[col])
+		}
+		l_rot[col][0], l_rot[col][1], l_rot[col][2] = c[0], c[1], c[2]
+	}
+	if m.Det3x3() < 0 {
+		tr.Scale[0] = -tr.Scale[0]
+		l_rot[0][0], l_rot[0][1], l_rot[0][2] = -l_rot[0][0], -l_rot[0][1], -l_rot[0][2]
+	}
+	tr.Rotation = smath.Mat4ToQuat(&l_rot)
+	tr.Rotation.Normalize()
+	return tr
+}
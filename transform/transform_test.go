@@ -0,0 +1,62 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/mat4"
+	"github.com/tinysss/smath/quat"
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestMat4(t *testing.T) {
+	tr := Transform{Translation: vector3.Vector{1, 2, 3}, Rotation: quat.FromZAxisAngle(1.5707964), Scale: vector3.Vector{2, 3, 1}}
+	m := tr.Mat4()
+	// 先缩放, 再旋转, 最后平移
+	cases := []struct{ p, want vector3.Vector }{
+		{vector3.Vector{0, 0, 0}, vector3.Vector{1, 2, 3}},
+		{vector3.Vector{1, 0, 0}, vector3.Vector{1, 4, 3}},
+		{vector3.Vector{0, 1, 1}, vector3.Vector{-2, 2, 4}},
+	}
+	for _, c := range cases {
+		if got := m.MulVec3(&c.p); !got.ApproxEqual(&c.want, 1e-5) {
+			t.Errorf("Mat4 * %v = %v, want %v", c.p, got, c.want)
+		}
+	}
+	if m := Ident.Mat4(); !m.ApproxEqual(&mat4.Ident, 0) {
+		t.Errorf("Ident.Mat4 = %v", m)
+	}
+}
+
+func TestFromMat4(t *testing.T) {
+	cases := []struct {
+		name string
+		tr   Transform
+	}{
+		{"ident", Ident},
+		{"trs", Transform{vector3.Vector{1, -2, 3}, quat.FromEulerAngles(0.3, -0.7, 1.1), vector3.Vector{2, 0.5, 3}}},
+		// 镜像放到x缩放上
+		{"mirror x", Transform{vector3.Vector{0, 1, 0}, quat.FromYAxisAngle(0.5), vector3.Vector{-1, 2, 1}}},
+	}
+	for _, c := range cases {
+		m := c.tr.Mat4()
+		got := FromMat4(&m)
+		if !got.Translation.ApproxEqual(&c.tr.Translation, 1e-5) || !got.Scale.ApproxEqual(&c.tr.Scale, 1e-5) {
+			t.Errorf("%s: FromMat4 = %v, want %v", c.name, got, c.tr)
+		}
+		neg := got.Rotation.Scaled(-1)
+		if !got.Rotation.ApproxEqual(&c.tr.Rotation, 1e-5) && !neg.ApproxEqual(&c.tr.Rotation, 1e-5) {
+			t.Errorf("%s: rotation = %v, want %v", c.name, got.Rotation, c.tr.Rotation)
+		}
+	}
+
+	// y为负的镜像拆出来不同, 但矩阵一致
+	tr := Transform{vector3.Vector{1, 2, 3}, quat.FromXAxisAngle(0.4), vector3.Vector{1, -2, 1}}
+	m := tr.Mat4()
+	got := FromMat4(&m)
+	if back := got.Mat4(); !back.ApproxEqual(&m, 1e-5) {
+		t.Errorf("mirror y: recomposed %v, want %v", back, m)
+	}
+	if got.Scale[0] >= 0 {
+		t.Errorf("mirror y: Scale = %v, want negative x", got.Scale)
+	}
+}
@@ -0,0 +1,28 @@
+package vector2
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/sutil"
+)
+
+func TestRotateOf(t *testing.T) {
+	cases := []struct {
+		name      string
+		got, want Vector
+	}{
+		{"FromAngle deg", FromAngle(sutil.Degrees(90)), Vector{0, 1}},
+		{"FromAngle rad", FromAngle(sutil.Radians(sutil.KPi)), Vector{-1, 0}},
+		{"RotatedOf", (&Vector{2, 0}).RotatedOf(sutil.Degrees(-90)), Vector{0, -2}},
+		{"RotateOf", *(&Vector{1, 1}).RotateOf(sutil.Degrees(180)), Vector{-1, -1}},
+		{"RotateAroundPointOf", *(&Vector{2, 1}).RotateAroundPointOf(&Vector{1, 1}, sutil.Degrees(90)), Vector{1, 2}},
+	}
+	for _, c := range cases {
+		if !c.got.ApproxEqual(&c.want, 1e-5) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if a := (&Vector{0, -3}).AngleRadians().Deg(); !approx(float32(a), -90, 1e-3) {
+		t.Errorf("AngleRadians = %v deg, want -90", a)
+	}
+}
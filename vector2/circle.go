@@ -0,0 +1,26 @@
+/*
+ * 圆
+ */
+package vector2
+
+type Circle struct {
+	Center Vector
+	Radius float32
+}
+
+func NewCircle(center Vector, radius float32) *Circle {
+	return &Circle{center, radius}
+}
+
+// 点包含
+func (t *Circle) ContainsPoint(pt *Vector) bool {
+	d := Sub(pt, &t.Center)
+	return d.LengthSqr() <= t.Radius*t.Radius
+}
+
+// 相交
+func (t *Circle) Intersects(o *Circle) bool {
+	d := Sub(&t.Center, &o.Center)
+	r := t.Radius + o.Radius
+	return d.LengthSqr() <= r*r
+}
@@ -0,0 +1,282 @@
+/*
+ * 2D GJK/EPA  与vector3中的实现保持一致
+ */
+package vector2
+
+import "math"
+
+const (
+	kGJKMaxIter   = 64
+	kGJKRelError  = 1e-6  // 收敛判定的相对误差
+	kGJKTolerance = 1e-12 // 距离平方小于该值视为相交
+	kEPAMaxIter   = 64
+	kEPATolerance = 1e-4
+)
+
+// Minkowski差 a-b 上的支撑点, 同时记录a,b上的原始点用于求最近点对
+type supportPoint struct {
+	w Vector // a - b
+	a Vector
+	b Vector
+}
+
+func minkowskiSupport(a, b Supporter, dir *Vector) supportPoint {
+	pa := a.Support(*dir)
+	pb := b.Support(dir.Inverted())
+	return supportPoint{Sub(&pa, &pb), pa, pb}
+}
+
+// 单纯形, bary为当前最近点的重心坐标
+type simplex struct {
+	pts  [3]supportPoint
+	bary [3]float32
+	n    int
+}
+
+func simplexOf(pts ...supportPoint) simplex {
+	var s simplex
+	s.n = copy(s.pts[:], pts)
+	return s
+}
+
+func (t *simplex) closest() Vector {
+	var v Vector
+	for i := 0; i < t.n; i++ {
+		w := t.pts[i].w.Scaled(t.bary[i])
+		v.Add(&w)
+	}
+	return v
+}
+
+func (t *simplex) witness() (pa, pb Vector) {
+	for i := 0; i < t.n; i++ {
+		a := t.pts[i].a.Scaled(t.bary[i])
+		b := t.pts[i].b.Scaled(t.bary[i])
+		pa.Add(&a)
+		pb.Add(&b)
+	}
+	return
+}
+
+func (t *simplex) contains(w *Vector) bool {
+	for i := 0; i < t.n; i++ {
+		if t.pts[i].w == *w {
+			return true
+		}
+	}
+	return false
+}
+
+// 求离原点最近的点并约简单纯形, 原点在三角形内时返回true
+func (t *simplex) solve() bool {
+	switch t.n {
+	case 1:
+		t.bary[0] = 1
+	case 2:
+		*t = solveSegment(t.pts[0], t.pts[1])
+	case 3:
+		var inside bool
+		*t, inside = solveTriangle(t.pts[0], t.pts[1], t.pts[2])
+		return inside
+	}
+	return false
+}
+
+func solveSegment(p0, p1 supportPoint) simplex {
+	ab := Sub(&p1.w, &p0.w)
+	l := Dot(&ab, &ab)
+	var u float32
+	if l > 0 {
+		u = -Dot(&p0.w, &ab) / l
+	}
+	if u <= 0 {
+		s := simplexOf(p0)
+		s.bary[0] = 1
+		return s
+	}
+	if u >= 1 {
+		s := simplexOf(p1)
+		s.bary[0] = 1
+		return s
+	}
+	s := simplexOf(p0, p1)
+	s.bary[0] = 1 - u
+	s.bary[1] = u
+	return s
+}
+
+func solveTriangle(p0, p1, p2 supportPoint) (simplex, bool) {
+	c0 := perpDot(&p0.w, &p1.w)
+	c1 := perpDot(&p1.w, &p2.w)
+	c2 := perpDot(&p2.w, &p0.w)
+	// 三条边看原点的方向一致, 原点在内部
+	if (c0 >= 0 && c1 >= 0 && c2 >= 0) || (c0 <= 0 && c1 <= 0 && c2 <= 0) {
+		area := c0 + c1 + c2
+		if area != 0 {
+			s := simplexOf(p0, p1, p2)
+			s.bary[0] = c1 / area
+			s.bary[1] = c2 / area
+			s.bary[2] = c0 / area
+			return s, true
+		}
+	}
+
+	best := solveSegment(p0, p1)
+	bv := best.closest()
+	for _, s := range [2]simplex{solveSegment(p1, p2), solveSegment(p2, p0)} {
+		if v := s.closest(); v.LengthSqr() < bv.LengthSqr() {
+			best, bv = s, v
+		}
+	}
+	return best, false
+}
+
+// a x b 的z分量
+func perpDot(a, b *Vector) float32 {
+	return a[0]*b[1] - a[1]*b[0]
+}
+
+// 迭代求Minkowski差离原点最近的点, 相交时返回的单纯形包含原点
+// separating为true时找到分离轴即返回
+func runGJK(a, b Supporter, separating bool) (s simplex, v Vector, hit bool) {
+	s = simplexOf(minkowskiSupport(a, b, &UnitX))
+	s.bary[0] = 1
+	v = s.pts[0].w
+
+	for i := 0; i < kGJKMaxIter; i++ {
+		vv := v.LengthSqr()
+		if vv <= kGJKTolerance {
+			return s, v, true
+		}
+
+		dir := v.Inverted()
+		w := minkowskiSupport(a, b, &dir)
+		if separating && Dot(&w.w, &dir) < 0 {
+			return s, v, false
+		}
+		if vv-Dot(&v, &w.w) <= kGJKRelError*vv || s.contains(&w.w) {
+			return s, v, false
+		}
+
+		// 约简前的单纯形与v对应, 没有进展时返回它
+		l_prev := s
+		s.pts[s.n] = w
+		s.n++
+		if s.solve() {
+			return s, Zero, true
+		}
+
+		nv := s.closest()
+		if nv.LengthSqr() >= vv {
+			return l_prev, v, false
+		}
+		v = nv
+	}
+	return s, v, v.LengthSqr() <= kGJKTolerance
+}
+
+// 凸体a,b是否相交
+func GJKIntersect(a, b Supporter) bool {
+	_, _, hit := runGJK(a, b, true)
+	return hit
+}
+
+// 凸体a,b之间的距离及a,b上的最近点对, 相交时距离为0
+func GJKDistance(a, b Supporter) (pa, pb Vector, dist float32) {
+	s, v, hit := runGJK(a, b, false)
+	pa, pb = s.witness()
+	if hit {
+		return pa, pb, 0
+	}
+	return pa, pb, v.Length()
+}
+
+// 凸体a,b的穿透信息
+// normal由a指向b, 将b沿normal移动depth即可分离; 不相交或退化时ok为false
+func EPA(a, b Supporter) (normal Vector, depth float32, ok bool) {
+	s, _, hit := runGJK(a, b, false)
+	if !hit {
+		return Zero, 0, false
+	}
+	if !expandToTriangle(a, b, &s) {
+		return Zero, 0, false
+	}
+
+	// 多边形顶点保持逆时针
+	poly := make([]Vector, 0, 16)
+	if perpDot2(&s.pts[0].w, &s.pts[1].w, &s.pts[2].w) < 0 {
+		poly = append(poly, s.pts[0].w, s.pts[2].w, s.pts[1].w)
+	} else {
+		poly = append(poly, s.pts[0].w, s.pts[1].w, s.pts[2].w)
+	}
+
+	for iter := 0; ; iter++ {
+		// 离原点最近的边
+		edge := -1
+		dist := float32(math.MaxFloat32)
+		for i := range poly {
+			e := Sub(&poly[(i+1)%len(poly)], &poly[i])
+			n := Vector{e[1], -e[0]}
+			if n.IsZero() {
+				continue
+			}
+			n.Normalize()
+			if d := Dot(&n, &poly[i]); d < dist {
+				edge, dist, normal = i, d, n
+			}
+		}
+		if edge < 0 {
+			return Zero, 0, false
+		}
+		if dist < 0 {
+			dist = 0
+		}
+
+		w := minkowskiSupport(a, b, &normal)
+		if Dot(&w.w, &normal)-dist < kEPATolerance || iter >= kEPAMaxIter {
+			return normal, dist, true
+		}
+
+		// 插入新顶点
+		poly = append(poly, Vector{})
+		copy(poly[edge+2:], poly[edge+1:])
+		poly[edge+1] = w.w
+	}
+}
+
+// (b-a) x (c-a)
+func perpDot2(a, b, c *Vector) float32 {
+	ab := Sub(b, a)
+	ac := Sub(c, a)
+	return perpDot(&ab, &ac)
+}
+
+// GJK结束时单纯形可能不足三个点, 补成包含原点的三角形
+func expandToTriangle(a, b Supporter, s *simplex) bool {
+	if s.n == 1 {
+		for _, dir := range [4]Vector{UnitX, UnitY, {-1, 0}, {0, -1}} {
+			w := minkowskiSupport(a, b, &dir)
+			d := Sub(&w.w, &s.pts[0].w)
+			if d.LengthSqr() > kGJKTolerance {
+				s.pts[1] = w
+				s.n = 2
+				break
+			}
+		}
+	}
+
+	if s.n == 2 {
+		e := Sub(&s.pts[1].w, &s.pts[0].w)
+		n := Vector{-e[1], e[0]}
+		for _, dir := range [2]Vector{n, n.Inverted()} {
+			w := minkowskiSupport(a, b, &dir)
+			if d := perpDot2(&s.pts[0].w, &s.pts[1].w, &w.w); d*d > kGJKTolerance*e.LengthSqr() {
+				s.pts[2] = w
+				s.n = 3
+				break
+			}
+		}
+	}
+
+	return s.n == 3
+}
@@ -0,0 +1,66 @@
+package vector2
+
+import (
+	"math"
+	"testing"
+)
+
+func approx(a, b, tol float32) bool {
+	d := a - b
+	return d <= tol && d >= -tol
+}
+
+func TestGJKDistance(t *testing.T) {
+	unit := NewRect(Vector{0, 0}, Vector{1, 1})
+	cases := []struct {
+		name   string
+		a, b   Supporter
+		dist   float32
+		pa, pb Vector
+	}{
+		{"circle-circle", NewCircle(Vector{0, 0}, 1), NewCircle(Vector{0, 4}, 1),
+			2, Vector{0, 1}, Vector{0, 3}},
+		{"rect-circle", unit, NewCircle(Vector{3, 0.5}, 1),
+			1, Vector{1, 0.5}, Vector{2, 0.5}},
+		{"rect-rect corner", unit, NewRect(Vector{2, 2}, Vector{3, 3}),
+			float32(math.Sqrt2), Vector{1, 1}, Vector{2, 2}},
+		{"polygon-rect", NewPolygon(Vector{-2, 0}, Vector{-1, 0}, Vector{-1.5, 1}), unit,
+			1, Vector{-1, 0}, Vector{0, 0}},
+	}
+	for _, c := range cases {
+		pa, pb, d := GJKDistance(c.a, c.b)
+		if !approx(d, c.dist, 1e-3) {
+			t.Errorf("%s: dist = %v, want %v", c.name, d, c.dist)
+		}
+		if !pa.ApproxEqual(&c.pa, 1e-3) || !pb.ApproxEqual(&c.pb, 1e-3) {
+			t.Errorf("%s: witness = %v %v, want %v %v", c.name, pa, pb, c.pa, c.pb)
+		}
+		if GJKIntersect(c.a, c.b) {
+			t.Errorf("%s: GJKIntersect = true", c.name)
+		}
+	}
+}
+
+func TestEPA(t *testing.T) {
+	unit := NewRect(Vector{0, 0}, Vector{1, 1})
+	cases := []struct {
+		name   string
+		a, b   Supporter
+		depth  float32
+		normal Vector
+		tol    float32
+	}{
+		{"rect-rect", unit, NewRect(Vector{0.1, 0.7}, Vector{1.1, 1.7}), 0.3, Vector{0, 1}, 1e-4},
+		{"contained", NewRect(Vector{0, 0}, Vector{4, 2}), NewRect(Vector{1, 0.5}, Vector{2, 1.2}), 1.2, Vector{0, -1}, 1e-4},
+		{"circle-circle", NewCircle(Vector{0, 0}, 1), NewCircle(Vector{1.5, 0}, 1), 0.5, Vector{1, 0}, 1e-2},
+	}
+	for _, c := range cases {
+		if _, _, d := GJKDistance(c.a, c.b); d != 0 {
+			t.Errorf("%s: GJKDistance = %v, want 0", c.name, d)
+		}
+		n, d, ok := EPA(c.a, c.b)
+		if !ok || !approx(d, c.depth, c.tol) || !n.ApproxEqual(&c.normal, c.tol*10) {
+			t.Errorf("%s: EPA = %v %v %v, want %v %v", c.name, n, d, ok, c.normal, c.depth)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+/*
+ * 凸多边形  以顶点集表示
+ */
+package vector2
+
+type Polygon []Vector
+
+func NewPolygon(points ...Vector) Polygon {
+	return Polygon(points)
+}
+
+// 包围rect
+func (t Polygon) Bounds() Rect {
+	if len(t) == 0 {
+		return Rect{}
+	}
+	r := Rect{t[0], t[0]}
+	for i := 1; i < len(t); i++ {
+		r.Min = Min(&r.Min, &t[i])
+		r.Max = Max(&r.Max, &t[i])
+	}
+	return r
+}
@@ -0,0 +1,43 @@
+/*
+ * 凸体支撑函数  供GJK/EPA使用
+ */
+package vector2
+
+// 凸体: Support返回dir方向上最远的点 (dir不要求归一化)
+type Supporter interface {
+	Support(dir Vector) Vector
+}
+
+func (t *Rect) Support(dir Vector) Vector {
+	var res Vector
+	for i := range dir {
+		if dir[i] >= 0 {
+			res[i] = t.Max[i]
+		} else {
+			res[i] = t.Min[i]
+		}
+	}
+	return res
+}
+
+func (t *Circle) Support(dir Vector) Vector {
+	if dir.IsZero() {
+		return Vector{t.Center[0] + t.Radius, t.Center[1]}
+	}
+	d := dir.Normalized()
+	return Add(&t.Center, d.Scale(t.Radius))
+}
+
+func (t Polygon) Support(dir Vector) Vector {
+	if len(t) == 0 {
+		return Zero
+	}
+	res := t[0]
+	best := Dot(&dir, &t[0])
+	for i := 1; i < len(t); i++ {
+		if d := Dot(&dir, &t[i]); d > best {
+			res, best = t[i], d
+		}
+	}
+	return res
+}
@@ -11,6 +11,7 @@ import (
 	"math"
 
 	"github.com/tinysss/smath/generic"
+	"github.com/tinysss/smath/sutil"
 )
 
 type Vector [2]float32
@@ -152,11 +153,24 @@ func (t *Vector) Rotated(angle float32) Vector {
 	}
 }
 
+// 带单位的版本, 如 RotateOf(sutil.Degrees(30))
+func (t *Vector) RotateOf(angle sutil.Angle) *Vector {
+	return t.Rotate(float32(angle.Rad()))
+}
+
+func (t *Vector) RotatedOf(angle sutil.Angle) Vector {
+	return t.Rotated(float32(angle.Rad()))
+}
+
 // >0逆时针 绕任意点旋转
 func (t *Vector) RotateAroundPoint(point *Vector, angle float32) *Vector {
 	return t.Sub(point).Rotate(angle).Add(point)
 }
 
+func (t *Vector) RotateAroundPointOf(point *Vector, angle sutil.Angle) *Vector {
+	return t.RotateAroundPoint(point, float32(angle.Rad()))
+}
+
 // 逆时针旋转90度，不用Rotate方法是为了加速运算
 func (t *Vector) Rotate90degLeft() *Vector {
 	l_temp := t[0]
@@ -178,6 +192,17 @@ func (t *Vector) Angle() float32 {
 	return float32(math.Atan2(float64(t[1]), float64(t[0])))
 }
 
+// 相对于x轴的弧度, 返回[-PI,PI]
+func (t *Vector) AngleRadians() sutil.Radians {
+	return sutil.Radians(t.Angle())
+}
+
+// angle方向的单位向量
+func FromAngle(angle sutil.Angle) Vector {
+	s, c := angle.Rad().Sincos()
+	return Vector{c, s}
+}
+
 // 限定在　min max之间
 func (t *Vector) Clamp(min, max *Vector) *Vector {
 	for i := range t {
@@ -209,6 +234,16 @@ func (t *Vector) Clamped01() Vector {
 	return res
 }
 
+// 各分量在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual)
+func (t *Vector) ApproxEqual(o *Vector, tol float32) bool {
+	for i := range t {
+		if !sutil.AlmostEqual(t[i], o[i], tol, tol) {
+			return false
+		}
+	}
+	return true
+}
+
 func Add(a, b *Vector) Vector {
 	return Vector{a[0] + b[0], a[1] + b[1]}
 }
@@ -0,0 +1,125 @@
+/*
+ * 整数rect  Min/Max均为包含的格子坐标, Min>Max的分量表示空
+ */
+package vector2i
+
+import (
+	"github.com/tinysss/smath/vector2"
+)
+
+type Rect struct {
+	Min Vector
+	Max Vector
+}
+
+func NewRect(min, max Vector) *Rect {
+	return &Rect{min, max}
+}
+
+// 覆盖浮点rect的所有格子 (格子(x,y)占[x,x+1)x[y,y+1))
+// 只在边界上接触的格子不算, 即RectCovering(x.ToRect())还原x; 坐标超出int32范围时截断
+func RectCovering(r *vector2.Rect) Rect {
+	return Rect{FromVectorFloor(&r.Min), Vector{cellMax(r.Max[0]), cellMax(r.Max[1])}}
+}
+
+// 格子区域对应的浮点rect  [Min, Max+1]
+func (t *Rect) ToRect() vector2.Rect {
+	return vector2.Rect{
+		Min: t.Min.Vector(),
+		Max: vector2.Vector{float32(t.Max[0]) + 1, float32(t.Max[1]) + 1},
+	}
+}
+
+func (t *Rect) IsEmpty() bool {
+	return t.Min[0] > t.Max[0] || t.Min[1] > t.Max[1]
+}
+
+// 每个方向的格子数, 空rect为0
+func (t *Rect) Size() Vector {
+	if t.IsEmpty() {
+		return Zero
+	}
+	return Vector{t.Max[0] - t.Min[0] + 1, t.Max[1] - t.Min[1] + 1}
+}
+
+// 格子总数
+func (t *Rect) Area() int64 {
+	if t.IsEmpty() {
+		return 0
+	}
+	return (int64(t.Max[0]) - int64(t.Min[0]) + 1) * (int64(t.Max[1]) - int64(t.Min[1]) + 1)
+}
+
+// 点包含
+func (t *Rect) ContainsPoint(pt *Vector) bool {
+	return pt[0] >= t.Min[0] && pt[0] <= t.Max[0] &&
+		pt[1] >= t.Min[1] && pt[1] <= t.Max[1]
+}
+
+// rect包含
+func (t *Rect) Contains(o *Rect) bool {
+	return o.Min[0] >= t.Min[0] && o.Max[0] <= t.Max[0] &&
+		o.Min[1] >= t.Min[1] && o.Max[1] <= t.Max[1]
+}
+
+// rect相交 (共享格子)
+func (t *Rect) Intersects(o *Rect) bool {
+	return t.Min[0] <= o.Max[0] && t.Max[0] >= o.Min[0] &&
+		t.Min[1] <= o.Max[1] && t.Max[1] >= o.Min[1]
+}
+
+// 相交区域, 不相交时ok=false
+func (t *Rect) Intersection(o *Rect) (r Rect, ok bool) {
+	r = Rect{Max(&t.Min, &o.Min), Min(&t.Max, &o.Max)}
+	return r, !r.IsEmpty()
+}
+
+// 合并放大rect
+func (t *Rect) Join(o *Rect) {
+	t.Min = Min(&t.Min, &o.Min)
+	t.Max = Max(&t.Max, &o.Max)
+}
+
+// 合并放大rect
+func Joined(a, o *Rect) *Rect {
+	return &Rect{Min(&a.Min, &o.Min), Max(&a.Max, &o.Max)}
+}
+
+// 向四周扩n格, n<0为收缩
+func (t *Rect) Expand(n int32) *Rect {
+	t.Min[0] -= n
+	t.Min[1] -= n
+	t.Max[0] += n
+	t.Max[1] += n
+	return t
+}
+
+// rect内离pt最近的格子
+func (t *Rect) ClosestPoint(pt *Vector) Vector {
+	return pt.Clamped(&t.Min, &t.Max)
+}
+
+// 行优先的线性下标 (x变化最快), 用于平铺数组; pt需在rect内
+func (t *Rect) Index(pt *Vector) int {
+	w := int(t.Max[0]) - int(t.Min[0]) + 1
+	return (int(pt[1])-int(t.Min[1]))*w + int(pt[0]) - int(t.Min[0])
+}
+
+// Index的逆
+func (t *Rect) PointAt(index int) Vector {
+	w := int(t.Max[0]) - int(t.Min[0]) + 1
+	return Vector{t.Min[0] + int32(index%w), t.Min[1] + int32(index/w)}
+}
+
+// 按行优先遍历所有格子, fn返回false时停止
+// 返回是否遍历完全部格子
+func (t *Rect) Cells(fn func(pt Vector) bool) bool {
+	for y := int64(t.Min[1]); y <= int64(t.Max[1]); y++ {
+		for x := int64(t.Min[0]); x <= int64(t.Max[0]); x++ {
+			if !fn(Vector{int32(x), int32(y)}) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package vector2i
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tinysss/smath/vector2"
+)
+
+func TestRectCovering(t *testing.T) {
+	cases := []struct {
+		name string
+		r    vector2.Rect
+		want Rect
+	}{
+		{"inside one cell", vector2.Rect{Min: vector2.Vector{0.2, 0.2}, Max: vector2.Vector{0.8, 0.9}}, Rect{Vector{0, 0}, Vector{0, 0}}},
+		{"negative", vector2.Rect{Min: vector2.Vector{-1.5, -0.5}, Max: vector2.Vector{0.5, 0.5}}, Rect{Vector{-2, -1}, Vector{0, 0}}},
+		// 终点正好在整数上, 只接触的格子不算
+		{"ends on integer", vector2.Rect{Min: vector2.Vector{0, 0}, Max: vector2.Vector{2, 1}}, Rect{Vector{0, 0}, Vector{1, 0}}},
+		{"point on integer", vector2.Rect{Min: vector2.Vector{1, 1}, Max: vector2.Vector{1, 1}}, Rect{Vector{1, 1}, Vector{0, 0}}},
+		{"clamped", vector2.Rect{Min: vector2.Vector{-1e10, 0}, Max: vector2.Vector{1e10, 1}},
+			Rect{Vector{math.MinInt32, 0}, Vector{math.MaxInt32, 0}}},
+	}
+	for _, c := range cases {
+		if got := RectCovering(&c.r); got != c.want {
+			t.Errorf("%s: RectCovering = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestRectRoundTrip(t *testing.T) {
+	rects := []Rect{
+		{Vector{0, 0}, Vector{0, 0}},
+		{Vector{-3, 2}, Vector{4, 7}},
+		{Vector{-100, -100}, Vector{-99, -1}},
+	}
+	for _, r := range rects {
+		f := r.ToRect()
+		if got := RectCovering(&f); got != r {
+			t.Errorf("RectCovering(%v.ToRect()) = %v", r, got)
+		}
+	}
+}
+
+func TestRectOps(t *testing.T) {
+	r := Rect{Vector{0, 0}, Vector{2, 1}}
+	empty := Rect{Vector{1, 0}, Vector{0, 0}}
+	if r.Size() != (Vector{3, 2}) || r.Area() != 6 || r.IsEmpty() {
+		t.Errorf("Size = %v, Area = %d", r.Size(), r.Area())
+	}
+	if !empty.IsEmpty() || empty.Size() != Zero || empty.Area() != 0 {
+		t.Errorf("empty: Size = %v, Area = %d", empty.Size(), empty.Area())
+	}
+
+	cases := []struct {
+		o          Rect
+		intersects bool
+		inter      Rect
+		contains   bool
+	}{
+		{Rect{Vector{2, 1}, Vector{5, 5}}, true, Rect{Vector{2, 1}, Vector{2, 1}}, false},
+		{Rect{Vector{3, 0}, Vector{5, 5}}, false, Rect{}, false},
+		{Rect{Vector{1, 0}, Vector{2, 0}}, true, Rect{Vector{1, 0}, Vector{2, 0}}, true},
+	}
+	for _, c := range cases {
+		if got := r.Intersects(&c.o); got != c.intersects {
+			t.Errorf("Intersects(%v) = %v", c.o, got)
+		}
+		inter, ok := r.Intersection(&c.o)
+		if ok != c.intersects || ok && inter != c.inter {
+			t.Errorf("Intersection(%v) = %v %v, want %v", c.o, inter, ok, c.inter)
+		}
+		if got := r.Contains(&c.o); got != c.contains {
+			t.Errorf("Contains(%v) = %v", c.o, got)
+		}
+	}
+
+	pt := Vector{5, -3}
+	if got := r.ClosestPoint(&pt); got != (Vector{2, 0}) {
+		t.Errorf("ClosestPoint = %v", got)
+	}
+	j := *Joined(&r, &Rect{Vector{-1, 3}, Vector{0, 4}})
+	if j != (Rect{Vector{-1, 0}, Vector{2, 4}}) {
+		t.Errorf("Joined = %v", j)
+	}
+	if e := r; *e.Expand(1) != (Rect{Vector{-1, -1}, Vector{3, 2}}) {
+		t.Errorf("Expand = %v", e)
+	}
+}
+
+func TestRectCells(t *testing.T) {
+	r := Rect{Vector{-2, 3}, Vector{1, 5}}
+	i := 0
+	done := r.Cells(func(pt Vector) bool {
+		if !r.ContainsPoint(&pt) || r.Index(&pt) != i || r.PointAt(i) != pt {
+			t.Errorf("cell %d: %v, Index = %d, PointAt = %v", i, pt, r.Index(&pt), r.PointAt(i))
+		}
+		i++
+		return true
+	})
+	if !done || int64(i) != r.Area() {
+		t.Errorf("Cells visited %d of %d, done = %v", i, r.Area(), done)
+	}
+
+	n := 0
+	if r.Cells(func(Vector) bool { n++; return n < 4 }) || n != 4 {
+		t.Errorf("stopped Cells visited %d", n)
+	}
+	empty := Rect{Vector{0, 0}, Vector{-1, 0}}
+	if !empty.Cells(func(Vector) bool { t.Error("empty rect has cells"); return true }) {
+		t.Error("empty Cells = false")
+	}
+}
@@ -0,0 +1,326 @@
+/*
+ * int32 2D向量封装  用于瓦片/网格/区块坐标
+ *   距离类结果用int64, 避免分量相乘溢出
+ */
+package vector2i
+
+import (
+	"math"
+
+	"github.com/tinysss/smath/vector2"
+)
+
+type Vector [2]int32
+
+var (
+	Zero   = Vector{}
+	UnitX  = Vector{1, 0}
+	UnitY  = Vector{0, 1}
+	UnitXY = Vector{1, 1}
+	MinVal = Vector{math.MinInt32, math.MinInt32}
+	MaxVal = Vector{math.MaxInt32, math.MaxInt32}
+
+	// 4邻域 (右 上 左 下)
+	Dirs4 = [4]Vector{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}
+	// 8邻域 逆时针
+	Dirs8 = [8]Vector{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+)
+
+func New(x, y int32) *Vector {
+	return &Vector{x, y}
+}
+
+// 向下取整, 超出int32范围时截断 (下同)
+func FromVectorFloor(v *vector2.Vector) Vector {
+	return Vector{floor(v[0]), floor(v[1])}
+}
+
+// 四舍五入(0.5远离0)
+func FromVectorRound(v *vector2.Vector) Vector {
+	return Vector{round(v[0]), round(v[1])}
+}
+
+// 向上取整
+func FromVectorCeil(v *vector2.Vector) Vector {
+	return Vector{ceil(v[0]), ceil(v[1])}
+}
+
+// 转float向量
+func (t *Vector) Vector() vector2.Vector {
+	return vector2.Vector{float32(t[0]), float32(t[1])}
+}
+
+// 整数向量精确比较; 与浮点向量比较时用 t.Vector() 再ApproxEqual
+func (t *Vector) Equal(o *Vector) bool {
+	return *t == *o
+}
+
+// 格子中心 (x+0.5, y+0.5)
+func (t *Vector) Center() vector2.Vector {
+	return vector2.Vector{float32(t[0]) + 0.5, float32(t[1]) + 0.5}
+}
+
+func (t *Vector) X() int32 {
+	return t[0]
+}
+func (t *Vector) Y() int32 {
+	return t[1]
+}
+
+func (t *Vector) IsZero() bool {
+	return t[0] == 0 && t[1] == 0
+}
+
+func (t *Vector) Add(v *Vector) *Vector {
+	t[0] += v[0]
+	t[1] += v[1]
+	return t
+}
+
+func (t *Vector) Sub(v *Vector) *Vector {
+	t[0] -= v[0]
+	t[1] -= v[1]
+	return t
+}
+
+func (t *Vector) Mul(v *Vector) *Vector {
+	t[0] *= v[0]
+	t[1] *= v[1]
+	return t
+}
+
+// 缩放自身
+func (t *Vector) Scale(ratio int32) *Vector {
+	t[0] *= ratio
+	t[1] *= ratio
+	return t
+}
+
+// 返回缩放自身的拷贝，自身不受影响
+func (t *Vector) Scaled(ratio int32) Vector {
+	return Vector{t[0] * ratio, t[1] * ratio}
+}
+
+// 相反向量
+func (t *Vector) Invert() *Vector {
+	t[0] = -t[0]
+	t[1] = -t[1]
+	return t
+}
+
+func (t *Vector) Inverted() Vector {
+	return Vector{-t[0], -t[1]}
+}
+
+func (t *Vector) Abs() *Vector {
+	t[0] = abs(t[0])
+	t[1] = abs(t[1])
+	return t
+}
+
+func (t *Vector) Absed() Vector {
+	return Vector{abs(t[0]), abs(t[1])}
+}
+
+// 向下取整的除法, 负坐标也能正确映射到区块  如 -1 / 16 = -1
+func (t *Vector) DivFloor(n int32) *Vector {
+	t[0] = divFloor(t[0], n)
+	t[1] = divFloor(t[1], n)
+	return t
+}
+
+func (t *Vector) DivFloored(n int32) Vector {
+	return Vector{divFloor(t[0], n), divFloor(t[1], n)}
+}
+
+// 与DivFloor配套的取模, 结果在[0,n)  即区块内的局部坐标
+func (t *Vector) ModFloor(n int32) *Vector {
+	t[0] = modFloor(t[0], n)
+	t[1] = modFloor(t[1], n)
+	return t
+}
+
+func (t *Vector) ModFloored(n int32) Vector {
+	return Vector{modFloor(t[0], n), modFloor(t[1], n)}
+}
+
+func (t *Vector) Clamp(min, max *Vector) *Vector {
+	for i := range t {
+		if t[i] < min[i] {
+			t[i] = min[i]
+		} else if t[i] > max[i] {
+			t[i] = max[i]
+		}
+	}
+	return t
+}
+
+func (t *Vector) Clamped(min, max *Vector) Vector {
+	l_temp := *t
+	return *l_temp.Clamp(min, max)
+}
+
+// 逆时针旋转90度
+func (t *Vector) Rotate90degLeft() *Vector {
+	t[0], t[1] = -t[1], t[0]
+	return t
+}
+
+// 顺时针旋转90度
+func (t *Vector) Rotate90degRight() *Vector {
+	t[0], t[1] = t[1], -t[0]
+	return t
+}
+
+// 超出int64时(分量接近±2^31)返回math.MaxInt64
+func (t *Vector) LengthSqr() int64 {
+	return sumSqr(absDiff(t[0], 0), absDiff(t[1], 0))
+}
+
+func (t *Vector) Length() float32 {
+	x, y := float64(t[0]), float64(t[1])
+	return float32(math.Sqrt(x*x + y*y))
+}
+
+func Add(a, b *Vector) Vector {
+	return Vector{a[0] + b[0], a[1] + b[1]}
+}
+
+func Sub(a, b *Vector) Vector {
+	return Vector{a[0] - b[0], a[1] - b[1]}
+}
+
+func Mul(a, b *Vector) Vector {
+	return Vector{a[0] * b[0], a[1] * b[1]}
+}
+
+func Dot(a, b *Vector) int64 {
+	return int64(a[0])*int64(b[0]) + int64(a[1])*int64(b[1])
+}
+
+// 2D叉积 (z分量)  >0表示b在a的逆时针方向
+func Cross(a, b *Vector) int64 {
+	return int64(a[0])*int64(b[1]) - int64(a[1])*int64(b[0])
+}
+
+// 分量差按int64计算, 不会回绕; 结果超出int64时(分量差超过约3e9)返回math.MaxInt64
+func SquareDistance(a, b *Vector) int64 {
+	return sumSqr(absDiff(a[0], b[0]), absDiff(a[1], b[1]))
+}
+
+func Distance(a, b *Vector) float32 {
+	dx, dy := float64(absDiff(a[0], b[0])), float64(absDiff(a[1], b[1]))
+	return float32(math.Sqrt(dx*dx + dy*dy))
+}
+
+// 曼哈顿距离 |dx|+|dy|  4邻域步数
+func Manhattan(a, b *Vector) int64 {
+	return absDiff(a[0], b[0]) + absDiff(a[1], b[1])
+}
+
+// 切比雪夫距离 max(|dx|,|dy|)  8邻域步数
+func Chebyshev(a, b *Vector) int64 {
+	dx, dy := absDiff(a[0], b[0]), absDiff(a[1], b[1])
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+// 两个分量最小值构成的新向量
+func Min(a, b *Vector) Vector {
+	l_min := *a
+	if l_min[0] > b[0] {
+		l_min[0] = b[0]
+	}
+	if l_min[1] > b[1] {
+		l_min[1] = b[1]
+	}
+	return l_min
+}
+
+// 两个分量最大值构成的新向量
+func Max(a, b *Vector) Vector {
+	l_max := *a
+	if l_max[0] < b[0] {
+		l_max[0] = b[0]
+	}
+	if l_max[1] < b[1] {
+		l_max[1] = b[1]
+	}
+	return l_max
+}
+
+func abs(a int32) int32 {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// 非负数的平方和, 溢出时饱和为math.MaxInt64
+func sumSqr(ds ...int64) int64 {
+	var l_sum uint64
+	for _, d := range ds {
+		sq := uint64(d) * uint64(d)
+		l_sum += sq
+		if l_sum < sq || l_sum > math.MaxInt64 {
+			return math.MaxInt64
+		}
+	}
+	return int64(l_sum)
+}
+
+func absDiff(a, b int32) int64 {
+	d := int64(a) - int64(b)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func divFloor(a, n int32) int32 {
+	q := a / n
+	if (a%n != 0) && ((a < 0) != (n < 0)) {
+		q--
+	}
+	return q
+}
+
+func modFloor(a, n int32) int32 {
+	m := a % n
+	if m != 0 && ((m < 0) != (n < 0)) {
+		m += n
+	}
+	return m
+}
+
+func floor(f float32) int32 {
+	return clampInt32(math.Floor(float64(f)))
+}
+
+func round(f float32) int32 {
+	return clampInt32(math.Round(float64(f)))
+}
+
+func ceil(f float32) int32 {
+	return clampInt32(math.Ceil(float64(f)))
+}
+
+// 半开格子[x,x+1)下, 上界f所在的最后一个格子
+func cellMax(f float32) int32 {
+	return clampInt32(math.Ceil(float64(f)) - 1)
+}
+
+// 超出int32范围的值截断, 避免转换回绕; NaN为0
+func clampInt32(f float64) int32 {
+	switch {
+	case f != f:
+		return 0
+	case f <= math.MinInt32:
+		return math.MinInt32
+	case f >= math.MaxInt32:
+		return math.MaxInt32
+	}
+	return int32(f)
+}
@@ -0,0 +1,158 @@
+package vector2i
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tinysss/smath/vector2"
+)
+
+func TestFromVector(t *testing.T) {
+	big := float32(1e10)
+	cases := []struct {
+		v                  vector2.Vector
+		floor, round, ceil Vector
+	}{
+		{vector2.Vector{0, 0}, Vector{0, 0}, Vector{0, 0}, Vector{0, 0}},
+		{vector2.Vector{-0.5, 1.5}, Vector{-1, 1}, Vector{-1, 2}, Vector{0, 2}},
+		{vector2.Vector{2.2, -2.7}, Vector{2, -3}, Vector{2, -3}, Vector{3, -2}},
+		// 超出int32范围截断而不回绕
+		{vector2.Vector{big, -big}, Vector{math.MaxInt32, math.MinInt32}, Vector{math.MaxInt32, math.MinInt32}, Vector{math.MaxInt32, math.MinInt32}},
+		{vector2.Vector{float32(math.Inf(-1)), float32(math.NaN())}, Vector{math.MinInt32, 0}, Vector{math.MinInt32, 0}, Vector{math.MinInt32, 0}},
+	}
+	for _, c := range cases {
+		if got := FromVectorFloor(&c.v); got != c.floor {
+			t.Errorf("FromVectorFloor(%v) = %v, want %v", c.v, got, c.floor)
+		}
+		if got := FromVectorRound(&c.v); got != c.round {
+			t.Errorf("FromVectorRound(%v) = %v, want %v", c.v, got, c.round)
+		}
+		if got := FromVectorCeil(&c.v); got != c.ceil {
+			t.Errorf("FromVectorCeil(%v) = %v, want %v", c.v, got, c.ceil)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	v := Vector{3, -4}
+	cases := []struct {
+		o    Vector
+		want bool
+	}{
+		{Vector{3, -4}, true},
+		{Vector{3, 4}, false},
+		{Vector{-4, 3}, false},
+	}
+	for _, c := range cases {
+		if got := v.Equal(&c.o); got != c.want {
+			t.Errorf("Equal(%v) = %v, want %v", c.o, got, c.want)
+		}
+	}
+	// 与浮点向量比较先转float
+	if f := v.Vector(); !f.ApproxEqual(&vector2.Vector{3.0001, -4}, 1e-3) {
+		t.Errorf("Vector() = %v", f)
+	}
+	if c := v.Center(); c != (vector2.Vector{3.5, -3.5}) {
+		t.Errorf("Center = %v", c)
+	}
+}
+
+func TestDivModFloor(t *testing.T) {
+	cases := []struct {
+		v, div, mod Vector
+		n           int32
+	}{
+		{Vector{0, 15}, Vector{0, 0}, Vector{0, 15}, 16},
+		{Vector{-1, -16}, Vector{-1, -1}, Vector{15, 0}, 16},
+		{Vector{-17, 33}, Vector{-2, 2}, Vector{15, 1}, 16},
+		{Vector{7, -7}, Vector{-3, 2}, Vector{-2, -1}, -3},
+	}
+	for _, c := range cases {
+		if got := c.v.DivFloored(c.n); got != c.div {
+			t.Errorf("%v.DivFloored(%d) = %v, want %v", c.v, c.n, got, c.div)
+		}
+		if got := c.v.ModFloored(c.n); got != c.mod {
+			t.Errorf("%v.ModFloored(%d) = %v, want %v", c.v, c.n, got, c.mod)
+		}
+		// div*n + mod还原v
+		back := c.div.Scaled(c.n)
+		if back.Add(&c.mod); back != c.v {
+			t.Errorf("%v: div*n+mod = %v", c.v, back)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	cases := []struct {
+		a, b                 Vector
+		sqr, manhattan, cheb int64
+		dot, cross           int64
+	}{
+		{Vector{0, 0}, Vector{3, 4}, 25, 7, 4, 0, 0},
+		{Vector{1, -2}, Vector{-3, 4}, 52, 10, 6, -11, -2},
+		// 分量相乘超出int32
+		{Vector{-40000, 0}, Vector{0, 50000}, 4100000000, 90000, 50000, 0, -2000000000},
+	}
+	for _, c := range cases {
+		if got := SquareDistance(&c.a, &c.b); got != c.sqr {
+			t.Errorf("SquareDistance(%v, %v) = %d, want %d", c.a, c.b, got, c.sqr)
+		}
+		if got := Manhattan(&c.a, &c.b); got != c.manhattan {
+			t.Errorf("Manhattan(%v, %v) = %d, want %d", c.a, c.b, got, c.manhattan)
+		}
+		if got := Chebyshev(&c.a, &c.b); got != c.cheb {
+			t.Errorf("Chebyshev(%v, %v) = %d, want %d", c.a, c.b, got, c.cheb)
+		}
+		if got := Dot(&c.a, &c.b); got != c.dot {
+			t.Errorf("Dot(%v, %v) = %d, want %d", c.a, c.b, got, c.dot)
+		}
+		if got := Cross(&c.a, &c.b); got != c.cross {
+			t.Errorf("Cross(%v, %v) = %d, want %d", c.a, c.b, got, c.cross)
+		}
+	}
+}
+
+func TestRotate90(t *testing.T) {
+	for i, d := range Dirs4 {
+		l := d
+		if l.Rotate90degLeft(); l != Dirs4[(i+1)%4] {
+			t.Errorf("%v.Rotate90degLeft = %v", d, l)
+		}
+		r := d
+		if r.Rotate90degRight(); r != Dirs4[(i+3)%4] {
+			t.Errorf("%v.Rotate90degRight = %v", d, r)
+		}
+	}
+}
+
+// 分量差超出int32时不能回绕
+func TestDistanceExtremes(t *testing.T) {
+	cases := []struct {
+		a, b Vector
+		sqr  int64
+		dist float64
+	}{
+		{Vector{-1.5e9, 0}, Vector{1.5e9, 0}, 9e18, 3e9},
+		{Vector{0, -2e9}, Vector{0, 1e9}, 9e18, 3e9},
+		// 平方和超出int64时饱和, Distance仍然正确
+		{Vector{-2e9, 0}, Vector{2e9, 0}, math.MaxInt64, 4e9},
+		{Vector{math.MinInt32, 0}, Vector{math.MaxInt32, 0}, math.MaxInt64, 4294967295},
+		{Vector{-1.5e9, -1.5e9}, Vector{1.5e9, 1.5e9}, math.MaxInt64, 3e9 * math.Sqrt2},
+		{Vector{math.MinInt32, math.MinInt32}, Vector{math.MaxInt32, math.MaxInt32}, math.MaxInt64, 4294967295 * math.Sqrt2},
+	}
+	for _, c := range cases {
+		if got := SquareDistance(&c.a, &c.b); got != c.sqr {
+			t.Errorf("SquareDistance(%v, %v) = %d, want %d", c.a, c.b, got, c.sqr)
+		}
+		if got := Distance(&c.a, &c.b); math.Abs(float64(got)-c.dist) > c.dist*1e-7 {
+			t.Errorf("Distance(%v, %v) = %v, want %v", c.a, c.b, got, c.dist)
+		}
+	}
+	// 长度平方饱和, 长度仍然正确
+	if got := MinVal.LengthSqr(); got != math.MaxInt64 {
+		t.Errorf("MinVal.LengthSqr() = %d, want MaxInt64", got)
+	}
+	if got, want := MinVal.Length(), float32(math.Sqrt(2)*2147483648); got != want {
+		t.Errorf("MinVal.Length() = %v, want %v", got, want)
+	}
+}
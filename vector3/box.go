@@ -1,6 +1,11 @@
 package vector3
 
-import "math"
+import (
+	"fmt"
+	"math"
+
+	"github.com/tinysss/smath/generic"
+)
 
 type Box struct {
 	Min Vector
@@ -69,3 +74,205 @@ func Joined(a, o *Box) *Box {
 	joinbox.Max = Max(&a.Max, &o.Max)
 	return &joinbox
 }
+
+// 8个顶点, 下标第i位为1表示第i轴取Max
+func (t *Box) Corners() [8]Vector {
+	var l_corners [8]Vector
+	for i := range l_corners {
+		for axis := 0; axis < 3; axis++ {
+			if i&(1<<uint(axis)) != 0 {
+				l_corners[i][axis] = t.Max[axis]
+			} else {
+				l_corners[i][axis] = t.Min[axis]
+			}
+		}
+	}
+	return l_corners
+}
+
+// 尺寸 Max-Min
+func (t *Box) Size() Vector {
+	return Sub(&t.Max, &t.Min)
+}
+
+// 半尺寸
+func (t *Box) Extents() Vector {
+	l_ext := t.Size()
+	return *l_ext.Scale(0.5)
+}
+
+func (t *Box) Volume() float32 {
+	s := t.Size()
+	return s[0] * s[1] * s[2]
+}
+
+func (t *Box) SurfaceArea() float32 {
+	s := t.Size()
+	return 2 * (s[0]*s[1] + s[1]*s[2] + s[2]*s[0])
+}
+
+// Min>Max的分量表示空box
+func (t *Box) IsEmpty() bool {
+	return t.Min[0] > t.Max[0] || t.Min[1] > t.Max[1] || t.Min[2] > t.Max[2]
+}
+
+// 放大到包含pt
+func (t *Box) ExpandPoint(pt *Vector) *Box {
+	t.Min = Min(&t.Min, pt)
+	t.Max = Max(&t.Max, pt)
+	return t
+}
+
+func (t *Box) ExpandedPoint(pt *Vector) Box {
+	return Box{Min(&t.Min, pt), Max(&t.Max, pt)}
+}
+
+// 每个方向扩margin, margin<0为收缩
+func (t *Box) ExpandMargin(margin float32) *Box {
+	for i := 0; i < 3; i++ {
+		t.Min[i] -= margin
+		t.Max[i] += margin
+	}
+	return t
+}
+
+func (t *Box) ExpandedMargin(margin float32) Box {
+	l_box := *t
+	return *l_box.ExpandMargin(margin)
+}
+
+// box上离pt最近的点
+func (t *Box) ClosestPoint(pt *Vector) Vector {
+	return pt.Clamped(&t.Min, &t.Max)
+}
+
+// 点到box距离的平方, 在内部为0
+func (t *Box) SquareDistance(pt *Vector) float32 {
+	var sqDist float32
+	for i := range pt {
+		if pt[i] < t.Min[i] {
+			d := t.Min[i] - pt[i]
+			sqDist += d * d
+		} else if pt[i] > t.Max[i] {
+			d := pt[i] - t.Max[i]
+			sqDist += d * d
+		}
+	}
+	return sqDist
+}
+
+// 点到box距离
+func (t *Box) Distance(pt *Vector) float32 {
+	return float32(math.Sqrt(float64(t.SquareDistance(pt))))
+}
+
+// 线段与box求交(slab), 返回第一个交点在线段上的参数 u[0,1]
+func (t *Box) IntersectSegment(s *Segment) (u float32, ok bool) {
+	d := s.Dir()
+	tmin := float32(0)
+	tmax := float32(1)
+	for i := 0; i < 3; i++ {
+		if d[i] == 0 {
+			// 平行于slab, 起点必须在slab内
+			if s.A[i] < t.Min[i] || s.A[i] > t.Max[i] {
+				return 0, false
+			}
+			continue
+		}
+		ood := 1 / d[i]
+		t1 := (t.Min[i] - s.A[i]) * ood
+		t2 := (t.Max[i] - s.A[i]) * ood
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > tmin {
+			tmin = t1
+		}
+		if t2 < tmax {
+			tmax = t2
+		}
+		if tmin > tmax {
+			return 0, false
+		}
+	}
+	return tmin, true
+}
+
+// 线段与box的最近点对, 返回box上的点, 线段上的点及距离平方
+// 相交时两点重合于线段进入box处
+func (t *Box) ClosestPointsSegment(s *Segment) (pb, ps Vector, sqDist float32) {
+	if u, ok := t.IntersectSegment(s); ok {
+		ps = s.PointAt(u)
+		return ps, ps, 0
+	}
+
+	// 点到凸集距离的平方沿线段是凸函数, 对其导数 2(p-c)·d 二分求零点
+	d := s.Dir()
+	slope := func(u float32) float32 {
+		p := s.PointAt(u)
+		c := t.ClosestPoint(&p)
+		pc := Sub(&p, &c)
+		return Dot(&pc, &d)
+	}
+	var u float32
+	if slope(0) >= 0 {
+		u = 0
+	} else if slope(1) <= 0 {
+		u = 1
+	} else {
+		lo, hi := float32(0), float32(1)
+		for i := 0; i < 32; i++ {
+			mid := (lo + hi) * 0.5
+			if slope(mid) < 0 {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		u = (lo + hi) * 0.5
+	}
+	ps = s.PointAt(u)
+	sqDist = t.SquareDistance(&ps)
+	pb = t.ClosestPoint(&ps)
+	return pb, ps, sqDist
+}
+
+// 线段到box距离的平方
+func (t *Box) SegmentSquareDistance(s *Segment) float32 {
+	_, _, sqDist := t.ClosestPointsSegment(s)
+	return sqDist
+}
+
+// 用m变换box, 结果为变换后8个顶点的轴对齐包围盒 (Arvo)
+// m按列存储且 M*v, 支持3x3(无平移) 3x4 4x4(第3列为平移, 只用前3行), 如mat3.Mat3 mat3x4.Mat3x4 mat4.Mat4
+func (t *Box) Transform(m generic.T) *Box {
+	*t = t.Transformed(m)
+	return t
+}
+
+func (t *Box) Transformed(m generic.T) Box {
+	cols, rows := m.Cols(), m.Rows()
+	if cols < 3 || cols > 4 || (rows != 3 && rows != cols) {
+		panic(fmt.Sprintf("unsupported type. cols=%d rows=%d ", cols, rows))
+	}
+	var l_res Box
+	if cols == 4 {
+		l_res.Min = Vector{m.Get(3, 0), m.Get(3, 1), m.Get(3, 2)}
+		l_res.Max = l_res.Min
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			e := m.Get(j, i)
+			a := e * t.Min[j]
+			b := e * t.Max[j]
+			if a < b {
+				l_res.Min[i] += a
+				l_res.Max[i] += b
+			} else {
+				l_res.Min[i] += b
+				l_res.Max[i] += a
+			}
+		}
+	}
+	return l_res
+}
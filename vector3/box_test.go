@@ -0,0 +1,147 @@
+package vector3
+
+import "testing"
+
+func TestBoxDistance(t *testing.T) {
+	b := NewBox(Vector{0, 0, 0}, Vector{1, 1, 1})
+	cases := []struct {
+		pt      Vector
+		closest Vector
+		sqDist  float32
+	}{
+		{Vector{0.5, 0.5, 0.5}, Vector{0.5, 0.5, 0.5}, 0},
+		{Vector{2, 0.5, 0.5}, Vector{1, 0.5, 0.5}, 1},
+		{Vector{-1, -1, -1}, Vector{0, 0, 0}, 3},
+		{Vector{0.5, 3, -2}, Vector{0.5, 1, 0}, 8},
+	}
+	for i, c := range cases {
+		if got := b.ClosestPoint(&c.pt); got != c.closest {
+			t.Errorf("case %d: ClosestPoint = %v, want %v", i, got, c.closest)
+		}
+		if d := b.SquareDistance(&c.pt); d != c.sqDist {
+			t.Errorf("case %d: SquareDistance = %v, want %v", i, d, c.sqDist)
+		}
+	}
+}
+
+func TestBoxSegment(t *testing.T) {
+	b := NewBox(Vector{0, 0, 0}, Vector{1, 1, 1})
+	cases := []struct {
+		name   string
+		seg    Segment
+		hit    bool
+		u      float32
+		sqDist float32
+	}{
+		{"through", Segment{Vector{-1, 0.5, 0.5}, Vector{3, 0.5, 0.5}}, true, 0.25, 0},
+		{"start inside", Segment{Vector{0.5, 0.5, 0.5}, Vector{3, 3, 3}}, true, 0, 0},
+		{"parallel outside", Segment{Vector{-1, 2, 0.5}, Vector{3, 2, 0.5}}, false, 0, 1},
+		{"short", Segment{Vector{-3, 0.5, 0.5}, Vector{-2, 0.5, 0.5}}, false, 0, 4},
+		{"edge", Segment{Vector{2, 3, 0.5}, Vector{3, 2, 0.5}}, false, 0, 4.5},
+	}
+	for _, c := range cases {
+		u, ok := b.IntersectSegment(&c.seg)
+		if ok != c.hit || (ok && u != c.u) {
+			t.Errorf("%s: IntersectSegment = %v %v, want %v %v", c.name, u, ok, c.u, c.hit)
+		}
+		if d := b.SegmentSquareDistance(&c.seg); !approx(d, c.sqDist, 1e-5) {
+			t.Errorf("%s: SegmentSquareDistance = %v, want %v", c.name, d, c.sqDist)
+		}
+	}
+}
+
+// 列存储的测试矩阵, 避免引用mat包造成循环依赖
+type testMat struct {
+	cols, rows int
+	data       []float32
+}
+
+func (t *testMat) Cols() int                { return t.cols }
+func (t *testMat) Rows() int                { return t.rows }
+func (t *testMat) Size() int                { return len(t.data) }
+func (t *testMat) Slice() []float32         { return t.data }
+func (t *testMat) Get(col, row int) float32 { return t.data[col*t.rows+row] }
+func (t *testMat) IsZero() bool             { return false }
+
+func (t *testMat) apply(v *Vector) Vector {
+	var r Vector
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i] += t.Get(j, i) * v[j]
+		}
+		if t.cols == 4 {
+			r[i] += t.Get(3, i)
+		}
+	}
+	return r
+}
+
+func TestBoxMetrics(t *testing.T) {
+	b := NewBox(Vector{-1, 0, 2}, Vector{3, 0.5, 4})
+	if s := b.Size(); s != (Vector{4, 0.5, 2}) {
+		t.Errorf("Size = %v", s)
+	}
+	if e := b.Extents(); e != (Vector{2, 0.25, 1}) {
+		t.Errorf("Extents = %v", e)
+	}
+	if v := b.Volume(); v != 4 {
+		t.Errorf("Volume = %v", v)
+	}
+	if a := b.SurfaceArea(); a != 22 {
+		t.Errorf("SurfaceArea = %v", a)
+	}
+	c := b.Corners()
+	want := [8]Vector{{-1, 0, 2}, {3, 0, 2}, {-1, 0.5, 2}, {3, 0.5, 2}, {-1, 0, 4}, {3, 0, 4}, {-1, 0.5, 4}, {3, 0.5, 4}}
+	if c != want {
+		t.Errorf("Corners = %v", c)
+	}
+	if b.IsEmpty() || !NewBox(Vector{0, 1, 0}, Vector{1, 0, 1}).IsEmpty() {
+		t.Errorf("IsEmpty wrong")
+	}
+	if e := b.ExpandedPoint(&Vector{10, -10, 3}); e != (Box{Vector{-1, -10, 2}, Vector{10, 0.5, 4}}) {
+		t.Errorf("ExpandedPoint = %v", e)
+	}
+	if e := b.ExpandedMargin(1); e != (Box{Vector{-2, -1, 1}, Vector{4, 1.5, 5}}) {
+		t.Errorf("ExpandedMargin = %v", e)
+	}
+}
+
+func TestBoxTransformed(t *testing.T) {
+	b := NewBox(Vector{-1, 0, 2}, Vector{3, 0.5, 4})
+	cases := []struct {
+		name string
+		m    *testMat
+	}{
+		// 绕z转90度
+		{"3x3", &testMat{3, 3, []float32{0, 1, 0, -1, 0, 0, 0, 0, 1}}},
+		{"3x4", &testMat{4, 3, []float32{0.6, 0.8, 0, -0.8, 0.6, 0, 0, 0, 2, 1, -2, 5}}},
+		{"4x4", &testMat{4, 4, []float32{1, 0.5, 0, 0, 0, 1, -0.25, 0, 0.3, 0, -1, 0, 1, -2, 5, 1}}},
+	}
+	for _, c := range cases {
+		// 8个顶点变换后的包围盒
+		want := Box{MaxVal, MinVal}
+		for _, p := range b.Corners() {
+			w := c.m.apply(&p)
+			want.ExpandPoint(&w)
+		}
+		got := b.Transformed(c.m)
+		if !got.Min.ApproxEqual(&want.Min, 1e-5) || !got.Max.ApproxEqual(&want.Max, 1e-5) {
+			t.Errorf("%s: Transformed = %v, want %v", c.name, got, want)
+		}
+		l_b := *b
+		if l_b.Transform(c.m); l_b != got {
+			t.Errorf("%s: Transform = %v, want %v", c.name, l_b, got)
+		}
+	}
+
+	for _, m := range []*testMat{{2, 2, make([]float32, 4)}, {3, 4, make([]float32, 12)}, {5, 5, make([]float32, 25)}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%dx%d: Transformed did not panic", m.rows, m.cols)
+				}
+			}()
+			b.Transformed(m)
+		}()
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * 胶囊体  线段 + 半径
+ */
+package vector3
+
+type Capsule struct {
+	A      Vector
+	B      Vector
+	Radius float32
+}
+
+func NewCapsule(a, b Vector, radius float32) *Capsule {
+	return &Capsule{a, b, radius}
+}
+
+// 中轴线段
+func (t *Capsule) Segment() Segment {
+	return Segment{t.A, t.B}
+}
+
+// 点包含
+func (t *Capsule) ContainsPoint(pt *Vector) bool {
+	seg := t.Segment()
+	return seg.SquareDistance(pt) <= t.Radius*t.Radius
+}
+
+// 包围盒
+func (t *Capsule) Bounds() Box {
+	r := Vector{t.Radius, t.Radius, t.Radius}
+	min := Min(&t.A, &t.B)
+	max := Max(&t.A, &t.B)
+	return Box{*min.Sub(&r), *max.Add(&r)}
+}
+
+// 与球相交
+func (t *Capsule) IntersectsSphere(s *Sphere) bool {
+	seg := t.Segment()
+	r := t.Radius + s.Radius
+	return seg.SquareDistance(&s.Center) <= r*r
+}
+
+// 与胶囊体相交
+func (t *Capsule) IntersectsCapsule(o *Capsule) bool {
+	s1 := t.Segment()
+	s2 := o.Segment()
+	r := t.Radius + o.Radius
+	return SegmentSquareDistance(&s1, &s2) <= r*r
+}
+
+// 与box相交
+func (t *Capsule) IntersectsBox(b *Box) bool {
+	seg := t.Segment()
+	return b.SegmentSquareDistance(&seg) <= t.Radius*t.Radius
+}
+
+// 与平面相交
+func (t *Capsule) IntersectsPlane(p *Plane) bool {
+	da := p.SignedDistance(&t.A)
+	db := p.SignedDistance(&t.B)
+	if da*db <= 0 { // 中轴穿过平面
+		return true
+	}
+	if da < 0 {
+		da, db = -da, -db
+	}
+	if db < da {
+		da = db
+	}
+	return da <= t.Radius
+}
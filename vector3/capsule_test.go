@@ -0,0 +1,35 @@
+package vector3
+
+import "testing"
+
+func TestCapsuleIntersects(t *testing.T) {
+	c := NewCapsule(Vector{0, 0, 0}, Vector{0, 2, 0}, 0.5)
+	unit := NewBox(Vector{0, 0, 0}, Vector{1, 1, 1})
+	cases := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"contains cap", c.ContainsPoint(&Vector{0, 2.4, 0}), true},
+		{"outside cap", c.ContainsPoint(&Vector{0.4, 2.4, 0}), false},
+		{"sphere touch", c.IntersectsSphere(NewSphere(Vector{1, 1, 0}, 0.5)), true},
+		{"sphere apart", c.IntersectsSphere(NewSphere(Vector{1.1, 1, 0}, 0.5)), false},
+		{"capsule cross", c.IntersectsCapsule(NewCapsule(Vector{-1, 1, 0.9}, Vector{1, 1, 0.9}, 0.5)), true},
+		{"capsule apart", c.IntersectsCapsule(NewCapsule(Vector{-1, 1, 1.1}, Vector{1, 1, 1.1}, 0.5)), false},
+		{"box", c.IntersectsBox(NewBox(Vector{0.4, 0.5, -1}, Vector{2, 1, 1})), true},
+		{"box apart", c.IntersectsBox(NewBox(Vector{0.6, 0.5, -1}, Vector{2, 1, 1})), false},
+		{"plane crossing", c.IntersectsPlane(NewPlane(UnitY, Vector{0, 1, 0})), true},
+		{"plane below", c.IntersectsPlane(NewPlane(UnitY, Vector{0, -0.6, 0})), false},
+		{"box unit", NewCapsule(Vector{2, 2, 2}, Vector{3, 3, 3}, 1.8).IntersectsBox(unit), true},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	want := Box{Vector{-0.5, -0.5, -0.5}, Vector{0.5, 2.5, 0.5}}
+	if b := c.Bounds(); b != want {
+		t.Errorf("Bounds = %v, want %v", b, want)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * 圆柱体  A,B为两个底面中心
+ */
+package vector3
+
+import "math"
+
+type Cylinder struct {
+	A      Vector
+	B      Vector
+	Radius float32
+}
+
+func NewCylinder(a, b Vector, radius float32) *Cylinder {
+	return &Cylinder{a, b, radius}
+}
+
+// 中轴线段
+func (t *Cylinder) Segment() Segment {
+	return Segment{t.A, t.B}
+}
+
+// 单位轴向及高度
+func (t *Cylinder) axis() (dir Vector, height float32) {
+	dir = Sub(&t.B, &t.A)
+	height = dir.Length()
+	if height > 0 {
+		dir.Scale(1 / height)
+	}
+	return dir, height
+}
+
+// 点包含
+func (t *Cylinder) ContainsPoint(pt *Vector) bool {
+	dir, h := t.axis()
+	ap := Sub(pt, &t.A)
+	u := Dot(&ap, &dir)
+	if u < 0 || u > h {
+		return false
+	}
+	radial := dir.Scaled(u)
+	radial = Sub(&ap, &radial)
+	return radial.LengthSqr() <= t.Radius*t.Radius
+}
+
+// 圆柱体上离pt最近的点  轴向和径向分别截断
+func (t *Cylinder) ClosestPoint(pt *Vector) Vector {
+	dir, h := t.axis()
+	ap := Sub(pt, &t.A)
+	u := Dot(&ap, &dir)
+	axial := dir.Scaled(u)
+	radial := Sub(&ap, &axial)
+
+	if u < 0 {
+		u = 0
+	} else if u > h {
+		u = h
+	}
+	if l := radial.Length(); l > t.Radius {
+		radial.Scale(t.Radius / l)
+	}
+	res := Add(&t.A, dir.Scale(u))
+	return *res.Add(&radial)
+}
+
+// 点到圆柱体距离的平方
+func (t *Cylinder) SquareDistance(pt *Vector) float32 {
+	c := t.ClosestPoint(pt)
+	return SquareDistance(pt, &c)
+}
+
+// 包围盒  底面圆在第i轴上的半长为 r*sqrt(1-dir[i]^2)
+func (t *Cylinder) Bounds() Box {
+	dir, _ := t.axis()
+	var e Vector
+	for i := range e {
+		e[i] = t.Radius * float32(math.Sqrt(math.Max(0, float64(1-dir[i]*dir[i]))))
+	}
+	min := Min(&t.A, &t.B)
+	max := Max(&t.A, &t.B)
+	return Box{*min.Sub(&e), *max.Add(&e)}
+}
+
+// 与球相交
+func (t *Cylinder) IntersectsSphere(s *Sphere) bool {
+	return t.SquareDistance(&s.Center) <= s.Radius*s.Radius
+}
+
+// 与胶囊体相交
+func (t *Cylinder) IntersectsCapsule(c *Capsule) bool {
+	seg := c.Segment()
+	_, _, dist := GJKDistance(t, &seg)
+	return dist <= c.Radius
+}
+
+// 与圆柱体相交
+func (t *Cylinder) IntersectsCylinder(o *Cylinder) bool {
+	return GJKIntersect(t, o)
+}
+
+// 与box相交
+func (t *Cylinder) IntersectsBox(b *Box) bool {
+	return GJKIntersect(t, b)
+}
+
+// 与平面相交  比较两个底面圆在法线上的投影区间
+func (t *Cylinder) IntersectsPlane(p *Plane) bool {
+	dir, _ := t.axis()
+	nd := Dot(&p.Normal, &dir)
+	e := t.Radius * float32(math.Sqrt(math.Max(0, float64(1-nd*nd))))
+	da := p.SignedDistance(&t.A)
+	db := p.SignedDistance(&t.B)
+	lo, hi := da, db
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return lo-e <= 0 && hi+e >= 0
+}
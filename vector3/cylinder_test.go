@@ -0,0 +1,51 @@
+package vector3
+
+import "testing"
+
+func TestCylinderClosestPoint(t *testing.T) {
+	c := NewCylinder(Vector{0, 0, 0}, Vector{0, 2, 0}, 1)
+	cases := []struct {
+		name   string
+		pt     Vector
+		inside bool
+		want   Vector
+	}{
+		{"inside", Vector{0.5, 1, 0}, true, Vector{0.5, 1, 0}},
+		{"side", Vector{3, 1, 0}, false, Vector{1, 1, 0}},
+		{"top", Vector{0, 5, 0.5}, false, Vector{0, 2, 0.5}},
+		{"bottom rim", Vector{0, -1, -3}, false, Vector{0, 0, -1}},
+	}
+	for _, cs := range cases {
+		if in := c.ContainsPoint(&cs.pt); in != cs.inside {
+			t.Errorf("%s: ContainsPoint = %v, want %v", cs.name, in, cs.inside)
+		}
+		if got := c.ClosestPoint(&cs.pt); !got.ApproxEqual(&cs.want, 1e-6) {
+			t.Errorf("%s: ClosestPoint = %v, want %v", cs.name, got, cs.want)
+		}
+	}
+}
+
+func TestCylinderIntersects(t *testing.T) {
+	c := NewCylinder(Vector{0, 0, 0}, Vector{0, 2, 0}, 1)
+	cases := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		// 球心在圆柱角外侧, 与包围胶囊相交但与圆柱不相交
+		{"sphere corner", c.IntersectsSphere(NewSphere(Vector{1.3, 2.3, 0}, 0.4)), false},
+		{"sphere side", c.IntersectsSphere(NewSphere(Vector{1.3, 1, 0}, 0.4)), true},
+		{"capsule", c.IntersectsCapsule(NewCapsule(Vector{-2, 2.2, 0}, Vector{2, 2.2, 0}, 0.3)), true},
+		{"cylinder", c.IntersectsCylinder(NewCylinder(Vector{1.5, 0, 0}, Vector{1.5, 2, 0}, 0.6)), true},
+		{"cylinder apart", c.IntersectsCylinder(NewCylinder(Vector{1.5, 0, 0}, Vector{1.5, 2, 0}, 0.4)), false},
+		{"box", c.IntersectsBox(NewBox(Vector{0.9, 0.5, -0.1}, Vector{2, 1, 0.1})), true},
+		{"box corner", c.IntersectsBox(NewBox(Vector{0.8, 0.5, 0.8}, Vector{2, 1, 2})), false},
+		{"plane tilted", c.IntersectsPlane(NewPlane(Vector{1, 1, 0}, Vector{2, 2, 0})), false},
+		{"plane touching", c.IntersectsPlane(NewPlane(Vector{1, 1, 0}, Vector{1, 2, 0})), true},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * EPA 穿透深度及方向, 在GJK相交结果上扩展多面体
+ */
+package vector3
+
+import "math"
+
+const (
+	kEPAMaxIter   = 64
+	kEPATolerance = 1e-4
+)
+
+type epaFace struct {
+	i, j, k int
+	normal  Vector // 外法线, 单位长度
+	dist    float32
+}
+
+type epaEdge struct {
+	a, b int
+}
+
+// 凸体a,b的穿透信息
+// normal由a指向b, 将b沿normal移动depth即可分离; 不相交或退化时ok为false
+func EPA(a, b Supporter) (normal Vector, depth float32, ok bool) {
+	s, _, hit := runGJK(a, b, false)
+	if !hit {
+		return Zero, 0, false
+	}
+	if !expandToTetrahedron(a, b, &s) {
+		return Zero, 0, false
+	}
+
+	verts := make([]supportPoint, 0, 32)
+	verts = append(verts, s.pts[:4]...)
+	faces := make([]epaFace, 0, 64)
+	for _, f := range [4][4]int{{0, 1, 2, 3}, {0, 3, 1, 2}, {0, 2, 3, 1}, {1, 3, 2, 0}} {
+		face, valid := newEPAFace(verts, f[0], f[1], f[2])
+		if !valid {
+			return Zero, 0, false
+		}
+		// 保证法线背向对顶点
+		opp := Sub(&verts[f[3]].w, &verts[f[0]].w)
+		if Dot(&face.normal, &opp) > 0 {
+			face, _ = newEPAFace(verts, f[0], f[2], f[1])
+		}
+		faces = append(faces, face)
+	}
+
+	for iter := 0; iter < kEPAMaxIter; iter++ {
+		closest := 0
+		for i := 1; i < len(faces); i++ {
+			if faces[i].dist < faces[closest].dist {
+				closest = i
+			}
+		}
+		face := faces[closest]
+
+		w := minkowskiSupport(a, b, &face.normal)
+		if Dot(&w.w, &face.normal)-face.dist < kEPATolerance {
+			return face.normal, face.dist, true
+		}
+
+		// 删除所有朝向新点的面, 并收集边界(horizon)
+		verts = append(verts, w)
+		wi := len(verts) - 1
+		var horizon []epaEdge
+		kept := faces[:0]
+		for _, f := range faces {
+			d := Sub(&w.w, &verts[f.i].w)
+			if Dot(&f.normal, &d) > 0 {
+				horizon = addHorizonEdge(horizon, f.i, f.j)
+				horizon = addHorizonEdge(horizon, f.j, f.k)
+				horizon = addHorizonEdge(horizon, f.k, f.i)
+				continue
+			}
+			kept = append(kept, f)
+		}
+		faces = kept
+
+		for _, e := range horizon {
+			if f, valid := newEPAFace(verts, e.a, e.b, wi); valid {
+				faces = append(faces, f)
+			}
+		}
+		if len(faces) == 0 {
+			return face.normal, face.dist, true
+		}
+	}
+
+	closest := 0
+	for i := 1; i < len(faces); i++ {
+		if faces[i].dist < faces[closest].dist {
+			closest = i
+		}
+	}
+	return faces[closest].normal, faces[closest].dist, true
+}
+
+func newEPAFace(verts []supportPoint, i, j, k int) (epaFace, bool) {
+	ab := Sub(&verts[j].w, &verts[i].w)
+	ac := Sub(&verts[k].w, &verts[i].w)
+	n := Cross(&ab, &ac)
+	l := n.Length()
+	if l <= 1e-12 {
+		return epaFace{}, false
+	}
+	n.Scale(1 / l)
+	d := Dot(&n, &verts[i].w)
+	if d < 0 { // 原点在面上时数值误差可能使其为负
+		d = 0
+	}
+	return epaFace{i, j, k, n, d}, true
+}
+
+// 共享边(反向出现过)说明是内部边, 删除; 否则加入
+func addHorizonEdge(edges []epaEdge, a, b int) []epaEdge {
+	for i := range edges {
+		if edges[i].a == b && edges[i].b == a {
+			edges[i] = edges[len(edges)-1]
+			return edges[:len(edges)-1]
+		}
+	}
+	return append(edges, epaEdge{a, b})
+}
+
+// GJK结束时单纯形可能不足四个点(接触或刚好穿过), 补成包含原点的四面体
+func expandToTetrahedron(a, b Supporter, s *simplex) bool {
+	axes := [6]Vector{UnitX, UnitY, UnitZ, {-1, 0, 0}, {0, -1, 0}, {0, 0, -1}}
+
+	if s.n == 1 {
+		for i := range axes {
+			w := minkowskiSupport(a, b, &axes[i])
+			if SquareDistance(&w.w, &s.pts[0].w) > kGJKTolerance {
+				s.pts[1] = w
+				s.n = 2
+				break
+			}
+		}
+	}
+
+	if s.n == 2 {
+		d := Sub(&s.pts[1].w, &s.pts[0].w)
+		// 取与线段最不平行的轴构造垂直方向, 每60度尝试一次
+		axis := UnitX
+		ad := d.Absed()
+		if ad[1] < ad[0] && ad[1] <= ad[2] {
+			axis = UnitY
+		} else if ad[2] < ad[0] && ad[2] < ad[1] {
+			axis = UnitZ
+		}
+		p := Cross(&d, &axis)
+		p.Normalize()
+		q := Cross(&d, &p)
+		q.Normalize()
+		for i := 0; i < 6; i++ {
+			sa, ca := math.Sincos(float64(i) * math.Pi / 3)
+			pc := p.Scaled(float32(ca))
+			qs := q.Scaled(float32(sa))
+			dir := Add(&pc, &qs)
+			w := minkowskiSupport(a, b, &dir)
+			seg := Segment{s.pts[0].w, s.pts[1].w}
+			if seg.SquareDistance(&w.w) > kGJKTolerance {
+				s.pts[2] = w
+				s.n = 3
+				break
+			}
+		}
+	}
+
+	if s.n == 3 {
+		ab := Sub(&s.pts[1].w, &s.pts[0].w)
+		ac := Sub(&s.pts[2].w, &s.pts[0].w)
+		n := Cross(&ab, &ac)
+		for _, dir := range [2]Vector{n, n.Inverted()} {
+			w := minkowskiSupport(a, b, &dir)
+			aw := Sub(&w.w, &s.pts[0].w)
+			if d := Dot(&aw, &n); d*d > kGJKTolerance*n.LengthSqr() {
+				s.pts[3] = w
+				s.n = 4
+				break
+			}
+		}
+	}
+
+	return s.n == 4
+}
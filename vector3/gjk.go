@@ -0,0 +1,282 @@
+/*
+ * GJK 凸体相交及距离查询
+ */
+package vector3
+
+import "math"
+
+const (
+	kGJKMaxIter   = 64
+	kGJKRelError  = 1e-6  // 收敛判定的相对误差
+	kGJKTolerance = 1e-12 // 距离平方小于该值视为相交
+)
+
+// Minkowski差 a-b 上的支撑点, 同时记录a,b上的原始点用于求最近点对
+type supportPoint struct {
+	w Vector // a - b
+	a Vector
+	b Vector
+}
+
+func minkowskiSupport(a, b Supporter, dir *Vector) supportPoint {
+	pa := a.Support(*dir)
+	pb := b.Support(dir.Inverted())
+	return supportPoint{Sub(&pa, &pb), pa, pb}
+}
+
+// 单纯形, bary为当前最近点的重心坐标
+type simplex struct {
+	pts  [4]supportPoint
+	bary [4]float32
+	n    int
+}
+
+func simplexOf(pts ...supportPoint) simplex {
+	var s simplex
+	s.n = copy(s.pts[:], pts)
+	return s
+}
+
+// 单纯形上离原点最近的点
+func (t *simplex) closest() Vector {
+	var v Vector
+	for i := 0; i < t.n; i++ {
+		w := t.pts[i].w.Scaled(t.bary[i])
+		v.Add(&w)
+	}
+	return v
+}
+
+// a, b上对应的最近点
+func (t *simplex) witness() (pa, pb Vector) {
+	for i := 0; i < t.n; i++ {
+		a := t.pts[i].a.Scaled(t.bary[i])
+		b := t.pts[i].b.Scaled(t.bary[i])
+		pa.Add(&a)
+		pb.Add(&b)
+	}
+	return
+}
+
+func (t *simplex) contains(w *Vector) bool {
+	for i := 0; i < t.n; i++ {
+		if t.pts[i].w == *w {
+			return true
+		}
+	}
+	return false
+}
+
+// 求离原点最近的点并约简为支撑该点的最小子单纯形, 原点在四面体内时返回true
+func (t *simplex) solve() bool {
+	switch t.n {
+	case 1:
+		t.bary[0] = 1
+	case 2:
+		*t = solveSegment(t.pts[0], t.pts[1])
+	case 3:
+		*t = solveTriangle(t.pts[0], t.pts[1], t.pts[2])
+	case 4:
+		var inside bool
+		*t, inside = solveTetrahedron(t.pts[0], t.pts[1], t.pts[2], t.pts[3])
+		return inside
+	}
+	return false
+}
+
+func solveSegment(p0, p1 supportPoint) simplex {
+	ab := Sub(&p1.w, &p0.w)
+	l := Dot(&ab, &ab)
+	var u float32
+	if l > 0 {
+		u = -Dot(&p0.w, &ab) / l
+	}
+	if u <= 0 {
+		s := simplexOf(p0)
+		s.bary[0] = 1
+		return s
+	}
+	if u >= 1 {
+		s := simplexOf(p1)
+		s.bary[0] = 1
+		return s
+	}
+	s := simplexOf(p0, p1)
+	s.bary[0] = 1 - u
+	s.bary[1] = u
+	return s
+}
+
+// 参考 Ericson, Real-Time Collision Detection 5.1.5, 查询点取原点
+func solveTriangle(p0, p1, p2 supportPoint) simplex {
+	a, b, c := &p0.w, &p1.w, &p2.w
+	ab := Sub(b, a)
+	ac := Sub(c, a)
+
+	ap := a.Inverted()
+	d1 := Dot(&ab, &ap)
+	d2 := Dot(&ac, &ap)
+	if d1 <= 0 && d2 <= 0 {
+		s := simplexOf(p0)
+		s.bary[0] = 1
+		return s
+	}
+
+	bp := b.Inverted()
+	d3 := Dot(&ab, &bp)
+	d4 := Dot(&ac, &bp)
+	if d3 >= 0 && d4 <= d3 {
+		s := simplexOf(p1)
+		s.bary[0] = 1
+		return s
+	}
+
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return solveSegment(p0, p1)
+	}
+
+	cp := c.Inverted()
+	d5 := Dot(&ab, &cp)
+	d6 := Dot(&ac, &cp)
+	if d6 >= 0 && d5 <= d6 {
+		s := simplexOf(p2)
+		s.bary[0] = 1
+		return s
+	}
+
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return solveSegment(p0, p2)
+	}
+
+	va := d3*d6 - d5*d4
+	if va <= 0 && (d4-d3) >= 0 && (d5-d6) >= 0 {
+		return solveSegment(p1, p2)
+	}
+
+	sum := va + vb + vc
+	if sum <= 0 { // 退化(共线), 取最近的边
+		best := solveSegment(p0, p1)
+		bv := best.closest()
+		for _, s := range [2]simplex{solveSegment(p0, p2), solveSegment(p1, p2)} {
+			if v := s.closest(); v.LengthSqr() < bv.LengthSqr() {
+				best, bv = s, v
+			}
+		}
+		return best
+	}
+
+	s := simplexOf(p0, p1, p2)
+	s.bary[1] = vb / sum
+	s.bary[2] = vc / sum
+	s.bary[0] = 1 - s.bary[1] - s.bary[2]
+	return s
+}
+
+func solveTetrahedron(p0, p1, p2, p3 supportPoint) (simplex, bool) {
+	pts := [4]supportPoint{p0, p1, p2, p3}
+	// 每个面及其对面的顶点
+	faces := [4][4]int{{0, 1, 2, 3}, {0, 3, 1, 2}, {0, 2, 3, 1}, {1, 3, 2, 0}}
+
+	inside := true
+	var best simplex
+	bestDist := float32(math.MaxFloat32)
+	for _, f := range faces {
+		a, b, c, d := &pts[f[0]].w, &pts[f[1]].w, &pts[f[2]].w, &pts[f[3]].w
+		ab := Sub(b, a)
+		ac := Sub(c, a)
+		n := Cross(&ab, &ac)
+		ad := Sub(d, a)
+		sOpp := Dot(&n, &ad)
+		sOrigin := -Dot(&n, a)
+		// 原点与对顶点不在同侧(或四面体退化)时, 最近点可能在该面上
+		if sOpp != 0 && sOrigin*sOpp >= 0 {
+			continue
+		}
+		inside = false
+		s := solveTriangle(pts[f[0]], pts[f[1]], pts[f[2]])
+		v := s.closest()
+		if l := v.LengthSqr(); l < bestDist {
+			best, bestDist = s, l
+		}
+	}
+
+	if inside {
+		// 原点的重心坐标: 用原点替换对应顶点后的有向体积之比
+		s := simplexOf(p0, p1, p2, p3)
+		a, b, c, d := &p0.w, &p1.w, &p2.w, &p3.w
+		vol := signedVolume(a, b, c, d)
+		s.bary[0] = signedVolume(&Zero, b, c, d) / vol
+		s.bary[1] = signedVolume(a, &Zero, c, d) / vol
+		s.bary[2] = signedVolume(a, b, &Zero, d) / vol
+		s.bary[3] = 1 - s.bary[0] - s.bary[1] - s.bary[2]
+		return s, true
+	}
+	return best, false
+}
+
+// 四面体abcd的有向体积(的6倍)
+func signedVolume(a, b, c, d *Vector) float32 {
+	ab := Sub(b, a)
+	ac := Sub(c, a)
+	ad := Sub(d, a)
+	n := Cross(&ac, &ad)
+	return Dot(&ab, &n)
+}
+
+// 迭代求Minkowski差离原点最近的点, 相交时返回的单纯形包含原点
+// separating为true时找到分离轴即返回
+func runGJK(a, b Supporter, separating bool) (s simplex, v Vector, hit bool) {
+	s = simplexOf(minkowskiSupport(a, b, &UnitX))
+	s.bary[0] = 1
+	v = s.pts[0].w
+
+	for i := 0; i < kGJKMaxIter; i++ {
+		vv := v.LengthSqr()
+		if vv <= kGJKTolerance {
+			return s, v, true
+		}
+
+		dir := v.Inverted()
+		w := minkowskiSupport(a, b, &dir)
+		if separating && Dot(&w.w, &dir) < 0 {
+			return s, v, false
+		}
+		// 没有更近的支撑点, 收敛
+		if vv-Dot(&v, &w.w) <= kGJKRelError*vv || s.contains(&w.w) {
+			return s, v, false
+		}
+
+		// 约简前的单纯形与v对应, 没有进展时返回它
+		l_prev := s
+		s.pts[s.n] = w
+		s.n++
+		if s.solve() {
+			return s, Zero, true
+		}
+
+		nv := s.closest()
+		if nv.LengthSqr() >= vv {
+			return l_prev, v, false
+		}
+		v = nv
+	}
+	return s, v, v.LengthSqr() <= kGJKTolerance
+}
+
+// 凸体a,b是否相交
+func GJKIntersect(a, b Supporter) bool {
+	_, _, hit := runGJK(a, b, true)
+	return hit
+}
+
+// 凸体a,b之间的距离及a,b上的最近点对, 相交时距离为0
+func GJKDistance(a, b Supporter) (pa, pb Vector, dist float32) {
+	s, v, hit := runGJK(a, b, false)
+	pa, pb = s.witness()
+	if hit {
+		return pa, pb, 0
+	}
+	return pa, pb, v.Length()
+}
@@ -0,0 +1,98 @@
+package vector3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGJKDistance(t *testing.T) {
+	unit := NewBox(Vector{0, 0, 0}, Vector{1, 1, 1})
+	cases := []struct {
+		name   string
+		a, b   Supporter
+		dist   float32
+		pa, pb Vector
+	}{
+		{"sphere-sphere", NewSphere(Vector{0, 0, 0}, 1), NewSphere(Vector{5, 0, 0}, 1),
+			3, Vector{1, 0, 0}, Vector{4, 0, 0}},
+		{"sphere-box face", NewSphere(Vector{3, 0.5, 0.5}, 1), unit,
+			1, Vector{2, 0.5, 0.5}, Vector{1, 0.5, 0.5}},
+		{"box-box corner", unit, NewBox(Vector{2, 2, 2}, Vector{3, 3, 3}),
+			float32(math.Sqrt(3)), Vector{1, 1, 1}, Vector{2, 2, 2}},
+		{"box-segment edge", unit, NewSegment(Vector{2, 2, -1}, Vector{2, 2, 2}),
+			float32(math.Sqrt2), Vector{1, 1, 0.5}, Vector{2, 2, 0.5}},
+	}
+	for _, c := range cases {
+		pa, pb, d := GJKDistance(c.a, c.b)
+		if !approx(d, c.dist, 1e-3) {
+			t.Errorf("%s: dist = %v, want %v", c.name, d, c.dist)
+		}
+		// 沿边最近时最近点不唯一, 只检查点对的距离
+		if c.name == "box-segment edge" {
+			if got := Distance(&pa, &pb); !approx(got, c.dist, 1e-3) {
+				t.Errorf("%s: |pa-pb| = %v, want %v", c.name, got, c.dist)
+			}
+			continue
+		}
+		if !pa.ApproxEqual(&c.pa, 1e-3) || !pb.ApproxEqual(&c.pb, 1e-3) {
+			t.Errorf("%s: witness = %v %v, want %v %v", c.name, pa, pb, c.pa, c.pb)
+		}
+		if hit := GJKIntersect(c.a, c.b); hit {
+			t.Errorf("%s: GJKIntersect = true", c.name)
+		}
+	}
+}
+
+func TestGJKOverlapWitness(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b *Box
+	}{
+		// 原点被四面体包围
+		{"contained", NewBox(Vector{0, 0, 0}, Vector{2, 2, 2}), NewBox(Vector{0.5, 0.5, 0.5}, Vector{1.5, 1.5, 1.5})},
+		{"partial", NewBox(Vector{0, 0, 0}, Vector{1, 1, 1}), NewBox(Vector{0.8, 0.1, 0.2}, Vector{1.8, 1.1, 1.2})},
+	}
+	for _, c := range cases {
+		pa, pb, d := GJKDistance(c.a, c.b)
+		if d != 0 || !GJKIntersect(c.a, c.b) {
+			t.Errorf("%s: dist = %v, want overlap", c.name, d)
+		}
+		// 相交时返回两者的一个公共点
+		if !pa.ApproxEqual(&pb, 1e-5) {
+			t.Errorf("%s: witness %v != %v", c.name, pa, pb)
+		}
+		ea, eb := c.a.ExpandedMargin(1e-5), c.b.ExpandedMargin(1e-5)
+		if !ea.ContainsPoint(&pa) || !eb.ContainsPoint(&pa) {
+			t.Errorf("%s: witness %v not inside both boxes", c.name, pa)
+		}
+	}
+}
+
+func TestEPA(t *testing.T) {
+	unit := NewBox(Vector{0, 0, 0}, Vector{1, 1, 1})
+	cases := []struct {
+		name   string
+		a, b   Supporter
+		depth  float32
+		normal Vector
+		tol    float32
+	}{
+		{"box-box", unit, NewBox(Vector{0.8, 0.1, 0.2}, Vector{1.8, 1.1, 1.2}), 0.2, UnitX, 1e-4},
+		{"box-sphere", unit, NewSphere(Vector{0.5, 1.2, 0.5}, 0.5), 0.3, UnitY, 1e-3},
+		{"sphere-sphere", NewSphere(Vector{0, 0, 0}, 1), NewSphere(Vector{0, 0, 1.5}, 1), 0.5, UnitZ, 1e-2},
+	}
+	for _, c := range cases {
+		n, d, ok := EPA(c.a, c.b)
+		if !ok {
+			t.Errorf("%s: EPA failed", c.name)
+			continue
+		}
+		if !approx(d, c.depth, c.tol) || !n.ApproxEqual(&c.normal, c.tol*10) {
+			t.Errorf("%s: EPA = %v %v, want %v %v", c.name, n, d, c.normal, c.depth)
+		}
+	}
+
+	if _, _, ok := EPA(unit, NewSphere(Vector{3, 0, 0}, 1)); ok {
+		t.Errorf("separated: EPA ok = true")
+	}
+}
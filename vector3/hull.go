@@ -0,0 +1,23 @@
+/*
+ * 凸包  以点集表示, 支撑函数只需要顶点
+ */
+package vector3
+
+type Hull []Vector
+
+func NewHull(points ...Vector) Hull {
+	return Hull(points)
+}
+
+// 包围盒
+func (t Hull) Bounds() Box {
+	if len(t) == 0 {
+		return Box{}
+	}
+	b := Box{t[0], t[0]}
+	for i := 1; i < len(t); i++ {
+		b.Min = Min(&b.Min, &t[i])
+		b.Max = Max(&b.Max, &t[i])
+	}
+	return b
+}
@@ -0,0 +1,62 @@
+/*
+ * 有向包围盒
+ */
+package vector3
+
+import "math"
+
+type OBB struct {
+	Center  Vector
+	Axis    [3]Vector // 局部坐标轴, 单位正交
+	Extents Vector    // 各轴半长
+}
+
+func NewOBB(center Vector, axis [3]Vector, extents Vector) *OBB {
+	return &OBB{center, axis, extents}
+}
+
+// 由轴对齐box构造
+func NewOBBFromBox(b *Box) *OBB {
+	e := Sub(&b.Max, &b.Min)
+	return &OBB{b.Center(), [3]Vector{UnitX, UnitY, UnitZ}, *e.Scale(0.5)}
+}
+
+// 点包含
+func (t *OBB) ContainsPoint(pt *Vector) bool {
+	d := Sub(pt, &t.Center)
+	for i := range t.Axis {
+		dist := Dot(&d, &t.Axis[i])
+		if dist > t.Extents[i] || dist < -t.Extents[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// obb上离pt最近的点
+func (t *OBB) ClosestPoint(pt *Vector) Vector {
+	d := Sub(pt, &t.Center)
+	res := t.Center
+	for i := range t.Axis {
+		dist := Dot(&d, &t.Axis[i])
+		if dist > t.Extents[i] {
+			dist = t.Extents[i]
+		} else if dist < -t.Extents[i] {
+			dist = -t.Extents[i]
+		}
+		axis := t.Axis[i].Scaled(dist)
+		res.Add(&axis)
+	}
+	return res
+}
+
+// 点到obb距离的平方
+func (t *OBB) SquareDistance(pt *Vector) float32 {
+	c := t.ClosestPoint(pt)
+	return SquareDistance(pt, &c)
+}
+
+// 点到obb距离
+func (t *OBB) Distance(pt *Vector) float32 {
+	return float32(math.Sqrt(float64(t.SquareDistance(pt))))
+}
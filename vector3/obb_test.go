@@ -0,0 +1,36 @@
+package vector3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOBBClosestPoint(t *testing.T) {
+	// 绕z轴转45度, x半长 sqrt2, y半长 sqrt2/2
+	s := float32(math.Sqrt2) / 2
+	obb := NewOBB(Vector{1, 1, 0}, [3]Vector{{s, s, 0}, {-s, s, 0}, UnitZ}, Vector{2 * s, s, 1})
+	cases := []struct {
+		pt     Vector
+		inside bool
+		want   Vector
+	}{
+		{Vector{1, 1, 0}, true, Vector{1, 1, 0}},
+		{Vector{2, 2, 0.5}, true, Vector{2, 2, 0.5}},
+		{Vector{3, 3, 0}, false, Vector{2, 2, 0}},
+		{Vector{0, 2, 0}, false, Vector{0.5, 1.5, 0}},
+		{Vector{1, 1, 5}, false, Vector{1, 1, 1}},
+	}
+	for i, c := range cases {
+		if in := obb.ContainsPoint(&c.pt); in != c.inside {
+			t.Errorf("case %d: ContainsPoint = %v, want %v", i, in, c.inside)
+		}
+		if got := obb.ClosestPoint(&c.pt); !got.ApproxEqual(&c.want, 1e-5) {
+			t.Errorf("case %d: ClosestPoint = %v, want %v", i, got, c.want)
+		}
+	}
+
+	box := NewOBBFromBox(NewBox(Vector{0, 0, 0}, Vector{2, 4, 6}))
+	if d := box.SquareDistance(&Vector{3, 5, 3}); d != 2 {
+		t.Errorf("NewOBBFromBox SquareDistance = %v, want 2", d)
+	}
+}
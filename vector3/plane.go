@@ -0,0 +1,47 @@
+/*
+ * 平面  Normal·X = D
+ */
+package vector3
+
+type Plane struct {
+	Normal Vector // 单位法线
+	D      float32
+}
+
+// 法线和平面上一点构造, normal会被归一化
+func NewPlane(normal, pt Vector) *Plane {
+	normal.Normalize()
+	return &Plane{normal, Dot(&normal, &pt)}
+}
+
+// 三点构造 (逆时针为正面)
+func NewPlaneFromPoints(a, b, c Vector) *Plane {
+	tri := Triangle{a, b, c}
+	return NewPlane(tri.Normal(), a)
+}
+
+// 有符号距离, >0在法线一侧
+func (t *Plane) SignedDistance(pt *Vector) float32 {
+	return Dot(&t.Normal, pt) - t.D
+}
+
+// 平面上离pt最近的点(投影)
+func (t *Plane) ClosestPoint(pt *Vector) Vector {
+	n := t.Normal.Scaled(t.SignedDistance(pt))
+	return Sub(pt, &n)
+}
+
+// 点到平面距离的平方
+func (t *Plane) SquareDistance(pt *Vector) float32 {
+	d := t.SignedDistance(pt)
+	return d * d
+}
+
+// 点到平面距离
+func (t *Plane) Distance(pt *Vector) float32 {
+	d := t.SignedDistance(pt)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
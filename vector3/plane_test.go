@@ -0,0 +1,34 @@
+package vector3
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/sutil"
+)
+
+func TestPlane(t *testing.T) {
+	p := NewPlaneFromPoints(Vector{0, 1, 0}, Vector{0, 1, 1}, Vector{1, 1, 0})
+	if !p.Normal.ApproxEqual(&UnitY, 1e-6) || p.D != 1 {
+		t.Fatalf("NewPlaneFromPoints = %v", p)
+	}
+	cases := []struct {
+		pt      Vector
+		signed  float32
+		closest Vector
+	}{
+		{Vector{3, 4, 5}, 3, Vector{3, 1, 5}},
+		{Vector{0, -1, 0}, -2, Vector{0, 1, 0}},
+		{Vector{2, 1, 2}, 0, Vector{2, 1, 2}},
+	}
+	for i, c := range cases {
+		if d := p.SignedDistance(&c.pt); d != c.signed {
+			t.Errorf("case %d: SignedDistance = %v, want %v", i, d, c.signed)
+		}
+		if d := p.Distance(&c.pt); d != sutil.Abs(c.signed) {
+			t.Errorf("case %d: Distance = %v", i, d)
+		}
+		if got := p.ClosestPoint(&c.pt); !got.ApproxEqual(&c.closest, 1e-6) {
+			t.Errorf("case %d: ClosestPoint = %v, want %v", i, got, c.closest)
+		}
+	}
+}
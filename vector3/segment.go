@@ -0,0 +1,116 @@
+/*
+ * 线段及最近点查询
+ */
+package vector3
+
+import (
+	"math"
+
+	"github.com/tinysss/smath/sutil"
+)
+
+// 长度平方小于该值的线段视为退化成点
+const kDegenerateSqr = 1e-12
+
+type Segment struct {
+	A Vector
+	B Vector
+}
+
+func NewSegment(a, b Vector) *Segment {
+	return &Segment{a, b}
+}
+
+// B - A
+func (t *Segment) Dir() Vector {
+	return Sub(&t.B, &t.A)
+}
+
+func (t *Segment) Length() float32 {
+	return Distance(&t.A, &t.B)
+}
+
+// 参数 u[0,1] 对应的点
+func (t *Segment) PointAt(u float32) Vector {
+	return Interpolate(&t.A, &t.B, u)
+}
+
+// 线段上离pt最近点的参数 u[0,1]
+func (t *Segment) ClosestParam(pt *Vector) float32 {
+	ab := t.Dir()
+	l := Dot(&ab, &ab)
+	if l <= kDegenerateSqr {
+		return 0
+	}
+	ap := Sub(pt, &t.A)
+	return sutil.Clamp(Dot(&ap, &ab)/l, 0, 1)
+}
+
+// 线段上离pt最近的点
+func (t *Segment) ClosestPoint(pt *Vector) Vector {
+	return t.PointAt(t.ClosestParam(pt))
+}
+
+// 点到线段距离的平方
+func (t *Segment) SquareDistance(pt *Vector) float32 {
+	c := t.ClosestPoint(pt)
+	return SquareDistance(pt, &c)
+}
+
+// 点到线段距离
+func (t *Segment) Distance(pt *Vector) float32 {
+	return float32(math.Sqrt(float64(t.SquareDistance(pt))))
+}
+
+// 两条线段之间的最近点对, 返回a上的点, b上的点及距离平方
+// 参考 Ericson, Real-Time Collision Detection 5.1.9
+func ClosestPointsSegments(a, b *Segment) (pa, pb Vector, sqDist float32) {
+	s, u := closestParamsSegments(a, b)
+	pa = a.PointAt(s)
+	pb = b.PointAt(u)
+	return pa, pb, SquareDistance(&pa, &pb)
+}
+
+// 两条线段之间距离的平方
+func SegmentSquareDistance(a, b *Segment) float32 {
+	_, _, sqDist := ClosestPointsSegments(a, b)
+	return sqDist
+}
+
+// 两条线段之间的最近点参数 s(a上) u(b上)
+func closestParamsSegments(a, b *Segment) (s, u float32) {
+	d1 := a.Dir()
+	d2 := b.Dir()
+	r := Sub(&a.A, &b.A)
+	l1 := Dot(&d1, &d1)
+	l2 := Dot(&d2, &d2)
+	f := Dot(&d2, &r)
+
+	if l1 <= kDegenerateSqr && l2 <= kDegenerateSqr { // 都退化成点
+		return 0, 0
+	}
+	if l1 <= kDegenerateSqr { // a退化成点
+		return 0, sutil.Clamp(f/l2, 0, 1)
+	}
+
+	c := Dot(&d1, &r)
+	if l2 <= kDegenerateSqr { // b退化成点
+		return sutil.Clamp(-c/l1, 0, 1), 0
+	}
+
+	bb := Dot(&d1, &d2)
+	denom := l1*l2 - bb*bb
+	// 平行时任取 s = 0
+	if denom != 0 {
+		s = sutil.Clamp((bb*f-c*l2)/denom, 0, 1)
+	}
+	u = (bb*s + f) / l2
+	if u < 0 {
+		u = 0
+		s = sutil.Clamp(-c/l1, 0, 1)
+	} else if u > 1 {
+		u = 1
+		s = sutil.Clamp((bb-c)/l1, 0, 1)
+	}
+	return s, u
+}
@@ -0,0 +1,69 @@
+package vector3
+
+import "testing"
+
+func TestSegmentClosestPoint(t *testing.T) {
+	seg := NewSegment(Vector{0, 0, 0}, Vector{2, 0, 0})
+	cases := []struct {
+		pt     Vector
+		u      float32
+		sqDist float32
+	}{
+		{Vector{1, 1, 0}, 0.5, 1},
+		{Vector{-1, 0, 0}, 0, 1},
+		{Vector{3, 2, 0}, 1, 5},
+		{Vector{0.5, 0, 0}, 0.25, 0},
+	}
+	for i, c := range cases {
+		if u := seg.ClosestParam(&c.pt); u != c.u {
+			t.Errorf("case %d: ClosestParam = %v, want %v", i, u, c.u)
+		}
+		if d := seg.SquareDistance(&c.pt); d != c.sqDist {
+			t.Errorf("case %d: SquareDistance = %v, want %v", i, d, c.sqDist)
+		}
+	}
+
+	// 退化成点
+	pt := NewSegment(Vector{1, 1, 1}, Vector{1, 1, 1})
+	if u := pt.ClosestParam(&Vector{5, 5, 5}); u != 0 {
+		t.Errorf("degenerate ClosestParam = %v, want 0", u)
+	}
+}
+
+func TestClosestPointsSegments(t *testing.T) {
+	cases := []struct {
+		name   string
+		a, b   Segment
+		pa, pb Vector
+		sqDist float32
+	}{
+		{"cross", Segment{Vector{-1, 0, 0}, Vector{1, 0, 0}}, Segment{Vector{0, -1, 1}, Vector{0, 1, 1}},
+			Vector{0, 0, 0}, Vector{0, 0, 1}, 1},
+		{"endpoint", Segment{Vector{0, 0, 0}, Vector{1, 0, 0}}, Segment{Vector{2, 1, 0}, Vector{2, 3, 0}},
+			Vector{1, 0, 0}, Vector{2, 1, 0}, 2},
+		{"point-segment", Segment{Vector{1, 1, 0}, Vector{1, 1, 0}}, Segment{Vector{0, 0, 0}, Vector{2, 0, 0}},
+			Vector{1, 1, 0}, Vector{1, 0, 0}, 1},
+		{"segment-point", Segment{Vector{0, 0, 0}, Vector{2, 0, 0}}, Segment{Vector{3, 0, 0}, Vector{3, 0, 0}},
+			Vector{2, 0, 0}, Vector{3, 0, 0}, 1},
+		{"touching", Segment{Vector{0, 0, 0}, Vector{2, 2, 0}}, Segment{Vector{0, 2, 0}, Vector{2, 0, 0}},
+			Vector{1, 1, 0}, Vector{1, 1, 0}, 0},
+	}
+	for _, c := range cases {
+		pa, pb, d := ClosestPointsSegments(&c.a, &c.b)
+		if !pa.ApproxEqual(&c.pa, 1e-6) || !pb.ApproxEqual(&c.pb, 1e-6) || !approx(d, c.sqDist, 1e-6) {
+			t.Errorf("%s: got %v %v %v, want %v %v %v", c.name, pa, pb, d, c.pa, c.pb, c.sqDist)
+		}
+	}
+
+	// 平行线段距离与取哪个点无关
+	a := Segment{Vector{0, 0, 0}, Vector{4, 0, 0}}
+	b := Segment{Vector{1, 2, 0}, Vector{3, 2, 0}}
+	if d := SegmentSquareDistance(&a, &b); !approx(d, 4, 1e-6) {
+		t.Errorf("parallel: SegmentSquareDistance = %v, want 4", d)
+	}
+}
+
+func approx(a, b, tol float32) bool {
+	d := a - b
+	return d <= tol && d >= -tol
+}
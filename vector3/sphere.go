@@ -0,0 +1,35 @@
+/*
+ * 球
+ */
+package vector3
+
+type Sphere struct {
+	Center Vector
+	Radius float32
+}
+
+func NewSphere(center Vector, radius float32) *Sphere {
+	return &Sphere{center, radius}
+}
+
+// 点包含
+func (t *Sphere) ContainsPoint(pt *Vector) bool {
+	return SquareDistance(pt, &t.Center) <= t.Radius*t.Radius
+}
+
+// 相交
+func (t *Sphere) Intersects(o *Sphere) bool {
+	r := t.Radius + o.Radius
+	return SquareDistance(&t.Center, &o.Center) <= r*r
+}
+
+// 与box相交
+func (t *Sphere) IntersectsBox(b *Box) bool {
+	return b.SquareDistance(&t.Center) <= t.Radius*t.Radius
+}
+
+// 包围盒
+func (t *Sphere) Bounds() Box {
+	r := Vector{t.Radius, t.Radius, t.Radius}
+	return Box{Sub(&t.Center, &r), Add(&t.Center, &r)}
+}
@@ -0,0 +1,108 @@
+/*
+ * 凸体支撑函数  供GJK/EPA使用
+ */
+package vector3
+
+// 凸体: Support返回dir方向上最远的点 (dir不要求归一化)
+type Supporter interface {
+	Support(dir Vector) Vector
+}
+
+func (t *Box) Support(dir Vector) Vector {
+	var res Vector
+	for i := range dir {
+		if dir[i] >= 0 {
+			res[i] = t.Max[i]
+		} else {
+			res[i] = t.Min[i]
+		}
+	}
+	return res
+}
+
+func (t *OBB) Support(dir Vector) Vector {
+	res := t.Center
+	for i := range t.Axis {
+		axis := t.Axis[i].Scaled(t.Extents[i])
+		if Dot(&dir, &t.Axis[i]) >= 0 {
+			res.Add(&axis)
+		} else {
+			res.Sub(&axis)
+		}
+	}
+	return res
+}
+
+func (t *Sphere) Support(dir Vector) Vector {
+	return Add(&t.Center, radialOffset(&dir, t.Radius))
+}
+
+func (t *Capsule) Support(dir Vector) Vector {
+	var res Vector
+	if Dot(&dir, &t.A) >= Dot(&dir, &t.B) {
+		res = t.A
+	} else {
+		res = t.B
+	}
+	return *res.Add(radialOffset(&dir, t.Radius))
+}
+
+func (t *Cylinder) Support(dir Vector) Vector {
+	axis, _ := t.axis()
+	var res Vector
+	if Dot(&dir, &axis) >= 0 {
+		res = t.B
+	} else {
+		res = t.A
+	}
+	// dir去掉轴向分量后即底面圆上的最远方向
+	ad := axis.Scaled(Dot(&dir, &axis))
+	radial := Sub(&dir, &ad)
+	if radial.IsZero() {
+		return res
+	}
+	return *res.Add(radialOffset(&radial, t.Radius))
+}
+
+func (t *Segment) Support(dir Vector) Vector {
+	if Dot(&dir, &t.A) >= Dot(&dir, &t.B) {
+		return t.A
+	}
+	return t.B
+}
+
+func (t *Triangle) Support(dir Vector) Vector {
+	res := t.A
+	best := Dot(&dir, &t.A)
+	if d := Dot(&dir, &t.B); d > best {
+		res, best = t.B, d
+	}
+	if d := Dot(&dir, &t.C); d > best {
+		res = t.C
+	}
+	return res
+}
+
+func (t Hull) Support(dir Vector) Vector {
+	if len(t) == 0 {
+		return Zero
+	}
+	res := t[0]
+	best := Dot(&dir, &t[0])
+	for i := 1; i < len(t); i++ {
+		if d := Dot(&dir, &t[i]); d > best {
+			res, best = t[i], d
+		}
+	}
+	return res
+}
+
+// dir方向上长度为r的偏移, dir为零向量时取x轴
+func radialOffset(dir *Vector, r float32) *Vector {
+	if dir.IsZero() {
+		res := UnitX.Scaled(r)
+		return &res
+	}
+	res := dir.Normalized()
+	return res.Scale(r)
+}
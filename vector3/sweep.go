@@ -0,0 +1,83 @@
+/*
+ * 连续碰撞(扫掠)检测
+ */
+package vector3
+
+const (
+	kSweepMaxIter   = 32
+	kSweepTolerance = 1e-4
+)
+
+// 平移后的凸体
+type translated struct {
+	shape  Supporter
+	offset Vector
+}
+
+func (t *translated) Support(dir Vector) Vector {
+	p := t.shape.Support(dir)
+	return *p.Add(&t.offset)
+}
+
+// 球沿vel平移一帧, 与box的首次碰撞
+// toi[0,1]为碰撞时刻占vel的比例, normal为碰撞点处由box指向球的单位法线
+func SweepSphereBox(s *Sphere, vel *Vector, b *Box) (toi float32, normal Vector, hit bool) {
+	core := Sphere{s.Center, 0}
+	return sweepRounded(&core, s.Radius, vel, b)
+}
+
+// 球沿vel平移一帧, 与三角形的首次碰撞
+func SweepSphereTriangle(s *Sphere, vel *Vector, tri *Triangle) (toi float32, normal Vector, hit bool) {
+	core := Sphere{s.Center, 0}
+	return sweepRounded(&core, s.Radius, vel, tri)
+}
+
+// 胶囊体沿vel平移一帧, 与box的首次碰撞
+func SweepCapsuleBox(c *Capsule, vel *Vector, b *Box) (toi float32, normal Vector, hit bool) {
+	core := c.Segment()
+	return sweepRounded(&core, c.Radius, vel, b)
+}
+
+// 胶囊体沿vel平移一帧, 与三角形的首次碰撞
+func SweepCapsuleTriangle(c *Capsule, vel *Vector, tri *Triangle) (toi float32, normal Vector, hit bool) {
+	core := c.Segment()
+	return sweepRounded(&core, c.Radius, vel, tri)
+}
+
+// 保守推进: 凸体平移时距离关于时间是凸函数, 按 距离/接近速度 前进不会越过首次接触
+// core为去掉半径后的内核(点或线段), 迭代次数用完仍未收敛到容差内时视为未命中
+func sweepRounded(core Supporter, radius float32, vel *Vector, target Supporter) (toi float32, normal Vector, hit bool) {
+	moving := translated{core, Zero}
+	for i := 0; i < kSweepMaxIter; i++ {
+		moving.offset = vel.Scaled(toi)
+		pa, pb, dist := GJKDistance(&moving, target)
+		if dist <= 0 {
+			// 内核已经穿透, 用EPA求分离方向
+			n, _, ok := EPA(&moving, target)
+			if ok {
+				normal = n.Inverted()
+			} else {
+				normal = vel.Inverted()
+				normal.Normalize()
+			}
+			return toi, normal, true
+		}
+
+		normal = Sub(&pa, &pb)
+		normal.Scale(1 / dist)
+		gap := dist - radius
+		if gap <= kSweepTolerance {
+			return toi, normal, true
+		}
+
+		closing := -Dot(vel, &normal)
+		if closing <= 0 { // 正在远离
+			return 0, Zero, false
+		}
+		toi += gap / closing
+		if toi > 1 {
+			return 0, Zero, false
+		}
+	}
+	return 0, Zero, false
+}
@@ -0,0 +1,46 @@
+package vector3
+
+import "testing"
+
+func TestSweep(t *testing.T) {
+	unit := NewBox(Vector{0, 0, 0}, Vector{1, 1, 1})
+	tri := NewTriangle(Vector{-5, 0, -5}, Vector{-5, 0, 5}, Vector{5, 0, 0})
+	cases := []struct {
+		name   string
+		toi    float32
+		normal Vector
+		hit    bool
+		sweep  func() (float32, Vector, bool)
+	}{
+		{"sphere-box face", 0.5, UnitX, true, func() (float32, Vector, bool) {
+			return SweepSphereBox(NewSphere(Vector{3, 0.5, 0.5}, 1), &Vector{-2, 0, 0}, unit)
+		}},
+		{"sphere-box miss", 0, Zero, false, func() (float32, Vector, bool) {
+			return SweepSphereBox(NewSphere(Vector{3, 3, 0.5}, 1), &Vector{-4, 0, 0}, unit)
+		}},
+		{"sphere-box too short", 0, Zero, false, func() (float32, Vector, bool) {
+			return SweepSphereBox(NewSphere(Vector{5, 0.5, 0.5}, 1), &Vector{-2, 0, 0}, unit)
+		}},
+		{"sphere-box receding", 0, Zero, false, func() (float32, Vector, bool) {
+			return SweepSphereBox(NewSphere(Vector{3, 0.5, 0.5}, 1), &Vector{2, 0, 0}, unit)
+		}},
+		{"sphere-triangle", 0.25, UnitY, true, func() (float32, Vector, bool) {
+			return SweepSphereTriangle(NewSphere(Vector{0, 2, 0}, 1), &Vector{0, -4, 0}, tri)
+		}},
+		{"capsule-box", 0.5, UnitY, true, func() (float32, Vector, bool) {
+			return SweepCapsuleBox(NewCapsule(Vector{-1, 2, 0.5}, Vector{2, 2, 0.5}, 0.5), &Vector{0, -1, 0}, unit)
+		}},
+		{"capsule-triangle", 0.5, UnitY, true, func() (float32, Vector, bool) {
+			return SweepCapsuleTriangle(NewCapsule(Vector{0, 2, 0}, Vector{0, 3, 0}, 1), &Vector{0, -2, 0}, tri)
+		}},
+		{"already touching", 0, UnitX, true, func() (float32, Vector, bool) {
+			return SweepSphereBox(NewSphere(Vector{2, 0.5, 0.5}, 1), &Vector{-1, 0, 0}, unit)
+		}},
+	}
+	for _, c := range cases {
+		toi, n, hit := c.sweep()
+		if hit != c.hit || !approx(toi, c.toi, 1e-3) || !n.ApproxEqual(&c.normal, 1e-3) {
+			t.Errorf("%s: got %v %v %v, want %v %v %v", c.name, toi, n, hit, c.toi, c.normal, c.hit)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * 三角形及最近点查询
+ */
+package vector3
+
+import "math"
+
+type Triangle struct {
+	A Vector
+	B Vector
+	C Vector
+}
+
+func NewTriangle(a, b, c Vector) *Triangle {
+	return &Triangle{a, b, c}
+}
+
+// 法线 (AB x AC), 未归一化
+func (t *Triangle) Normal() Vector {
+	ab := Sub(&t.B, &t.A)
+	ac := Sub(&t.C, &t.A)
+	return Cross(&ab, &ac)
+}
+
+// 面积
+func (t *Triangle) Area() float32 {
+	n := t.Normal()
+	return n.Length() * 0.5
+}
+
+// 重心
+func (t *Triangle) Center() Vector {
+	c := Add(&t.A, &t.B)
+	c.Add(&t.C)
+	return *c.Scale(1.0 / 3.0)
+}
+
+// 三角形上离pt最近的点
+// 参考 Ericson, Real-Time Collision Detection 5.1.5
+func (t *Triangle) ClosestPoint(pt *Vector) Vector {
+	ab := Sub(&t.B, &t.A)
+	ac := Sub(&t.C, &t.A)
+
+	// A 的顶点区域
+	ap := Sub(pt, &t.A)
+	d1 := Dot(&ab, &ap)
+	d2 := Dot(&ac, &ap)
+	if d1 <= 0 && d2 <= 0 {
+		return t.A
+	}
+
+	// B 的顶点区域
+	bp := Sub(pt, &t.B)
+	d3 := Dot(&ab, &bp)
+	d4 := Dot(&ac, &bp)
+	if d3 >= 0 && d4 <= d3 {
+		return t.B
+	}
+
+	// AB 的边区域
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		v := d1 / (d1 - d3)
+		return Add(&t.A, ab.Scale(v))
+	}
+
+	// C 的顶点区域
+	cp := Sub(pt, &t.C)
+	d5 := Dot(&ab, &cp)
+	d6 := Dot(&ac, &cp)
+	if d6 >= 0 && d5 <= d6 {
+		return t.C
+	}
+
+	// AC 的边区域
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		w := d2 / (d2 - d6)
+		return Add(&t.A, ac.Scale(w))
+	}
+
+	// BC 的边区域
+	va := d3*d6 - d5*d4
+	if va <= 0 && (d4-d3) >= 0 && (d5-d6) >= 0 {
+		w := (d4 - d3) / ((d4 - d3) + (d5 - d6))
+		bc := Sub(&t.C, &t.B)
+		return Add(&t.B, bc.Scale(w))
+	}
+
+	// 面内部
+	denom := 1 / (va + vb + vc)
+	v := vb * denom
+	w := vc * denom
+	res := Add(&t.A, ab.Scale(v))
+	return *res.Add(ac.Scale(w))
+}
+
+// 点到三角形距离的平方
+func (t *Triangle) SquareDistance(pt *Vector) float32 {
+	c := t.ClosestPoint(pt)
+	return SquareDistance(pt, &c)
+}
+
+// 点到三角形距离
+func (t *Triangle) Distance(pt *Vector) float32 {
+	return float32(math.Sqrt(float64(t.SquareDistance(pt))))
+}
@@ -0,0 +1,32 @@
+package vector3
+
+import "testing"
+
+func TestTriangleClosestPoint(t *testing.T) {
+	tri := NewTriangle(Vector{0, 0, 0}, Vector{2, 0, 0}, Vector{0, 2, 0})
+	cases := []struct {
+		name string
+		pt   Vector
+		want Vector
+	}{
+		{"vertex A", Vector{-1, -1, 0}, Vector{0, 0, 0}},
+		{"vertex B", Vector{3, -1, 1}, Vector{2, 0, 0}},
+		{"vertex C", Vector{-1, 3, 0}, Vector{0, 2, 0}},
+		{"edge AB", Vector{1, -1, 0}, Vector{1, 0, 0}},
+		{"edge AC", Vector{-1, 1, 0}, Vector{0, 1, 0}},
+		{"edge BC", Vector{2, 2, 0}, Vector{1, 1, 0}},
+		{"face", Vector{0.5, 0.5, 3}, Vector{0.5, 0.5, 0}},
+	}
+	for _, c := range cases {
+		got := tri.ClosestPoint(&c.pt)
+		if !got.ApproxEqual(&c.want, 1e-6) {
+			t.Errorf("%s: ClosestPoint = %v, want %v", c.name, got, c.want)
+		}
+	}
+	if d := tri.Distance(&Vector{0.5, 0.5, -3}); !approx(d, 3, 1e-6) {
+		t.Errorf("Distance = %v, want 3", d)
+	}
+	if a := tri.Area(); a != 2 {
+		t.Errorf("Area = %v, want 2", a)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"math"
 
 	"github.com/tinysss/smath/generic"
+	"github.com/tinysss/smath/sutil"
 )
 
 type Vector [3]float32
@@ -196,6 +197,16 @@ func (t *Vector) Clamped01() Vector {
 	return result
 }
 
+// 各分量在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual)
+func (t *Vector) ApproxEqual(o *Vector, tol float32) bool {
+	for i := range t {
+		if !sutil.AlmostEqual(t[i], o[i], tol, tol) {
+			return false
+		}
+	}
+	return true
+}
+
 func Add(a, b *Vector) Vector {
 	return Vector{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
 }
@@ -0,0 +1,138 @@
+/*
+ * 整数box  Min/Max均为包含的格子坐标, Min>Max的分量表示空
+ */
+package vector3i
+
+import (
+	"github.com/tinysss/smath/vector3"
+)
+
+type Box struct {
+	Min Vector
+	Max Vector
+}
+
+func NewBox(min, max Vector) *Box {
+	return &Box{min, max}
+}
+
+// 覆盖浮点box的所有格子 (格子(x,y,z)占[x,x+1)x[y,y+1)x[z,z+1))
+// 只在边界上接触的格子不算, 即BoxCovering(x.ToBox())还原x; 坐标超出int32范围时截断
+func BoxCovering(b *vector3.Box) Box {
+	return Box{FromVectorFloor(&b.Min), Vector{cellMax(b.Max[0]), cellMax(b.Max[1]), cellMax(b.Max[2])}}
+}
+
+// 格子区域对应的浮点box  [Min, Max+1]
+func (t *Box) ToBox() vector3.Box {
+	return vector3.Box{
+		Min: t.Min.Vector(),
+		Max: vector3.Vector{float32(t.Max[0]) + 1, float32(t.Max[1]) + 1, float32(t.Max[2]) + 1},
+	}
+}
+
+func (t *Box) IsEmpty() bool {
+	return t.Min[0] > t.Max[0] || t.Min[1] > t.Max[1] || t.Min[2] > t.Max[2]
+}
+
+// 每个方向的格子数, 空box为0
+func (t *Box) Size() Vector {
+	if t.IsEmpty() {
+		return Zero
+	}
+	return Vector{t.Max[0] - t.Min[0] + 1, t.Max[1] - t.Min[1] + 1, t.Max[2] - t.Min[2] + 1}
+}
+
+// 格子总数
+func (t *Box) Volume() int64 {
+	if t.IsEmpty() {
+		return 0
+	}
+	return (int64(t.Max[0]) - int64(t.Min[0]) + 1) *
+		(int64(t.Max[1]) - int64(t.Min[1]) + 1) *
+		(int64(t.Max[2]) - int64(t.Min[2]) + 1)
+}
+
+// 点包含
+func (t *Box) ContainsPoint(pt *Vector) bool {
+	return pt[0] >= t.Min[0] && pt[0] <= t.Max[0] &&
+		pt[1] >= t.Min[1] && pt[1] <= t.Max[1] &&
+		pt[2] >= t.Min[2] && pt[2] <= t.Max[2]
+}
+
+// box包含
+func (t *Box) Contains(o *Box) bool {
+	return o.Min[0] >= t.Min[0] && o.Max[0] <= t.Max[0] &&
+		o.Min[1] >= t.Min[1] && o.Max[1] <= t.Max[1] &&
+		o.Min[2] >= t.Min[2] && o.Max[2] <= t.Max[2]
+}
+
+// 相交 (共享格子)
+func (t *Box) Intersects(o *Box) bool {
+	return t.Min[0] <= o.Max[0] && t.Max[0] >= o.Min[0] &&
+		t.Min[1] <= o.Max[1] && t.Max[1] >= o.Min[1] &&
+		t.Min[2] <= o.Max[2] && t.Max[2] >= o.Min[2]
+}
+
+// 相交区域, 不相交时ok=false
+func (t *Box) Intersection(o *Box) (b Box, ok bool) {
+	b = Box{Max(&t.Min, &o.Min), Min(&t.Max, &o.Max)}
+	return b, !b.IsEmpty()
+}
+
+// 合并放大box
+func (t *Box) Join(o *Box) {
+	t.Min = Min(&t.Min, &o.Min)
+	t.Max = Max(&t.Max, &o.Max)
+}
+
+// 合并放大box
+func Joined(a, o *Box) *Box {
+	return &Box{Min(&a.Min, &o.Min), Max(&a.Max, &o.Max)}
+}
+
+// 向四周扩n格, n<0为收缩
+func (t *Box) Expand(n int32) *Box {
+	for i := 0; i < 3; i++ {
+		t.Min[i] -= n
+		t.Max[i] += n
+	}
+	return t
+}
+
+// box内离pt最近的格子
+func (t *Box) ClosestPoint(pt *Vector) Vector {
+	return pt.Clamped(&t.Min, &t.Max)
+}
+
+// x变化最快, 其次y, 最后z的线性下标, 用于平铺数组; pt需在box内
+func (t *Box) Index(pt *Vector) int {
+	w := int(t.Max[0]) - int(t.Min[0]) + 1
+	h := int(t.Max[1]) - int(t.Min[1]) + 1
+	return ((int(pt[2])-int(t.Min[2]))*h+int(pt[1])-int(t.Min[1]))*w + int(pt[0]) - int(t.Min[0])
+}
+
+// Index的逆
+func (t *Box) PointAt(index int) Vector {
+	w := int(t.Max[0]) - int(t.Min[0]) + 1
+	h := int(t.Max[1]) - int(t.Min[1]) + 1
+	return Vector{
+		t.Min[0] + int32(index%w),
+		t.Min[1] + int32(index/w%h),
+		t.Min[2] + int32(index/(w*h)),
+	}
+}
+
+// 按Index顺序遍历所有格子, fn返回false时停止
+// 返回是否遍历完全部格子
+func (t *Box) Cells(fn func(pt Vector) bool) bool {
+	for z := int64(t.Min[2]); z <= int64(t.Max[2]); z++ {
+		for y := int64(t.Min[1]); y <= int64(t.Max[1]); y++ {
+			for x := int64(t.Min[0]); x <= int64(t.Max[0]); x++ {
+				if !fn(Vector{int32(x), int32(y), int32(z)}) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package vector3i
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestBoxCovering(t *testing.T) {
+	cases := []struct {
+		name string
+		b    vector3.Box
+		want Box
+	}{
+		{"mixed", vector3.Box{Min: vector3.Vector{-0.5, 0, 0}, Max: vector3.Vector{1.5, 0.9, 3}}, Box{Vector{-1, 0, 0}, Vector{1, 0, 2}}},
+		// 终点正好在整数上, 只接触的格子不算
+		{"ends on integer", vector3.Box{Min: vector3.Vector{0, 0, 0}, Max: vector3.Vector{1, 2, 3}}, Box{Vector{0, 0, 0}, Vector{0, 1, 2}}},
+		{"clamped", vector3.Box{Min: vector3.Vector{-1e10, 0, 0}, Max: vector3.Vector{1e10, 1, 1}},
+			Box{Vector{math.MinInt32, 0, 0}, Vector{math.MaxInt32, 0, 0}}},
+	}
+	for _, c := range cases {
+		if got := BoxCovering(&c.b); got != c.want {
+			t.Errorf("%s: BoxCovering = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	boxes := []Box{
+		{Vector{0, 0, 0}, Vector{0, 0, 0}},
+		{Vector{-3, 2, -8}, Vector{4, 7, -5}},
+	}
+	for _, b := range boxes {
+		f := b.ToBox()
+		if got := BoxCovering(&f); got != b {
+			t.Errorf("BoxCovering(%v.ToBox()) = %v", b, got)
+		}
+	}
+}
+
+func TestBoxOps(t *testing.T) {
+	b := Box{Vector{0, 0, 0}, Vector{2, 1, 3}}
+	empty := Box{Vector{0, 0, 1}, Vector{0, 0, 0}}
+	if b.Size() != (Vector{3, 2, 4}) || b.Volume() != 24 || b.IsEmpty() {
+		t.Errorf("Size = %v, Volume = %d", b.Size(), b.Volume())
+	}
+	if !empty.IsEmpty() || empty.Size() != Zero || empty.Volume() != 0 {
+		t.Errorf("empty: Size = %v, Volume = %d", empty.Size(), empty.Volume())
+	}
+
+	cases := []struct {
+		o          Box
+		intersects bool
+		inter      Box
+		contains   bool
+	}{
+		{Box{Vector{2, 1, 3}, Vector{5, 5, 5}}, true, Box{Vector{2, 1, 3}, Vector{2, 1, 3}}, false},
+		{Box{Vector{0, 0, 4}, Vector{5, 5, 5}}, false, Box{}, false},
+		{Box{Vector{1, 0, 1}, Vector{2, 1, 2}}, true, Box{Vector{1, 0, 1}, Vector{2, 1, 2}}, true},
+	}
+	for _, c := range cases {
+		if got := b.Intersects(&c.o); got != c.intersects {
+			t.Errorf("Intersects(%v) = %v", c.o, got)
+		}
+		inter, ok := b.Intersection(&c.o)
+		if ok != c.intersects || ok && inter != c.inter {
+			t.Errorf("Intersection(%v) = %v %v, want %v", c.o, inter, ok, c.inter)
+		}
+		if got := b.Contains(&c.o); got != c.contains {
+			t.Errorf("Contains(%v) = %v", c.o, got)
+		}
+	}
+
+	pt := Vector{5, -3, 2}
+	if got := b.ClosestPoint(&pt); got != (Vector{2, 0, 2}) {
+		t.Errorf("ClosestPoint = %v", got)
+	}
+	if e := b; *e.Expand(1) != (Box{Vector{-1, -1, -1}, Vector{3, 2, 4}}) {
+		t.Errorf("Expand = %v", e)
+	}
+}
+
+func TestBoxCells(t *testing.T) {
+	b := Box{Vector{-2, -1, 3}, Vector{1, 2, 4}}
+	i := 0
+	done := b.Cells(func(pt Vector) bool {
+		if !b.ContainsPoint(&pt) || b.Index(&pt) != i || b.PointAt(i) != pt {
+			t.Errorf("cell %d: %v, Index = %d, PointAt = %v", i, pt, b.Index(&pt), b.PointAt(i))
+		}
+		i++
+		return true
+	})
+	if !done || i != 32 || int64(i) != b.Volume() {
+		t.Errorf("Cells visited %d of %d, done = %v", i, b.Volume(), done)
+	}
+
+	n := 0
+	if b.Cells(func(Vector) bool { n++; return n < 5 }) || n != 5 {
+		t.Errorf("stopped Cells visited %d", n)
+	}
+	empty := Box{Vector{1, 0, 0}, Vector{0, 0, 0}}
+	if !empty.Cells(func(Vector) bool { t.Error("empty box has cells"); return true }) {
+		t.Error("empty Cells = false")
+	}
+}
@@ -0,0 +1,143 @@
+package vector3i
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tinysss/smath/vector3"
+)
+
+func TestFromVector(t *testing.T) {
+	nan := float32(math.NaN())
+	cases := []struct {
+		v                  vector3.Vector
+		floor, round, ceil Vector
+	}{
+		{vector3.Vector{0, 0, 0}, Vector{0, 0, 0}, Vector{0, 0, 0}, Vector{0, 0, 0}},
+		{vector3.Vector{-0.5, 1.5, 2.2}, Vector{-1, 1, 2}, Vector{-1, 2, 2}, Vector{0, 2, 3}},
+		// 超出int32范围截断而不回绕
+		{vector3.Vector{1e10, -1e10, nan}, Vector{math.MaxInt32, math.MinInt32, 0},
+			Vector{math.MaxInt32, math.MinInt32, 0}, Vector{math.MaxInt32, math.MinInt32, 0}},
+	}
+	for _, c := range cases {
+		if got := FromVectorFloor(&c.v); got != c.floor {
+			t.Errorf("FromVectorFloor(%v) = %v, want %v", c.v, got, c.floor)
+		}
+		if got := FromVectorRound(&c.v); got != c.round {
+			t.Errorf("FromVectorRound(%v) = %v, want %v", c.v, got, c.round)
+		}
+		if got := FromVectorCeil(&c.v); got != c.ceil {
+			t.Errorf("FromVectorCeil(%v) = %v, want %v", c.v, got, c.ceil)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	v := Vector{3, -4, 12}
+	cases := []struct {
+		o    Vector
+		want bool
+	}{
+		{Vector{3, -4, 12}, true},
+		{Vector{3, -4, -12}, false},
+		{Vector{12, -4, 3}, false},
+	}
+	for _, c := range cases {
+		if got := v.Equal(&c.o); got != c.want {
+			t.Errorf("Equal(%v) = %v, want %v", c.o, got, c.want)
+		}
+	}
+	// 与浮点向量比较先转float
+	if f := v.Vector(); !f.ApproxEqual(&vector3.Vector{3, -4, 12.0001}, 1e-3) {
+		t.Errorf("Vector() = %v", f)
+	}
+	if c := v.Center(); c != (vector3.Vector{3.5, -3.5, 12.5}) {
+		t.Errorf("Center = %v", c)
+	}
+}
+
+func TestDivModFloor(t *testing.T) {
+	cases := []struct {
+		v, div, mod Vector
+		n           int32
+	}{
+		{Vector{0, 15, 16}, Vector{0, 0, 1}, Vector{0, 15, 0}, 16},
+		{Vector{-1, -16, -17}, Vector{-1, -1, -2}, Vector{15, 0, 15}, 16},
+		{Vector{7, -7, 0}, Vector{-3, 2, 0}, Vector{-2, -1, 0}, -3},
+	}
+	for _, c := range cases {
+		if got := c.v.DivFloored(c.n); got != c.div {
+			t.Errorf("%v.DivFloored(%d) = %v, want %v", c.v, c.n, got, c.div)
+		}
+		if got := c.v.ModFloored(c.n); got != c.mod {
+			t.Errorf("%v.ModFloored(%d) = %v, want %v", c.v, c.n, got, c.mod)
+		}
+		back := c.div.Scaled(c.n)
+		if back.Add(&c.mod); back != c.v {
+			t.Errorf("%v: div*n+mod = %v", c.v, back)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	cases := []struct {
+		a, b                      Vector
+		sqr, manhattan, cheb, dot int64
+		cross                     Vector
+	}{
+		{Vector{0, 0, 0}, Vector{2, 3, 6}, 49, 11, 6, 0, Vector{0, 0, 0}},
+		{Vector{1, -2, 3}, Vector{-3, 4, 3}, 52, 10, 6, -2, Vector{-18, -12, -2}},
+		{UnitX, UnitY, 2, 2, 1, 0, UnitZ},
+		// 分量相乘超出int32
+		{Vector{-40000, 0, 0}, Vector{0, 50000, 0}, 4100000000, 90000, 50000, 0, Vector{0, 0, -2000000000}},
+	}
+	for _, c := range cases {
+		if got := SquareDistance(&c.a, &c.b); got != c.sqr {
+			t.Errorf("SquareDistance(%v, %v) = %d, want %d", c.a, c.b, got, c.sqr)
+		}
+		if got := Manhattan(&c.a, &c.b); got != c.manhattan {
+			t.Errorf("Manhattan(%v, %v) = %d, want %d", c.a, c.b, got, c.manhattan)
+		}
+		if got := Chebyshev(&c.a, &c.b); got != c.cheb {
+			t.Errorf("Chebyshev(%v, %v) = %d, want %d", c.a, c.b, got, c.cheb)
+		}
+		if got := Dot(&c.a, &c.b); got != c.dot {
+			t.Errorf("Dot(%v, %v) = %d, want %d", c.a, c.b, got, c.dot)
+		}
+		if got := Cross(&c.a, &c.b); got != c.cross {
+			t.Errorf("Cross(%v, %v) = %v, want %v", c.a, c.b, got, c.cross)
+		}
+	}
+}
+
+// 分量差超出int32时不能回绕
+func TestDistanceExtremes(t *testing.T) {
+	cases := []struct {
+		a, b Vector
+		sqr  int64
+		dist float64
+	}{
+		{Vector{-1.5e9, 0, 0}, Vector{1.5e9, 0, 0}, 9e18, 3e9},
+		{Vector{0, -2e9, 0}, Vector{0, 1e9, 0}, 9e18, 3e9},
+		// 平方和超出int64时饱和, Distance仍然正确
+		{Vector{-2e9, 0, 0}, Vector{2e9, 0, 0}, math.MaxInt64, 4e9},
+		{Vector{math.MinInt32, 0, 0}, Vector{math.MaxInt32, 0, 0}, math.MaxInt64, 4294967295},
+		{Vector{-1.5e9, -1.5e9, 0}, Vector{1.5e9, 1.5e9, 0}, math.MaxInt64, 3e9 * math.Sqrt2},
+		{Vector{math.MinInt32, math.MinInt32, 0}, Vector{math.MaxInt32, math.MaxInt32, 0}, math.MaxInt64, 4294967295 * math.Sqrt2},
+	}
+	for _, c := range cases {
+		if got := SquareDistance(&c.a, &c.b); got != c.sqr {
+			t.Errorf("SquareDistance(%v, %v) = %d, want %d", c.a, c.b, got, c.sqr)
+		}
+		if got := Distance(&c.a, &c.b); math.Abs(float64(got)-c.dist) > c.dist*1e-7 {
+			t.Errorf("Distance(%v, %v) = %v, want %v", c.a, c.b, got, c.dist)
+		}
+	}
+	// 长度平方饱和, 长度仍然正确
+	if got := MinVal.LengthSqr(); got != math.MaxInt64 {
+		t.Errorf("MinVal.LengthSqr() = %d, want MaxInt64", got)
+	}
+	if got, want := MinVal.Length(), float32(math.Sqrt(3)*2147483648); got != want {
+		t.Errorf("MinVal.Length() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * 齐次坐标的点和方向  按仿射规则运算, 类型上区分, 不做隐式透视除法
+ *   点+方向=点, 点-点=方向, 方向+方向=方向; 点+点无定义, 不提供
+ */
+package vector4
+
+import (
+	"github.com/tinysss/smath/vector3"
+)
+
+// W != 0, 通常为1
+type Point4 Vector
+
+// W == 0
+type Direction4 Vector
+
+func NewPoint4(x, y, z float32) Point4 {
+	return Point4{x, y, z, 1}
+}
+
+func NewDirection4(x, y, z float32) Direction4 {
+	return Direction4{x, y, z, 0}
+}
+
+func PointFromVec3(v *vector3.Vector) Point4 {
+	return Point4{v[0], v[1], v[2], 1}
+}
+
+func DirectionFromVec3(v *vector3.Vector) Direction4 {
+	return Direction4{v[0], v[1], v[2], 0}
+}
+
+//-------------------------------------------- Point4 ------------------------------------------------
+
+func (t *Point4) Vector() Vector {
+	return Vector(*t)
+}
+
+// 笛卡尔坐标, W不为1时除以W
+func (t *Point4) Vec3() vector3.Vector {
+	return (*Vector)(t).Vec3DividedByW()
+}
+
+// 规范化为W=1
+func (t *Point4) Normalized() Point4 {
+	v := t.Vec3()
+	return PointFromVec3(&v)
+}
+
+// 点沿方向平移
+func (t *Point4) Translate(d *Direction4) *Point4 {
+	*t = t.Translated(d)
+	return t
+}
+
+func (t *Point4) Translated(d *Direction4) Point4 {
+	if t[3] == 1 {
+		return Point4{t[0] + d[0], t[1] + d[1], t[2] + d[2], 1}
+	}
+	v := t.Vec3()
+	return Point4{v[0] + d[0], v[1] + d[1], v[2] + d[2], 1}
+}
+
+// b指向a的方向 a-b
+func SubPoints(a, b *Point4) Direction4 {
+	if a[3] == 1 && b[3] == 1 {
+		return Direction4{a[0] - b[0], a[1] - b[1], a[2] - b[2], 0}
+	}
+	a3, b3 := a.Vec3(), b.Vec3()
+	return Direction4{a3[0] - b3[0], a3[1] - b3[1], a3[2] - b3[2], 0}
+}
+
+func PointDistance(a, b *Point4) float32 {
+	d := SubPoints(a, b)
+	return d.Length()
+}
+
+// 仿射组合 a*(1-t) + b*t
+func LerpPoints(a, b *Point4, t float32) Point4 {
+	d := SubPoints(b, a)
+	d.Scale(t)
+	return a.Translated(&d)
+}
+
+//-------------------------------------------- Direction4 ------------------------------------------------
+
+func (t *Direction4) Vector() Vector {
+	return Vector(*t)
+}
+
+func (t *Direction4) Vec3() vector3.Vector {
+	return vector3.Vector{t[0], t[1], t[2]}
+}
+
+func (t *Direction4) Add(d *Direction4) *Direction4 {
+	t[0] += d[0]
+	t[1] += d[1]
+	t[2] += d[2]
+	return t
+}
+
+func (t *Direction4) Sub(d *Direction4) *Direction4 {
+	t[0] -= d[0]
+	t[1] -= d[1]
+	t[2] -= d[2]
+	return t
+}
+
+func (t *Direction4) Scale(f float32) *Direction4 {
+	t[0] *= f
+	t[1] *= f
+	t[2] *= f
+	return t
+}
+
+func (t *Direction4) Scaled(f float32) Direction4 {
+	return Direction4{t[0] * f, t[1] * f, t[2] * f, 0}
+}
+
+func (t *Direction4) LengthSqr() float32 {
+	return t[0]*t[0] + t[1]*t[1] + t[2]*t[2]
+}
+
+func (t *Direction4) Length() float32 {
+	v := t.Vec3()
+	return v.Length()
+}
+
+// 零向量保持不变
+func (t *Direction4) Normalize() *Direction4 {
+	l := t.Length()
+	if l == 0 {
+		return t
+	}
+	return t.Scale(1 / l)
+}
+
+func (t *Direction4) Normalized() Direction4 {
+	r := *t
+	return *r.Normalize()
+}
+
+func DotDirections(a, b *Direction4) float32 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func CrossDirections(a, b *Direction4) Direction4 {
+	return Direction4(CrossXYZ((*Vector)(a), (*Vector)(b)))
+}
@@ -0,0 +1,140 @@
+package vector4
+
+import (
+	"testing"
+
+	"github.com/tinysss/smath/sutil"
+	"github.com/tinysss/smath/vector3"
+)
+
+func finite(v *Vector) bool {
+	for _, f := range v {
+		if !sutil.IsFinite(f) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPoint4(t *testing.T) {
+	p := NewPoint4(1, 2, 3)
+	q := Point4{2, 4, 6, 2} // 与p为同一点
+	d := NewDirection4(0, 1, 0)
+	cases := []struct {
+		name      string
+		got, want Vector
+	}{
+		{"Normalized", Vector(q.Normalized()), Vector{1, 2, 3, 1}},
+		{"SubPoints same", Vector(SubPoints(&q, &p)), Vector{0, 0, 0, 0}},
+		{"SubPoints", Vector(SubPoints(&Point4{4, 6, 3, 1}, &p)), Vector{3, 4, 0, 0}},
+		{"SubPoints w!=1", Vector(SubPoints(&Point4{8, 12, 6, 2}, &p)), Vector{3, 4, 0, 0}},
+		{"Translated", Vector(p.Translated(&d)), Vector{1, 3, 3, 1}},
+		{"Translated w!=1", Vector(q.Translated(&d)), Vector{1, 3, 3, 1}},
+		{"LerpPoints", Vector(LerpPoints(&p, &Point4{3, 2, 3, 1}, 0.5)), Vector{2, 2, 3, 1}},
+		{"LerpPoints w!=1", Vector(LerpPoints(&q, &Point4{6, 4, 6, 2}, 0.5)), Vector{2, 2, 3, 1}},
+	}
+	for _, c := range cases {
+		if !c.got.ApproxEqual(&c.want, 1e-6) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if dist := PointDistance(&p, &Point4{1, 2, 7, 1}); dist != 4 {
+		t.Errorf("PointDistance = %v, want 4", dist)
+	}
+	p.Translate(&d)
+	if p != NewPoint4(1, 3, 3) {
+		t.Errorf("Translate = %v", p)
+	}
+	v3 := vector3.Vector{1, 2, 3}
+	if PointFromVec3(&v3) != NewPoint4(1, 2, 3) || DirectionFromVec3(&v3) != NewDirection4(1, 2, 3) {
+		t.Errorf("FromVec3")
+	}
+	if got := q.Vec3(); got != v3 {
+		t.Errorf("Point4.Vec3 = %v", got)
+	}
+}
+
+func TestDirection4(t *testing.T) {
+	a := NewDirection4(3, 0, 4)
+	b := NewDirection4(0, 1, 0)
+	sum, diff := a, a
+	sum.Add(&b)
+	diff.Sub(&b)
+	cases := []struct {
+		name      string
+		got, want Direction4
+	}{
+		{"Add", sum, Direction4{3, 1, 4, 0}},
+		{"Sub", diff, Direction4{3, -1, 4, 0}},
+		{"Scaled", a.Scaled(2), Direction4{6, 0, 8, 0}},
+		{"Normalized", a.Normalized(), Direction4{0.6, 0, 0.8, 0}},
+		{"Normalized zero", (&Direction4{}).Normalized(), Direction4{}},
+		{"Cross", CrossDirections(&a, &b), Direction4{-4, 0, 3, 0}},
+	}
+	for _, c := range cases {
+		got, want := c.got.Vector(), c.want.Vector()
+		if !got.ApproxEqual(&want, 1e-6) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if l := a.Length(); l != 5 {
+		t.Errorf("Length = %v", l)
+	}
+	if l := a.LengthSqr(); l != 25 {
+		t.Errorf("LengthSqr = %v", l)
+	}
+	if d := DotDirections(&a, &Direction4{1, 1, 1, 0}); d != 7 {
+		t.Errorf("DotDirections = %v", d)
+	}
+}
+
+// W=0不做除法, 结果不出现Inf/NaN
+func TestDirectionW0(t *testing.T) {
+	p := Vector{1, 2, 3, 1}
+	q := Vector{2, 4, 6, 2}
+	d := Vector{1, 0, 0, 0}
+	cases := []struct {
+		name      string
+		got, want Vector
+	}{
+		{"Add point dir", Add(&p, &d), Vector{2, 2, 3, 1}},
+		{"Add dir point", Add(&d, &p), Vector{2, 2, 3, 1}},
+		{"Add w=2 dir", Add(&q, &d), Vector{2, 2, 3, 1}},
+		{"Sub point dir", Sub(&p, &d), Vector{0, 2, 3, 1}},
+		{"Add dir dir", Add(&d, &d), Vector{2, 0, 0, 0}},
+		{"DividedByW", d.DividedByW(), d},
+		{"Scaled keeps w", d.Scaled(2), Vector{2, 0, 0, 0}},
+		{"Inverted keeps w", p.Inverted(), Vector{-1, -2, -3, 1}},
+		{"Normalized dir", (&Vector{0, 3, 4, 0}).Normalized(), Vector{0, 0.6, 0.8, 0}},
+		{"CrossXYZ", CrossXYZ(&d, &q), Vector{0, -6, 4, 0}},
+	}
+	for _, c := range cases {
+		if !finite(&c.got) || !c.got.ApproxEqual(&c.want, 1e-6) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	m := d
+	m.Add(&p)
+	if want := (Vector{2, 2, 3, 1}); m != want {
+		t.Errorf("dir.Add(point) = %v, want %v", m, want)
+	}
+	m = p
+	m.Sub(&d)
+	if want := (Vector{0, 2, 3, 1}); m != want {
+		t.Errorf("point.Sub(dir) = %v, want %v", m, want)
+	}
+
+	if l := d.Length(); l != 1 {
+		t.Errorf("direction Length = %v", l)
+	}
+	if v := d.Vec3DividedByW(); v != (vector3.Vector{1, 0, 0}) {
+		t.Errorf("Vec3DividedByW = %v", v)
+	}
+	if got := DotXYZ(&q, &d); got != 2 {
+		t.Errorf("DotXYZ = %v, want 2 (no divide)", got)
+	}
+	if !d.IsDirection() || d.IsPoint() || !p.IsPoint() || p.IsDirection() {
+		t.Errorf("IsDirection/IsPoint")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"math"
 
 	"github.com/tinysss/smath/generic"
+	"github.com/tinysss/smath/sutil"
 	"github.com/tinysss/smath/vector3"
 )
 
@@ -84,14 +85,23 @@ func (t *Vector) W() float32 {
 	return t[3]
 }
 
+// W为0视为方向, 否则为点
+func (t *Vector) IsDirection() bool {
+	return t[3] == 0
+}
+
+func (t *Vector) IsPoint() bool {
+	return t[3] != 0
+}
+
 func (t *Vector) Length() float32 {
-	v4 := t.DividedByW()
-	return v4.Length()
+	v3 := t.Vec3DividedByW()
+	return v3.Length()
 }
 
 func (t *Vector) LengthSqr() float32 {
-	v4 := t.DividedByW()
-	return v4.LengthSqr()
+	v3 := t.Vec3DividedByW()
+	return v3.LengthSqr()
 }
 
 // 缩放自身
@@ -104,7 +114,7 @@ func (t *Vector) Scale(ratio float32) *Vector {
 
 // 返回缩放自身的拷贝，自身不受影响
 func (t *Vector) Scaled(ratio float32) Vector {
-	return Vector{t[0] * ratio, t[1] * ratio, t[2] * ratio}
+	return Vector{t[0] * ratio, t[1] * ratio, t[2] * ratio, t[3]}
 }
 
 // 逆暂且求相反向量
@@ -117,7 +127,7 @@ func (t *Vector) Invert(ratio float32) *Vector {
 
 // 返回逆自身的拷贝，自身不受影响
 func (t *Vector) Inverted() Vector {
-	return Vector{-t[0], -t[1], -t[2]}
+	return Vector{-t[0], -t[1], -t[2], t[3]}
 }
 
 // 使用vector3 归一化
@@ -127,7 +137,9 @@ func (t *Vector) Normalize() *Vector {
 	t[0] = v3[0]
 	t[1] = v3[1]
 	t[2] = v3[2]
-	t[3] = 1
+	if t[3] != 0 {
+		t[3] = 1
+	}
 	return t
 }
 
@@ -145,9 +157,9 @@ func (t *Vector) Normal() Vector {
 	return Vector{n3[0], n3[1], n3[2], 1}
 }
 
-// 根据W分量取值, 自身
+// 根据W分量取值, 自身; W为0(方向)时不做除法
 func (t *Vector) DivideByW() *Vector {
-	if t[3] == 1 {
+	if t[3] == 1 || t[3] == 0 {
 		return t
 	}
 	s := 1 / t[3]
@@ -158,18 +170,18 @@ func (t *Vector) DivideByW() *Vector {
 	return t
 }
 
-// 根据W分量取值， 拷贝
+// 根据W分量取值， 拷贝; W为0(方向)时不做除法
 func (t *Vector) DividedByW() Vector {
-	if t[3] == 1 {
+	if t[3] == 1 || t[3] == 0 {
 		return *t
 	}
 	s := 1 / t[3]
 	return Vector{t[0] * s, t[1] * s, t[2] * s, 1}
 }
 
-// 根据W分量取值， vector3拷贝
+// 根据W分量取值， vector3拷贝; W为0(方向)时直接取xyz
 func (t *Vector) Vec3DividedByW() vector3.Vector {
-	if t[3] == 1 {
+	if t[3] == 1 || t[3] == 0 {
 		return vector3.Vector{t[0], t[1], t[2]}
 	}
 	s := 1 / t[3]
@@ -202,6 +214,7 @@ func (t *Vector) Add(v *Vector) *Vector {
 	t[0] += v3[0]
 	t[1] += v3[1]
 	t[2] += v3[2]
+	t[3] = 1 // W不同时至少一方为点, 结果为点
 	return t
 }
 
@@ -218,6 +231,7 @@ func (t *Vector) Sub(v *Vector) *Vector {
 	t[0] -= v3[0]
 	t[1] -= v3[1]
 	t[2] -= v3[2]
+	t[3] = 1 // W不同时至少一方为点, 结果为点
 	return t
 }
 
@@ -248,6 +262,16 @@ func (t *Vector) Clamped01() Vector {
 	return result
 }
 
+// 各分量在误差tol内相等 (绝对误差或相对误差, 见sutil.AlmostEqual)
+func (t *Vector) ApproxEqual(o *Vector, tol float32) bool {
+	for i := range t {
+		if !sutil.AlmostEqual(t[i], o[i], tol, tol) {
+			return false
+		}
+	}
+	return true
+}
+
 func Add(a, b *Vector) Vector {
 	if a[3] == b[3] {
 		return Vector{a[0] + b[0], a[1] + b[1], a[2] + b[2], a[3]}
@@ -276,6 +300,11 @@ func Dot(a, b *Vector) float32 {
 	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] + a[3]*b[3]
 }
 
+// 只取xyz点积, 不做透视除法
+func DotXYZ(a, b *Vector) float32 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
 func Cross(a, b *Vector) Vector {
 	a3 := a.Vec3DividedByW()
 	b3 := b.Vec3DividedByW()
@@ -283,6 +312,16 @@ func Cross(a, b *Vector) Vector {
 	return Vector{c3[0], c3[1], c3[2], 1}
 }
 
+// 只取xyz叉积, 不做透视除法, 结果为方向(W=0)
+func CrossXYZ(a, b *Vector) Vector {
+	return Vector{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+		0,
+	}
+}
+
 // a,b夹角  [0,pi]
 // a·b=|a|·|b|·cosθ
 func Angle(a, b *Vector) float32 {